@@ -0,0 +1,91 @@
+// Command import applies a team import file directly to the database. With
+// -reconcile the file is the full desired state of the organization.
+package main
+
+import (
+	"avito-autumn-2025/internal/config"
+	"avito-autumn-2025/internal/importer"
+	"avito-autumn-2025/internal/logger"
+	db "avito-autumn-2025/internal/postgres"
+	"avito-autumn-2025/internal/service/team"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	path := flag.String("file", "", "path to a JSON, YAML or CSV import file")
+	format := flag.String("format", "", "file format: json, yaml or csv (default: by extension)")
+	dryRun := flag.Bool("dry-run", false, "report the changes without applying them")
+	reconcile := flag.Bool("reconcile", false, "deactivate and remove everything missing from the file")
+	flag.Parse()
+
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "Usage: import -file <path> [-format json|yaml|csv] [-dry-run] [-reconcile]")
+		os.Exit(2)
+	}
+
+	fileFormat := importer.Format(*format)
+	if fileFormat == "" {
+		var err error
+		if fileFormat, err = importer.FormatFromPath(*path); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to detect format: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open import file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	imp, err := importer.Parse(fileFormat, file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse import file: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	stdLogger := logger.NewStdLogger()
+	dbPool, err := db.ConnectPostgres(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer dbPool.Close()
+
+	teamStorage := postgres.NewTeamStorage(dbPool, stdLogger)
+	teamSvc := team.NewTeamService(&teamStorage, stdLogger)
+
+	apply := teamSvc.ImportTeams
+	if *reconcile {
+		apply = teamSvc.ReconcileTeams
+	}
+
+	result, err := apply(context.Background(), imp, *dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to import teams: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to print result: %v\n", err)
+		os.Exit(1)
+	}
+
+	if result.Failed() {
+		os.Exit(1)
+	}
+}
@@ -1,10 +1,24 @@
 package main
 
 import (
+	"avito-autumn-2025/internal/auth"
 	"avito-autumn-2025/internal/config"
+	"avito-autumn-2025/internal/graphql"
+	grpcserver "avito-autumn-2025/internal/grpc/server"
 	"avito-autumn-2025/internal/http/server"
+	"avito-autumn-2025/internal/ldap"
 	"avito-autumn-2025/internal/logger"
 	db "avito-autumn-2025/internal/postgres"
+	"avito-autumn-2025/internal/service/directory"
+	"avito-autumn-2025/internal/service/idempotency"
+	"avito-autumn-2025/internal/service/pull_request"
+	"avito-autumn-2025/internal/service/repository"
+	"avito-autumn-2025/internal/service/scim"
+	"avito-autumn-2025/internal/service/sla"
+	"avito-autumn-2025/internal/service/stale"
+	"avito-autumn-2025/internal/service/team"
+	"avito-autumn-2025/internal/service/user"
+	"avito-autumn-2025/internal/storage/postgres"
 	"context"
 	"fmt"
 	"os"
@@ -22,7 +36,7 @@ func main() {
 	}
 
 	stdLogger := logger.NewStdLogger()
-	stdLogger.Info("Starting reviewer service", "port", cfg.Port, "host", cfg.Host)
+	stdLogger.Info("Starting reviewer service", "port", cfg.Port, "grpc_port", cfg.GRPCPort, "host", cfg.Host)
 
 	dbPool, err := db.ConnectPostgres(cfg)
 	if err != nil {
@@ -45,9 +59,69 @@ func main() {
 	}
 	stdLogger.Info("Migrations applied successfully", "migrations_path", migrationsPath)
 
-	srv := server.NewServer(dbPool, stdLogger)
+	var authenticator *auth.Authenticator
+	if cfg.AuthInsecure {
+		stdLogger.Warn("Authentication disabled by AUTH_INSECURE, every caller has admin rights")
+	} else {
+		authenticator = auth.NewAuthenticator(cfg.AuthSecret, cfg.AuthAdmins)
+		stdLogger.Info("Authentication enabled", "admins_count", len(cfg.AuthAdmins))
+	}
+
+	userStorage := postgres.NewUserStorage(dbPool, stdLogger)
+	teamStorage := postgres.NewTeamStorage(dbPool, stdLogger)
+	repoStorage := postgres.NewRepositoryStorage(dbPool, stdLogger)
+	prStorage := postgres.NewPullRequestStorage(dbPool, stdLogger)
+	idempotencyStorage := postgres.NewIdempotencyStorage(dbPool, stdLogger)
+
+	// The HTTP and gRPC servers share the same service instances
+	userSvc := user.NewUserService(&userStorage, stdLogger)
+	teamSvc := team.NewTeamService(&teamStorage, stdLogger)
+	repoSvc := repository.NewRepositoryService(&repoStorage, &teamStorage, stdLogger)
+	prSvc := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, stdLogger)
+	scimSvc := scim.NewSCIMService(&userStorage, &teamStorage, stdLogger)
+
+	srv := server.NewServer(server.Services{
+		Users:        &userSvc,
+		Teams:        &teamSvc,
+		Repositories: &repoSvc,
+		PullRequests: prSvc,
+		SCIM:         &scimSvc,
+		GraphQL:      graphql.NewExecutor(&userStorage, &teamStorage, &prStorage, stdLogger),
+		Idempotency:  &idempotencyStorage,
+	}, stdLogger, authenticator, cfg.IdempotencyKeyTTL)
 	srv.SetupRoutes()
 
+	grpcSrv := grpcserver.NewServer(&userSvc, &teamSvc, prSvc, stdLogger, authenticator)
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	slaWorker := sla.NewWorker(&prStorage, cfg.SLACheckInterval, stdLogger)
+	go slaWorker.Run(workerCtx)
+
+	staleWorker := stale.NewWorker(&prStorage, cfg.StaleCheckInterval, stdLogger)
+	go staleWorker.Run(workerCtx)
+
+	idempotencyWorker := idempotency.NewWorker(&idempotencyStorage, cfg.IdempotencyKeyTTL, cfg.IdempotencyPurgeInterval, stdLogger)
+	go idempotencyWorker.Run(workerCtx)
+
+	if cfg.LDAPSyncEnabled {
+		ldapDirectory := ldap.NewDirectory(ldap.Config{
+			URL:             cfg.LDAPURL,
+			BindDN:          cfg.LDAPBindDN,
+			BindPassword:    cfg.LDAPBindPassword,
+			BaseDN:          cfg.LDAPBaseDN,
+			UserFilter:      cfg.LDAPUserFilter,
+			GroupFilter:     cfg.LDAPGroupFilter,
+			UserIdAttr:      cfg.LDAPUserIdAttr,
+			UsernameAttr:    cfg.LDAPUsernameAttr,
+			GroupNameAttr:   cfg.LDAPGroupNameAttr,
+			GroupMemberAttr: cfg.LDAPGroupMemberAttr,
+		}, stdLogger)
+		directoryWorker := directory.NewWorker(ldapDirectory, &teamStorage, cfg.LDAPSyncInterval, cfg.LDAPAdoptUsers, stdLogger)
+		go directoryWorker.Run(workerCtx)
+	}
+
 	go func() {
 		addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 		stdLogger.Info("Starting HTTP server", "address", addr)
@@ -57,10 +131,19 @@ func main() {
 		}
 	}()
 
+	go func() {
+		addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.GRPCPort)
+		if err := grpcSrv.Run(addr); err != nil {
+			stdLogger.Error("Failed to start gRPC server", "error", err)
+			os.Exit(1)
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	stdLogger.Info("Shutting down server...")
+	stopWorkers()
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -68,6 +151,9 @@ func main() {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		stdLogger.Error("Server forced to shutdown", "error", err)
 	}
+	if err := grpcSrv.Shutdown(shutdownCtx); err != nil {
+		stdLogger.Error("gRPC server forced to shutdown", "error", err)
+	}
 
 	stdLogger.Info("Server exited")
 }
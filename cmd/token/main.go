@@ -0,0 +1,38 @@
+// Command token prints an API token for a user, signed with AUTH_SECRET. The
+// token expires after -ttl, AUTH_TOKEN_TTL by default.
+package main
+
+import (
+	"avito-autumn-2025/internal/auth"
+	"avito-autumn-2025/internal/config"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	userID := flag.String("user", "", "id of the user to issue the token for")
+	ttl := flag.Duration("ttl", 0, "how long the token stays valid (default AUTH_TOKEN_TTL)")
+	flag.Parse()
+
+	if *userID == "" {
+		fmt.Fprintln(os.Stderr, "Usage: token -user <user_id>")
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.AuthSecret == "" {
+		fmt.Fprintln(os.Stderr, "AUTH_SECRET is not set")
+		os.Exit(1)
+	}
+
+	if *ttl <= 0 {
+		*ttl = cfg.AuthTokenTTL
+	}
+
+	fmt.Println(auth.NewAuthenticator(cfg.AuthSecret, cfg.AuthAdmins).Token(*userID, *ttl))
+}
@@ -0,0 +1,23 @@
+// Package actor carries the identity of whoever triggered a mutation through
+// the request context, so that storage can attribute audit records.
+package actor
+
+import "context"
+
+// System is the actor of mutations that are not triggered by a caller, such as
+// background workers.
+const System = "system"
+
+type contextKey struct{}
+
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the actor stored in ctx or System when there is none.
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+		return id
+	}
+	return System
+}
@@ -0,0 +1,206 @@
+// Package apperr defines the kinds of errors reported to API clients. Storage
+// and services return an *Error; the HTTP layer picks the status from its kind
+// and returns its code, which clients can rely on, in the response.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Kinds of errors. Match them with errors.Is.
+var (
+	ErrInvalid              = errors.New("invalid request")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrUnauthorized         = errors.New("unauthorized")
+	ErrForbidden            = errors.New("forbidden")
+	ErrNotFound             = errors.New("not found")
+	ErrConflict             = errors.New("conflict")
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrVersionMismatch      = errors.New("version mismatch")
+	ErrNoCandidates         = errors.New("no candidates")
+)
+
+// Stable error codes.
+const (
+	CodeInvalidRequest       = "INVALID_REQUEST"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeInvalidToken         = "INVALID_TOKEN"
+	CodeForbidden            = "FORBIDDEN"
+	CodeInternal             = "INTERNAL"
+
+	CodeNotFound            = "NOT_FOUND"
+	CodeConflict            = "CONFLICT"
+	CodePreconditionFailed  = "PRECONDITION_FAILED"
+	CodeUserNotFound        = "USER_NOT_FOUND"
+	CodeTeamNotFound        = "TEAM_NOT_FOUND"
+	CodePolicyNotFound      = "POLICY_NOT_FOUND"
+	CodeRepositoryNotFound  = "REPOSITORY_NOT_FOUND"
+	CodePullRequestNotFound = "PR_NOT_FOUND"
+	CodeNotAssigned         = "NOT_ASSIGNED"
+	CodeNotMember           = "NOT_MEMBER"
+
+	CodeUserExists          = "USER_EXISTS"
+	CodePullRequestExists   = "PR_EXISTS"
+	CodeTeamExists          = "TEAM_EXISTS"
+	CodePullRequestMerged   = "PR_MERGED"
+	CodePullRequestClosed   = "PR_CLOSED"
+	CodeUserDeleted         = "USER_DELETED"
+	CodeTeamCycle           = "TEAM_CYCLE"
+	CodeOpenReviews         = "OPEN_REVIEWS"
+	CodeIdempotencyKeyInUse = "IDEMPOTENCY_KEY_IN_USE"
+
+	CodeAuthorInactive       = "AUTHOR_INACTIVE"
+	CodeChangesRequested     = "CHANGES_REQUESTED"
+	CodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+
+	CodeNoCandidate = "NO_CANDIDATE"
+
+	CodeVersionMismatch = "VERSION_MISMATCH"
+)
+
+// Error is an error of a known kind with a stable code. Fields lists the
+// violated constraints of an invalid request, when they are known.
+type Error struct {
+	kind    error
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// FieldError is a constraint violated by one field of a request. In is where
+// the field is: body, query, path or header.
+type FieldError struct {
+	In      string `json:"in"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+func newError(kind error, code, format string, args []any) *Error {
+	return &Error{kind: kind, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func Invalid(code, format string, args ...any) *Error {
+	return newError(ErrInvalid, code, format, args)
+}
+
+// InvalidFields reports a request that violates the constraints of fields.
+func InvalidFields(code string, fields []FieldError) *Error {
+	err := newError(ErrInvalid, code, "request has %d invalid field(s)", []any{len(fields)})
+	err.Fields = fields
+	return err
+}
+
+func UnsupportedMediaType(code, format string, args ...any) *Error {
+	return newError(ErrUnsupportedMediaType, code, format, args)
+}
+
+func Unauthorized(code, format string, args ...any) *Error {
+	return newError(ErrUnauthorized, code, format, args)
+}
+
+func Forbidden(code, format string, args ...any) *Error {
+	return newError(ErrForbidden, code, format, args)
+}
+
+func NotFound(code, format string, args ...any) *Error {
+	return newError(ErrNotFound, code, format, args)
+}
+
+func Conflict(code, format string, args ...any) *Error {
+	return newError(ErrConflict, code, format, args)
+}
+
+// PreconditionFailed reports a request that is valid but cannot be applied to
+// the current state, such as a pull request by an inactive author.
+func PreconditionFailed(code, format string, args ...any) *Error {
+	return newError(ErrPreconditionFailed, code, format, args)
+}
+
+// VersionMismatch reports a mutation conditioned on a version of the resource
+// that is no longer current.
+func VersionMismatch(code, format string, args ...any) *Error {
+	return newError(ErrVersionMismatch, code, format, args)
+}
+
+// NoCandidates reports that nobody can be assigned as a reviewer.
+func NoCandidates(code, format string, args ...any) *Error {
+	return newError(ErrNoCandidates, code, format, args)
+}
+
+var statuses = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{ErrInvalid, http.StatusBadRequest, CodeInvalidRequest},
+	{ErrUnsupportedMediaType, http.StatusUnsupportedMediaType, CodeUnsupportedMediaType},
+	{ErrUnauthorized, http.StatusUnauthorized, CodeUnauthorized},
+	{ErrForbidden, http.StatusForbidden, CodeForbidden},
+	{ErrNotFound, http.StatusNotFound, CodeNotFound},
+	{ErrConflict, http.StatusConflict, CodeConflict},
+	{ErrPreconditionFailed, http.StatusUnprocessableEntity, CodePreconditionFailed},
+	{ErrNoCandidates, http.StatusUnprocessableEntity, CodeNoCandidate},
+	{ErrVersionMismatch, http.StatusPreconditionFailed, CodeVersionMismatch},
+}
+
+// Status returns the HTTP status for err. Errors of no known kind are
+// internal.
+func Status(err error) int {
+	for _, s := range statuses {
+		if errors.Is(err, s.kind) {
+			return s.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// Code returns the code of the first *Error in err's chain, falling back to
+// the code of its kind.
+func Code(err error) string {
+	var appErr *Error
+	if errors.As(err, &appErr) && appErr.Code != "" {
+		return appErr.Code
+	}
+	for _, s := range statuses {
+		if errors.Is(err, s.kind) {
+			return s.code
+		}
+	}
+	return CodeInternal
+}
+
+// Response is the body of every error response.
+type Response struct {
+	Error ResponseError `json:"error"`
+}
+
+type ResponseError struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// NewResponse renders err. Messages of internal errors are hidden.
+func NewResponse(err error) Response {
+	message := err.Error()
+	if Status(err) == http.StatusInternalServerError {
+		message = "internal server error"
+	}
+	response := Response{Error: ResponseError{Code: Code(err), Message: message}}
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		response.Error.Fields = appErr.Fields
+	}
+	return response
+}
@@ -0,0 +1,147 @@
+// Package auth authenticates API callers with signed tokens and checks
+// team-level permissions of the authenticated caller.
+package auth
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrForbidden is returned when the caller lacks a permission.
+var ErrForbidden = apperr.ErrForbidden
+
+// ErrInvalidToken is returned for malformed tokens and bad signatures.
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrTokenExpired is returned for correctly signed tokens past their expiry.
+var ErrTokenExpired = errors.New("token expired")
+
+// Principal is an authenticated caller. Admins pass every permission check.
+type Principal struct {
+	UserID string
+	Admin  bool
+}
+
+type contextKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the authenticated caller. There is none for background
+// workers and when the servers run with AUTH_INSECURE, and then permission
+// checks are skipped.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(Principal)
+	return p, ok
+}
+
+// Authenticator issues and verifies tokens of the form
+// <user_id>.<expires_at>.<signature>, where expires_at is a Unix time in
+// seconds and the signature is an HMAC-SHA256 of everything before it.
+type Authenticator struct {
+	secret []byte
+	admins map[string]bool
+	now    func() time.Time
+}
+
+func NewAuthenticator(secret string, admins []string) *Authenticator {
+	a := &Authenticator{secret: []byte(secret), admins: make(map[string]bool, len(admins)), now: time.Now}
+	for _, id := range admins {
+		if id = strings.TrimSpace(id); id != "" {
+			a.admins[id] = true
+		}
+	}
+	return a
+}
+
+// Token issues a token for userID that expires after ttl.
+func (a *Authenticator) Token(userID string, ttl time.Duration) string {
+	payload := userID + "." + strconv.FormatInt(a.now().Add(ttl).Unix(), 10)
+	return payload + "." + a.sign(payload)
+}
+
+func (a *Authenticator) Verify(token string) (Principal, error) {
+	i := strings.LastIndex(token, ".")
+	if i <= 0 {
+		return Principal{}, ErrInvalidToken
+	}
+	payload, signature := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(a.sign(payload))) {
+		return Principal{}, ErrInvalidToken
+	}
+
+	// User ids may contain dots, the expiry never does
+	i = strings.LastIndex(payload, ".")
+	if i <= 0 {
+		return Principal{}, ErrInvalidToken
+	}
+	userID := payload[:i]
+	expiresAt, err := strconv.ParseInt(payload[i+1:], 10, 64)
+	if err != nil {
+		return Principal{}, ErrInvalidToken
+	}
+	if !a.now().Before(time.Unix(expiresAt, 0)) {
+		return Principal{}, ErrTokenExpired
+	}
+	return Principal{UserID: userID, Admin: a.admins[userID]}, nil
+}
+
+func (a *Authenticator) sign(payload string) string {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// LeadChecker reports whether a user leads a team or one of its ancestors.
+type LeadChecker interface {
+	IsTeamLead(ctx context.Context, teamName, userID string) (bool, error)
+}
+
+// RequireTeamLead allows admins and leads of teamName or of one of its
+// ancestor teams. Calls without an authenticated caller are allowed, see
+// FromContext.
+func RequireTeamLead(ctx context.Context, checker LeadChecker, teamName string) error {
+	p, ok := FromContext(ctx)
+	if !ok || p.Admin {
+		return nil
+	}
+	if teamName != "" {
+		lead, err := checker.IsTeamLead(ctx, teamName, p.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to check team lead: %w", err)
+		}
+		if lead {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not a lead of team %s", ErrForbidden, p.UserID, teamName)
+}
+
+// RequireSelf allows admins and userID itself. Calls without an authenticated
+// caller are allowed, see FromContext.
+func RequireSelf(ctx context.Context, userID string) error {
+	p, ok := FromContext(ctx)
+	if !ok || p.Admin || p.UserID == userID {
+		return nil
+	}
+	return fmt.Errorf("%w: %s cannot act as %s", ErrForbidden, p.UserID, userID)
+}
+
+// RequireAdmin allows admins only. Calls without an authenticated caller are
+// allowed, see FromContext.
+func RequireAdmin(ctx context.Context) error {
+	p, ok := FromContext(ctx)
+	if !ok || p.Admin {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is not an admin", ErrForbidden, p.UserID)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
@@ -12,6 +13,7 @@ import (
 type Config struct {
 	Host        string `env:"HOST" env-default:"0.0.0.0"`
 	Port        int    `env:"PORT" env-default:"8080"`
+	GRPCPort    int    `env:"GRPC_PORT" env-default:"9090"`
 	DatabaseURL string `env:"DATABASE_URL"`
 
 	DBHost     string `env:"DB_HOST" env-default:"localhost"`
@@ -22,6 +24,31 @@ type Config struct {
 	DBSSLMode  string `env:"DB_SSLMODE" env-default:"disable"`
 
 	TestDBName string `env:"TEST_DB_NAME" env-default:"test_mydatabase"`
+
+	SLACheckInterval   time.Duration `env:"SLA_CHECK_INTERVAL" env-default:"5m"`
+	StaleCheckInterval time.Duration `env:"STALE_CHECK_INTERVAL" env-default:"1h"`
+
+	IdempotencyKeyTTL        time.Duration `env:"IDEMPOTENCY_KEY_TTL" env-default:"24h"`
+	IdempotencyPurgeInterval time.Duration `env:"IDEMPOTENCY_PURGE_INTERVAL" env-default:"1h"`
+
+	AuthInsecure bool          `env:"AUTH_INSECURE" env-default:"false"`
+	AuthSecret   string        `env:"AUTH_SECRET"`
+	AuthAdmins   []string      `env:"AUTH_ADMINS" env-separator:","`
+	AuthTokenTTL time.Duration `env:"AUTH_TOKEN_TTL" env-default:"720h"`
+
+	LDAPSyncEnabled     bool          `env:"LDAP_SYNC_ENABLED" env-default:"false"`
+	LDAPSyncInterval    time.Duration `env:"LDAP_SYNC_INTERVAL" env-default:"1h"`
+	LDAPAdoptUsers      bool          `env:"LDAP_ADOPT_USERS" env-default:"false"`
+	LDAPURL             string        `env:"LDAP_URL"`
+	LDAPBindDN          string        `env:"LDAP_BIND_DN"`
+	LDAPBindPassword    string        `env:"LDAP_BIND_PASSWORD"`
+	LDAPBaseDN          string        `env:"LDAP_BASE_DN"`
+	LDAPUserFilter      string        `env:"LDAP_USER_FILTER" env-default:"(objectClass=person)"`
+	LDAPGroupFilter     string        `env:"LDAP_GROUP_FILTER" env-default:"(objectClass=groupOfNames)"`
+	LDAPUserIdAttr      string        `env:"LDAP_USER_ID_ATTR" env-default:"uid"`
+	LDAPUsernameAttr    string        `env:"LDAP_USERNAME_ATTR" env-default:"cn"`
+	LDAPGroupNameAttr   string        `env:"LDAP_GROUP_NAME_ATTR" env-default:"cn"`
+	LDAPGroupMemberAttr string        `env:"LDAP_GROUP_MEMBER_ATTR" env-default:"member"`
 }
 
 func (c *Config) BuildDatabaseURL() string {
@@ -70,5 +97,14 @@ func LoadConfig(envFiles ...string) (*Config, error) {
 	if cfg.DatabaseURL == "" {
 		cfg.DatabaseURL = cfg.BuildDatabaseURL()
 	}
+	if cfg.IdempotencyKeyTTL <= 0 {
+		return nil, errors.New("IDEMPOTENCY_KEY_TTL must be positive")
+	}
+	if !cfg.AuthInsecure && cfg.AuthSecret == "" {
+		return nil, errors.New("AUTH_SECRET is required unless AUTH_INSECURE is set")
+	}
+	if cfg.LDAPSyncEnabled && (cfg.LDAPURL == "" || cfg.LDAPBaseDN == "") {
+		return nil, errors.New("LDAP_URL and LDAP_BASE_DN are required when LDAP_SYNC_ENABLED is set")
+	}
 	return cfg, nil
 }
@@ -0,0 +1,79 @@
+// Package graphql serves a read-only GraphQL view of teams, users, pull
+// requests and review statistics. Nested fields are loaded through per-request
+// dataloaders, so each level of a query costs one batched storage call rather
+// than one per parent object.
+package graphql
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/storage"
+	"context"
+	_ "embed"
+	"net/http"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var Schema string
+
+const (
+	maxDepth       = 12
+	maxQueryLength = 16 << 10
+	// maxParallelism bounds the resolvers running at once and therefore the
+	// number of keys a batch can collect; it should cover a dashboard page.
+	maxParallelism = 1000
+)
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type Executor struct {
+	schema       *graphqlgo.Schema
+	users        storage.User
+	teams        storage.Team
+	pullRequests storage.PullRequest
+	log          logger.Logger
+}
+
+func NewExecutor(users storage.User, teams storage.Team, pullRequests storage.PullRequest, log logger.Logger) *Executor {
+	e := &Executor{
+		users:        users,
+		teams:        teams,
+		pullRequests: pullRequests,
+		log:          log,
+	}
+	e.schema = graphqlgo.MustParseSchema(Schema, &Resolver{users: users, teams: teams, pullRequests: pullRequests},
+		graphqlgo.UseStringDescriptions(),
+		graphqlgo.MaxDepth(maxDepth),
+		graphqlgo.MaxQueryLength(maxQueryLength),
+		graphqlgo.MaxParallelism(maxParallelism),
+	)
+	return e
+}
+
+// Execute runs req with fresh dataloaders. Resolver errors carry their apperr
+// code in the "code" extension; messages of internal errors are hidden.
+func (e *Executor) Execute(ctx context.Context, req *Request) *graphqlgo.Response {
+	ctx = withLoaders(ctx, newLoaders(e.users, e.teams, e.pullRequests))
+	response := e.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	for _, queryErr := range response.Errors {
+		err := queryErr.ResolverError
+		if err == nil {
+			continue
+		}
+		if apperr.Status(err) == http.StatusInternalServerError {
+			e.log.Error("GraphQL resolver failed", "error", err, "path", queryErr.Path)
+		}
+		rendered := apperr.NewResponse(err).Error
+		queryErr.Message = rendered.Message
+		queryErr.Extensions = map[string]any{"code": rendered.Code}
+	}
+	return response
+}
@@ -0,0 +1,101 @@
+package graphql
+
+import (
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage"
+	"context"
+	"time"
+
+	"github.com/graph-gophers/dataloader/v7"
+)
+
+// batchWait is how long a loader collects keys after the first one before it
+// queries the storage.
+const batchWait = 5 * time.Millisecond
+
+// loaders batch and cache the storage calls of one request. Missing keys
+// resolve to nil.
+type loaders struct {
+	users                *dataloader.Loader[string, *models.User]
+	teams                *dataloader.Loader[string, *models.Team]
+	members              *dataloader.Loader[string, []*models.User]
+	subteams             *dataloader.Loader[string, []*models.Team]
+	pullRequests         *dataloader.Loader[models.PullRequestKey, *models.PullRequest]
+	reviewsByReviewer    *dataloader.Loader[string, []*models.ReviewAssignment]
+	reviewsByPullRequest *dataloader.Loader[models.PullRequestKey, []*models.ReviewAssignment]
+}
+
+func newLoaders(users storage.User, teams storage.Team, pullRequests storage.PullRequest) *loaders {
+	return &loaders{
+		users: newLoader(func(ctx context.Context, ids []string) (map[string]*models.User, error) {
+			found, err := users.GetUsersByIDs(ctx, ids)
+			return byKey(found, func(u *models.User) string { return u.Id }), err
+		}),
+		teams: newLoader(func(ctx context.Context, names []string) (map[string]*models.Team, error) {
+			found, err := teams.GetTeamsByNames(ctx, names)
+			return byKey(found, func(t *models.Team) string { return t.Name }), err
+		}),
+		members: newLoader(teams.GetTeamMembers),
+		subteams: newLoader(func(ctx context.Context, parents []string) (map[string][]*models.Team, error) {
+			found, err := teams.GetSubteams(ctx, parents)
+			return groupBy(found, func(t *models.Team) string { return t.ParentTeam }), err
+		}),
+		pullRequests: newLoader(func(ctx context.Context, keys []models.PullRequestKey) (map[models.PullRequestKey]*models.PullRequest, error) {
+			found, err := pullRequests.GetPullRequestsByKeys(ctx, keys)
+			return byKey(found, func(pr *models.PullRequest) models.PullRequestKey {
+				return models.PullRequestKey{Repository: pr.Repository, PullRequestId: pr.PullRequestId}
+			}), err
+		}),
+		reviewsByReviewer: newLoader(func(ctx context.Context, ids []string) (map[string][]*models.ReviewAssignment, error) {
+			found, err := pullRequests.GetReviewAssignmentsByReviewers(ctx, ids)
+			return groupBy(found, func(a *models.ReviewAssignment) string { return a.ReviewerId }), err
+		}),
+		reviewsByPullRequest: newLoader(func(ctx context.Context, keys []models.PullRequestKey) (map[models.PullRequestKey][]*models.ReviewAssignment, error) {
+			found, err := pullRequests.GetReviewAssignmentsByPullRequests(ctx, keys)
+			return groupBy(found, assignmentKey), err
+		}),
+	}
+}
+
+// newLoader wraps a storage call that fetches the values of many keys at once.
+func newLoader[K comparable, V any](fetch func(context.Context, []K) (map[K]V, error)) *dataloader.Loader[K, V] {
+	batch := func(ctx context.Context, keys []K) []*dataloader.Result[V] {
+		values, err := fetch(ctx, keys)
+		results := make([]*dataloader.Result[V], len(keys))
+		for i, key := range keys {
+			results[i] = &dataloader.Result[V]{Data: values[key], Error: err}
+		}
+		return results
+	}
+	return dataloader.NewBatchedLoader(batch, dataloader.WithWait[K, V](batchWait))
+}
+
+func byKey[K comparable, V any](values []V, key func(V) K) map[K]V {
+	m := make(map[K]V, len(values))
+	for _, v := range values {
+		m[key(v)] = v
+	}
+	return m
+}
+
+func groupBy[K comparable, V any](values []V, key func(V) K) map[K][]V {
+	m := make(map[K][]V)
+	for _, v := range values {
+		m[key(v)] = append(m[key(v)], v)
+	}
+	return m
+}
+
+func assignmentKey(a *models.ReviewAssignment) models.PullRequestKey {
+	return models.PullRequestKey{Repository: a.Repository, PullRequestId: a.PullRequestId}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
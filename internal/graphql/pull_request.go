@@ -0,0 +1,167 @@
+package graphql
+
+import (
+	"avito-autumn-2025/internal/models"
+	"context"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+type pullRequestResolver struct {
+	pr *models.PullRequest
+}
+
+func (r *pullRequestResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(r.pr.PullRequestId)
+}
+
+func (r *pullRequestResolver) Repository() string {
+	return r.pr.Repository
+}
+
+func (r *pullRequestResolver) Name() string {
+	return r.pr.PullRequestName
+}
+
+func (r *pullRequestResolver) AuthorID() graphqlgo.ID {
+	return graphqlgo.ID(r.pr.AuthorId)
+}
+
+func (r *pullRequestResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.pr.AuthorId)
+}
+
+func (r *pullRequestResolver) Status() string {
+	return string(r.pr.Status)
+}
+
+func (r *pullRequestResolver) Team(ctx context.Context) (*teamResolver, error) {
+	return loadTeam(ctx, r.pr.TeamName)
+}
+
+func (r *pullRequestResolver) Labels() []string {
+	if r.pr.Labels == nil {
+		return []string{}
+	}
+	return r.pr.Labels
+}
+
+func (r *pullRequestResolver) Priority() string {
+	return string(r.pr.Priority)
+}
+
+func (r *pullRequestResolver) URL() string {
+	return r.pr.URL
+}
+
+func (r *pullRequestResolver) Additions() int32 {
+	return int32(r.pr.Additions)
+}
+
+func (r *pullRequestResolver) Deletions() int32 {
+	return int32(r.pr.Deletions)
+}
+
+func (r *pullRequestResolver) ChangedFiles() int32 {
+	return int32(r.pr.ChangedFiles)
+}
+
+func (r *pullRequestResolver) Reviewers(ctx context.Context) ([]*reviewAssignmentResolver, error) {
+	key := models.PullRequestKey{Repository: r.pr.Repository, PullRequestId: r.pr.PullRequestId}
+	assignments, err := loadersFrom(ctx).reviewsByPullRequest.Load(ctx, key)()
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*reviewAssignmentResolver, len(assignments))
+	for i, a := range assignments {
+		resolvers[i] = &reviewAssignmentResolver{assignment: a}
+	}
+	return resolvers, nil
+}
+
+func (r *pullRequestResolver) CreatedAt() *graphqlgo.Time {
+	return timeToGraphQL(r.pr.CreatedAt)
+}
+
+func (r *pullRequestResolver) MergedAt() *graphqlgo.Time {
+	return timeToGraphQL(r.pr.MergedAt)
+}
+
+func (r *pullRequestResolver) UpdatedAt() *graphqlgo.Time {
+	return timeToGraphQL(r.pr.UpdatedAt)
+}
+
+func (r *pullRequestResolver) StaleAt() *graphqlgo.Time {
+	return timeToGraphQL(r.pr.StaleAt)
+}
+
+func (r *pullRequestResolver) ClosedAt() *graphqlgo.Time {
+	return timeToGraphQL(r.pr.ClosedAt)
+}
+
+func (r *pullRequestResolver) Version() int32 {
+	return int32(r.pr.Version)
+}
+
+type reviewAssignmentResolver struct {
+	assignment *models.ReviewAssignment
+}
+
+func (r *reviewAssignmentResolver) ReviewerID() graphqlgo.ID {
+	return graphqlgo.ID(r.assignment.ReviewerId)
+}
+
+func (r *reviewAssignmentResolver) Reviewer(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.assignment.ReviewerId)
+}
+
+func (r *reviewAssignmentResolver) PullRequest(ctx context.Context) (*pullRequestResolver, error) {
+	return loadPullRequest(ctx, assignmentKey(r.assignment))
+}
+
+func (r *reviewAssignmentResolver) Team(ctx context.Context) (*teamResolver, error) {
+	return loadTeam(ctx, r.assignment.TeamName)
+}
+
+func (r *reviewAssignmentResolver) AssignedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.assignment.AssignedAt}
+}
+
+func (r *reviewAssignmentResolver) RespondedAt() *graphqlgo.Time {
+	return timeToGraphQL(r.assignment.RespondedAt)
+}
+
+func (r *reviewAssignmentResolver) Verdict() *string {
+	if r.assignment.Verdict == "" {
+		return nil
+	}
+	verdict := string(r.assignment.Verdict)
+	return &verdict
+}
+
+func (r *reviewAssignmentResolver) EscalatedAt() *graphqlgo.Time {
+	return timeToGraphQL(r.assignment.EscalatedAt)
+}
+
+type pullRequestConnectionResolver struct {
+	keys       []models.PullRequestKey
+	nextCursor *string
+}
+
+func (r *pullRequestConnectionResolver) Nodes(ctx context.Context) ([]*pullRequestResolver, error) {
+	prs, errs := loadersFrom(ctx).pullRequests.LoadMany(ctx, r.keys)()
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+	resolvers := make([]*pullRequestResolver, 0, len(prs))
+	for _, pr := range prs {
+		if pr != nil {
+			resolvers = append(resolvers, &pullRequestResolver{pr: pr})
+		}
+	}
+	return resolvers, nil
+}
+
+func (r *pullRequestConnectionResolver) NextCursor() *string {
+	return r.nextCursor
+}
@@ -0,0 +1,231 @@
+package graphql
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage"
+	"context"
+	"strings"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+// Resolver resolves the fields of Query.
+type Resolver struct {
+	users        storage.User
+	teams        storage.Team
+	pullRequests storage.PullRequest
+}
+
+func (r *Resolver) Team(ctx context.Context, args struct{ Name string }) (*teamResolver, error) {
+	return loadTeam(ctx, args.Name)
+}
+
+func (r *Resolver) Teams(ctx context.Context) ([]*teamResolver, error) {
+	teams, err := r.teams.ListTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l := loadersFrom(ctx)
+	resolvers := make([]*teamResolver, len(teams))
+	for i, team := range teams {
+		l.teams.Prime(ctx, team.Name, team)
+		l.members.Prime(ctx, team.Name, team.Users)
+		resolvers[i] = &teamResolver{team: team}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) User(ctx context.Context, args struct{ ID graphqlgo.ID }) (*userResolver, error) {
+	return loadUser(ctx, string(args.ID))
+}
+
+func (r *Resolver) Users(ctx context.Context) ([]*userResolver, error) {
+	users, err := r.users.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l := loadersFrom(ctx)
+	resolvers := make([]*userResolver, len(users))
+	for i, user := range users {
+		l.users.Prime(ctx, user.Id, user)
+		resolvers[i] = &userResolver{user: user}
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) PullRequest(ctx context.Context, args struct {
+	Repository string
+	ID         graphqlgo.ID
+}) (*pullRequestResolver, error) {
+	return loadPullRequest(ctx, models.PullRequestKey{Repository: args.Repository, PullRequestId: string(args.ID)})
+}
+
+type pullRequestsArgs struct {
+	Filter *pullRequestFilter
+	Sort   string
+	Order  string
+	First  *int32
+	After  *string
+}
+
+type pullRequestFilter struct {
+	ReviewerId  *graphqlgo.ID
+	AuthorId    *graphqlgo.ID
+	TeamName    *string
+	Repository  *string
+	Statuses    *[]string
+	CreatedFrom *graphqlgo.Time
+	CreatedTo   *graphqlgo.Time
+}
+
+func (r *Resolver) PullRequests(ctx context.Context, args pullRequestsArgs) (*pullRequestConnectionResolver, error) {
+	query, err := args.query()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := r.pullRequests.SearchPullRequests(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &pullRequestConnectionResolver{keys: make([]models.PullRequestKey, len(page.PullRequests))}
+	for i, pr := range page.PullRequests {
+		connection.keys[i] = models.PullRequestKey{Repository: pr.Repository, PullRequestId: pr.PullRequestId}
+	}
+	if page.NextCursor != nil {
+		cursor := page.NextCursor.Encode()
+		connection.nextCursor = &cursor
+	}
+	return connection, nil
+}
+
+// query builds the storage query the way the HTTP API reads its query
+// parameters. Enum values were already checked against the schema.
+func (args *pullRequestsArgs) query() (*models.PullRequestQuery, error) {
+	query := &models.PullRequestQuery{
+		Sort:  models.PullRequestSort(strings.ToLower(args.Sort)),
+		Order: models.SortOrder(strings.ToLower(args.Order)),
+		Limit: models.DefaultPageLimit,
+	}
+
+	if f := args.Filter; f != nil {
+		query.ReviewerId = string(deref(f.ReviewerId))
+		query.AuthorId = string(deref(f.AuthorId))
+		query.TeamName = deref(f.TeamName)
+		query.Repository = deref(f.Repository)
+		for _, status := range deref(f.Statuses) {
+			query.Statuses = append(query.Statuses, models.PullRequestStatus(status))
+		}
+		query.CreatedFrom = timeFromGraphQL(f.CreatedFrom)
+		query.CreatedTo = timeFromGraphQL(f.CreatedTo)
+	}
+
+	if args.First != nil {
+		if *args.First < 1 || *args.First > models.MaxPageLimit {
+			return nil, apperr.Invalid(apperr.CodeInvalidRequest, "first must be between 1 and %d", models.MaxPageLimit)
+		}
+		query.Limit = int(*args.First)
+	}
+
+	if args.After != nil && *args.After != "" {
+		cursor, err := models.DecodePullRequestCursor(*args.After)
+		if err != nil {
+			return nil, apperr.Invalid(apperr.CodeInvalidRequest, "after is malformed")
+		}
+		if cursor.Sort != query.Sort || cursor.Order != query.Order {
+			return nil, apperr.Invalid(apperr.CodeInvalidRequest, "after was issued for sort=%s and order=%s", cursor.Sort, cursor.Order)
+		}
+		query.After = cursor
+	}
+
+	return query, nil
+}
+
+func (r *Resolver) Statistics(ctx context.Context) (*statisticsResolver, error) {
+	stats, err := r.pullRequests.GetReviewStatistics(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &statisticsResolver{stats: stats}, nil
+}
+
+func loadTeam(ctx context.Context, name string) (*teamResolver, error) {
+	if name == "" {
+		return nil, nil
+	}
+	team, err := loadersFrom(ctx).teams.Load(ctx, name)()
+	if err != nil || team == nil {
+		return nil, err
+	}
+	return &teamResolver{team: team}, nil
+}
+
+func loadTeams(ctx context.Context, names []string) ([]*teamResolver, error) {
+	teams, errs := loadersFrom(ctx).teams.LoadMany(ctx, names)()
+	if err := firstError(errs); err != nil {
+		return nil, err
+	}
+	resolvers := make([]*teamResolver, 0, len(teams))
+	for _, team := range teams {
+		if team != nil {
+			resolvers = append(resolvers, &teamResolver{team: team})
+		}
+	}
+	return resolvers, nil
+}
+
+func loadUser(ctx context.Context, id string) (*userResolver, error) {
+	if id == "" {
+		return nil, nil
+	}
+	user, err := loadersFrom(ctx).users.Load(ctx, id)()
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return &userResolver{user: user}, nil
+}
+
+func loadPullRequest(ctx context.Context, key models.PullRequestKey) (*pullRequestResolver, error) {
+	pr, err := loadersFrom(ctx).pullRequests.Load(ctx, key)()
+	if err != nil || pr == nil {
+		return nil, err
+	}
+	return &pullRequestResolver{pr: pr}, nil
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
+
+func timeFromGraphQL(t *graphqlgo.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func timeToGraphQL(t *time.Time) *graphqlgo.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphqlgo.Time{Time: *t}
+}
@@ -0,0 +1,116 @@
+package graphql
+
+import (
+	"avito-autumn-2025/internal/models"
+	"context"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+type statisticsResolver struct {
+	stats *models.ReviewStatistics
+}
+
+func (r *statisticsResolver) TotalPullRequests() int32 {
+	return int32(r.stats.TotalPRs)
+}
+
+func (r *statisticsResolver) OpenPullRequests() int32 {
+	return int32(r.stats.OpenPRs)
+}
+
+func (r *statisticsResolver) MergedPullRequests() int32 {
+	return int32(r.stats.MergedPRs)
+}
+
+func (r *statisticsResolver) ClosedPullRequests() int32 {
+	return int32(r.stats.ClosedPRs)
+}
+
+func (r *statisticsResolver) Reviewers() []*reviewerStatisticsResolver {
+	resolvers := make([]*reviewerStatisticsResolver, len(r.stats.ReviewerStats))
+	for i := range r.stats.ReviewerStats {
+		resolvers[i] = &reviewerStatisticsResolver{stats: &r.stats.ReviewerStats[i]}
+	}
+	return resolvers
+}
+
+func (r *statisticsResolver) Teams() []*teamStatisticsResolver {
+	resolvers := make([]*teamStatisticsResolver, len(r.stats.TeamStats))
+	for i := range r.stats.TeamStats {
+		resolvers[i] = &teamStatisticsResolver{stats: &r.stats.TeamStats[i]}
+	}
+	return resolvers
+}
+
+func (r *statisticsResolver) GeneratedAt() graphqlgo.Time {
+	return graphqlgo.Time{Time: r.stats.GeneratedAt}
+}
+
+type reviewerStatisticsResolver struct {
+	stats *models.ReviewerStatistics
+}
+
+func (r *reviewerStatisticsResolver) ReviewerID() graphqlgo.ID {
+	return graphqlgo.ID(r.stats.ReviewerID)
+}
+
+func (r *reviewerStatisticsResolver) Reviewer(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.stats.ReviewerID)
+}
+
+func (r *reviewerStatisticsResolver) AssignedPullRequests() int32 {
+	return int32(r.stats.AssignedPRsCount)
+}
+
+func (r *reviewerStatisticsResolver) LastAssignedAt() *graphqlgo.Time {
+	return timeToGraphQL(r.stats.LastAssignedAt)
+}
+
+type teamStatisticsResolver struct {
+	stats *models.TeamStatistics
+}
+
+func (r *teamStatisticsResolver) TeamName() string {
+	return r.stats.TeamName
+}
+
+func (r *teamStatisticsResolver) Team(ctx context.Context) (*teamResolver, error) {
+	return loadTeam(ctx, r.stats.TeamName)
+}
+
+func (r *teamStatisticsResolver) Deleted() bool {
+	return r.stats.Deleted
+}
+
+func (r *teamStatisticsResolver) MemberCount() int32 {
+	return int32(r.stats.MemberCount)
+}
+
+func (r *teamStatisticsResolver) ActiveMemberCount() int32 {
+	return int32(r.stats.ActiveMemberCount)
+}
+
+func (r *teamStatisticsResolver) PullRequestsCreated() int32 {
+	return int32(r.stats.PRsCreated)
+}
+
+func (r *teamStatisticsResolver) ReviewsAssigned() int32 {
+	return int32(r.stats.ReviewsAssigned)
+}
+
+func (r *teamStatisticsResolver) SubtreeMemberCount() int32 {
+	return int32(r.stats.SubtreeMemberCount)
+}
+
+func (r *teamStatisticsResolver) SubtreeActiveMemberCount() int32 {
+	return int32(r.stats.SubtreeActiveMemberCount)
+}
+
+func (r *teamStatisticsResolver) SubtreePullRequestsCreated() int32 {
+	return int32(r.stats.SubtreePRsCreated)
+}
+
+func (r *teamStatisticsResolver) SubtreeReviewsAssigned() int32 {
+	return int32(r.stats.SubtreeReviewsAssigned)
+}
@@ -0,0 +1,67 @@
+package graphql
+
+import (
+	"avito-autumn-2025/internal/models"
+	"context"
+	"strings"
+)
+
+type teamResolver struct {
+	team *models.Team
+}
+
+func (r *teamResolver) Name() string {
+	return r.team.Name
+}
+
+func (r *teamResolver) Parent(ctx context.Context) (*teamResolver, error) {
+	return loadTeam(ctx, r.team.ParentTeam)
+}
+
+func (r *teamResolver) Subteams(ctx context.Context) ([]*teamResolver, error) {
+	subteams, err := loadersFrom(ctx).subteams.Load(ctx, r.team.Name)()
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*teamResolver, len(subteams))
+	for i, team := range subteams {
+		resolvers[i] = &teamResolver{team: team}
+	}
+	return resolvers, nil
+}
+
+func (r *teamResolver) Members(ctx context.Context) ([]*teamMemberResolver, error) {
+	members, err := loadersFrom(ctx).members.Load(ctx, r.team.Name)()
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*teamMemberResolver, len(members))
+	for i, member := range members {
+		resolvers[i] = &teamMemberResolver{member: member}
+	}
+	return resolvers, nil
+}
+
+func (r *teamResolver) Version() int32 {
+	return int32(r.team.Version)
+}
+
+// teamMemberResolver resolves a member as listed by the team. The user itself
+// is loaded separately so that it carries all of its teams.
+type teamMemberResolver struct {
+	member *models.User
+}
+
+func (r *teamMemberResolver) Role() string {
+	return strings.ToUpper(string(r.member.Role))
+}
+
+func (r *teamMemberResolver) User(ctx context.Context) (*userResolver, error) {
+	user, err := loadUser(ctx, r.member.Id)
+	if err != nil || user != nil {
+		return user, err
+	}
+	// Deleted users keep no memberships, so this only happens when the user
+	// left the team while the request was running.
+	return &userResolver{user: r.member}, nil
+}
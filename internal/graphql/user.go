@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"avito-autumn-2025/internal/models"
+	"context"
+	"slices"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+)
+
+type userResolver struct {
+	user *models.User
+}
+
+func (r *userResolver) ID() graphqlgo.ID {
+	return graphqlgo.ID(r.user.Id)
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *userResolver) IsActive() bool {
+	return r.user.IsActive
+}
+
+func (r *userResolver) Team(ctx context.Context) (*teamResolver, error) {
+	return loadTeam(ctx, r.user.TeamName)
+}
+
+func (r *userResolver) Teams(ctx context.Context) ([]*teamResolver, error) {
+	return loadTeams(ctx, r.user.Teams)
+}
+
+func (r *userResolver) Reviews(ctx context.Context, args struct{ Statuses *[]string }) ([]*reviewAssignmentResolver, error) {
+	assignments, err := loadersFrom(ctx).reviewsByReviewer.Load(ctx, r.user.Id)()
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*reviewAssignmentResolver, 0, len(assignments))
+	for _, a := range assignments {
+		if args.Statuses == nil || slices.Contains(*args.Statuses, string(a.PullRequestStatus)) {
+			resolvers = append(resolvers, &reviewAssignmentResolver{assignment: a})
+		}
+	}
+	return resolvers, nil
+}
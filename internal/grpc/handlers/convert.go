@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/grpc/reviewerv1"
+	"avito-autumn-2025/internal/models"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var roles = map[reviewerv1.MembershipRole]models.MembershipRole{
+	reviewerv1.MembershipRole_MEMBERSHIP_ROLE_UNSPECIFIED: "",
+	reviewerv1.MembershipRole_MEMBERSHIP_ROLE_LEAD:        models.RoleLead,
+	reviewerv1.MembershipRole_MEMBERSHIP_ROLE_MEMBER:      models.RoleMember,
+	reviewerv1.MembershipRole_MEMBERSHIP_ROLE_OBSERVER:    models.RoleObserver,
+}
+
+var statuses = map[reviewerv1.PullRequestStatus]models.PullRequestStatus{
+	reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN:   models.OPEN,
+	reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED: models.MERGED,
+	reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_CLOSED: models.CLOSED,
+}
+
+var priorities = map[reviewerv1.PullRequestPriority]models.PullRequestPriority{
+	reviewerv1.PullRequestPriority_PULL_REQUEST_PRIORITY_UNSPECIFIED: models.NORMAL,
+	reviewerv1.PullRequestPriority_PULL_REQUEST_PRIORITY_LOW:         models.LOW,
+	reviewerv1.PullRequestPriority_PULL_REQUEST_PRIORITY_NORMAL:      models.NORMAL,
+	reviewerv1.PullRequestPriority_PULL_REQUEST_PRIORITY_HIGH:        models.HIGH,
+	reviewerv1.PullRequestPriority_PULL_REQUEST_PRIORITY_URGENT:      models.URGENT,
+}
+
+var verdicts = map[reviewerv1.ReviewVerdict]models.ReviewVerdict{
+	reviewerv1.ReviewVerdict_REVIEW_VERDICT_APPROVED:          models.APPROVED,
+	reviewerv1.ReviewVerdict_REVIEW_VERDICT_CHANGES_REQUESTED: models.CHANGES_REQUESTED,
+	reviewerv1.ReviewVerdict_REVIEW_VERDICT_COMMENTED:         models.COMMENTED,
+}
+
+var sorts = map[reviewerv1.SortField]models.PullRequestSort{
+	reviewerv1.SortField_SORT_FIELD_UNSPECIFIED: models.SortByCreatedAt,
+	reviewerv1.SortField_SORT_FIELD_CREATED_AT:  models.SortByCreatedAt,
+	reviewerv1.SortField_SORT_FIELD_UPDATED_AT:  models.SortByUpdatedAt,
+}
+
+var orders = map[reviewerv1.SortOrder]models.SortOrder{
+	reviewerv1.SortOrder_SORT_ORDER_UNSPECIFIED: models.SortDesc,
+	reviewerv1.SortOrder_SORT_ORDER_ASC:         models.SortAsc,
+	reviewerv1.SortOrder_SORT_ORDER_DESC:        models.SortDesc,
+}
+
+// toProto looks value up in a map from protobuf enum values, falling back to
+// the zero value.
+func toProto[E comparable, V comparable](values map[E]V, value V) E {
+	for e, v := range values {
+		if v == value && e != *new(E) {
+			return e
+		}
+	}
+	return *new(E)
+}
+
+// memberFromProto reads a team member, reporting its violations under field.
+func memberFromProto(v *violations, field string, member *reviewerv1.User) *models.User {
+	if member == nil {
+		v.add(field, "is required")
+		return nil
+	}
+	v.required(field+".id", member.GetId(), maxIDLength)
+	v.required(field+".username", member.GetUsername(), maxUsernameLength)
+	role, ok := roles[member.GetRole()]
+	if !ok {
+		v.add(field+".role", "must be one of lead, member, observer")
+	}
+	return &models.User{
+		Id:       member.GetId(),
+		Username: member.GetUsername(),
+		IsActive: member.GetIsActive(),
+		TeamName: member.GetTeamName(),
+		Role:     role,
+	}
+}
+
+func userToProto(user *models.User) *reviewerv1.User {
+	return &reviewerv1.User{
+		Id:       user.Id,
+		Username: user.Username,
+		IsActive: user.IsActive,
+		TeamName: user.TeamName,
+		Teams:    user.Teams,
+		Role:     toProto(roles, user.Role),
+	}
+}
+
+func teamToProto(team *models.Team) *reviewerv1.Team {
+	pb := &reviewerv1.Team{
+		TeamName:   team.Name,
+		ParentTeam: team.ParentTeam,
+		Version:    team.Version,
+	}
+	for _, user := range team.Users {
+		pb.Members = append(pb.Members, userToProto(user))
+	}
+	for _, subteam := range team.Subteams {
+		pb.Subteams = append(pb.Subteams, teamToProto(subteam))
+	}
+	return pb
+}
+
+func pullRequestToProto(pr *models.PullRequest) *reviewerv1.PullRequest {
+	return &reviewerv1.PullRequest{
+		PullRequestId:     pr.PullRequestId,
+		PullRequestName:   pr.PullRequestName,
+		AuthorId:          pr.AuthorId,
+		Status:            toProto(statuses, pr.Status),
+		Repository:        pr.Repository,
+		TeamName:          pr.TeamName,
+		Labels:            pr.Labels,
+		Priority:          toProto(priorities, pr.Priority),
+		Url:               pr.URL,
+		Additions:         int32(pr.Additions),
+		Deletions:         int32(pr.Deletions),
+		ChangedFiles:      int32(pr.ChangedFiles),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         timestampToProto(pr.CreatedAt),
+		MergedAt:          timestampToProto(pr.MergedAt),
+		UpdatedAt:         timestampToProto(pr.UpdatedAt),
+		StaleAt:           timestampToProto(pr.StaleAt),
+		ClosedAt:          timestampToProto(pr.ClosedAt),
+		Version:           pr.Version,
+	}
+}
+
+func pullRequestShortsToProto(prs []*models.PullRequestShort) []*reviewerv1.PullRequestShort {
+	pbs := make([]*reviewerv1.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		pbs = append(pbs, &reviewerv1.PullRequestShort{
+			Repository:      pr.Repository,
+			PullRequestId:   pr.PullRequestId,
+			PullRequestName: pr.PullRequestName,
+			AuthorId:        pr.AuthorId,
+			Status:          toProto(statuses, pr.Status),
+			TeamName:        pr.TeamName,
+			CreatedAt:       timestampToProto(pr.CreatedAt),
+			UpdatedAt:       timestampToProto(pr.UpdatedAt),
+		})
+	}
+	return pbs
+}
+
+func statisticsToProto(stats *models.ReviewStatistics) *reviewerv1.GetReviewStatisticsResponse {
+	pb := &reviewerv1.GetReviewStatisticsResponse{
+		TotalPrs:    int32(stats.TotalPRs),
+		OpenPrs:     int32(stats.OpenPRs),
+		MergedPrs:   int32(stats.MergedPRs),
+		ClosedPrs:   int32(stats.ClosedPRs),
+		GeneratedAt: timestamppb.New(stats.GeneratedAt),
+	}
+	for _, s := range stats.ReviewerStats {
+		pb.ReviewerStats = append(pb.ReviewerStats, &reviewerv1.ReviewerStatistics{
+			ReviewerId:       s.ReviewerID,
+			ReviewerName:     s.ReviewerName,
+			AssignedPrsCount: int32(s.AssignedPRsCount),
+			LastAssignedAt:   timestampToProto(s.LastAssignedAt),
+		})
+	}
+	for _, s := range stats.TeamStats {
+		pb.TeamStats = append(pb.TeamStats, &reviewerv1.TeamStatistics{
+			TeamName:                 s.TeamName,
+			ParentTeam:               s.ParentTeam,
+			MemberCount:              int32(s.MemberCount),
+			ActiveMemberCount:        int32(s.ActiveMemberCount),
+			PrsCreated:               int32(s.PRsCreated),
+			ReviewsAssigned:          int32(s.ReviewsAssigned),
+			SubtreeMemberCount:       int32(s.SubtreeMemberCount),
+			SubtreeActiveMemberCount: int32(s.SubtreeActiveMemberCount),
+			SubtreePrsCreated:        int32(s.SubtreePRsCreated),
+			SubtreeReviewsAssigned:   int32(s.SubtreeReviewsAssigned),
+			Deleted:                  s.Deleted,
+		})
+	}
+	return pb
+}
+
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
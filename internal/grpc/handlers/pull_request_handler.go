@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/grpc/reviewerv1"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service"
+	"context"
+	"time"
+)
+
+type PullRequestHandler struct {
+	reviewerv1.UnimplementedPullRequestServiceServer
+	prService service.PullRequest
+	log       logger.Logger
+}
+
+func NewPullRequestHandler(prService service.PullRequest, log logger.Logger) *PullRequestHandler {
+	return &PullRequestHandler{
+		prService: prService,
+		log:       log,
+	}
+}
+
+func (h *PullRequestHandler) CreatePullRequest(ctx context.Context, req *reviewerv1.CreatePullRequestRequest) (*reviewerv1.CreatePullRequestResponse, error) {
+	h.log.Debug("gRPC: Creating pull request request")
+
+	var v violations
+	v.required("pull_request_id", req.GetPullRequestId(), maxIDLength)
+	v.required("pull_request_name", req.GetPullRequestName(), maxTitleLength)
+	v.required("author_id", req.GetAuthorId(), maxIDLength)
+	v.maxLength("repository", req.GetRepository(), maxRepositoryLength)
+	v.maxLength("url", req.GetUrl(), maxURLLength)
+	priority, ok := priorities[req.GetPriority()]
+	if !ok {
+		v.add("priority", "must be one of LOW, NORMAL, HIGH, URGENT")
+	}
+	v.nonNegative("additions", int64(req.GetAdditions()))
+	v.nonNegative("deletions", int64(req.GetDeletions()))
+	v.nonNegative("changed_files", int64(req.GetChangedFiles()))
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	pr := &models.PullRequest{
+		PullRequestId:   req.GetPullRequestId(),
+		PullRequestName: req.GetPullRequestName(),
+		AuthorId:        req.GetAuthorId(),
+		Status:          models.OPEN,
+		Repository:      req.GetRepository(),
+		Labels:          req.GetLabels(),
+		Priority:        priority,
+		URL:             req.GetUrl(),
+		Additions:       int(req.GetAdditions()),
+		Deletions:       int(req.GetDeletions()),
+		ChangedFiles:    int(req.GetChangedFiles()),
+		CreatedAt:       &now,
+	}
+
+	var err error
+	created := true
+	if req.GetReturnExisting() {
+		pr, created, err = h.prService.CreateOrGetPullRequest(ctx, pr)
+	} else {
+		pr, err = h.prService.CreatePullRequest(ctx, pr)
+	}
+	if err != nil {
+		h.log.Error("gRPC: Failed to create pull request", "error", err)
+		return nil, err
+	}
+
+	if created {
+		h.log.Info("gRPC: Pull request created successfully", "pr_id", pr.PullRequestId)
+	} else {
+		h.log.Info("gRPC: Pull request already exists", "pr_id", pr.PullRequestId)
+	}
+	return &reviewerv1.CreatePullRequestResponse{PullRequest: pullRequestToProto(pr), Created: created}, nil
+}
+
+func (h *PullRequestHandler) GetPullRequest(ctx context.Context, req *reviewerv1.GetPullRequestRequest) (*reviewerv1.GetPullRequestResponse, error) {
+	h.log.Debug("gRPC: Getting pull request request")
+
+	var v violations
+	v.maxLength("repository", req.GetRepository(), maxRepositoryLength)
+	v.required("pull_request_id", req.GetPullRequestId(), maxIDLength)
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	pr, err := h.prService.GetPullRequest(ctx, req.GetRepository(), req.GetPullRequestId())
+	if err != nil {
+		h.log.Error("gRPC: Failed to get pull request", "error", err, "pr_id", req.GetPullRequestId())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Pull request retrieved successfully", "pr_id", req.GetPullRequestId())
+	return &reviewerv1.GetPullRequestResponse{PullRequest: pullRequestToProto(pr)}, nil
+}
+
+func (h *PullRequestHandler) MergePullRequest(ctx context.Context, req *reviewerv1.MergePullRequestRequest) (*reviewerv1.MergePullRequestResponse, error) {
+	h.log.Debug("gRPC: Merging pull request request")
+
+	var v violations
+	v.maxLength("repository", req.GetRepository(), maxRepositoryLength)
+	v.required("pull_request_id", req.GetPullRequestId(), maxIDLength)
+	v.nonNegative("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	version, err := h.prService.MergePullRequest(ctx, req.GetRepository(), req.GetPullRequestId(), req.GetForce(), req.GetVersion())
+	if err != nil {
+		h.log.Error("gRPC: Failed to merge pull request", "error", err, "pr_id", req.GetPullRequestId())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Pull request merged successfully", "pr_id", req.GetPullRequestId())
+	return &reviewerv1.MergePullRequestResponse{Version: version}, nil
+}
+
+func (h *PullRequestHandler) ReassignReviewer(ctx context.Context, req *reviewerv1.ReassignReviewerRequest) (*reviewerv1.ReassignReviewerResponse, error) {
+	h.log.Debug("gRPC: Reassigning reviewer request")
+
+	var v violations
+	v.maxLength("repository", req.GetRepository(), maxRepositoryLength)
+	v.required("pull_request_id", req.GetPullRequestId(), maxIDLength)
+	v.required("old_user_id", req.GetOldUserId(), maxIDLength)
+	v.nonNegative("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	version, err := h.prService.ReassignReviewer(ctx, req.GetRepository(), req.GetPullRequestId(), req.GetOldUserId(), req.GetVersion())
+	if err != nil {
+		h.log.Error("gRPC: Failed to reassign reviewer", "error", err, "pr_id", req.GetPullRequestId())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Successfully reassigned reviewer", "pr_id", req.GetPullRequestId(), "old_reviewer", req.GetOldUserId())
+	return &reviewerv1.ReassignReviewerResponse{Version: version}, nil
+}
+
+func (h *PullRequestHandler) SubmitReview(ctx context.Context, req *reviewerv1.SubmitReviewRequest) (*reviewerv1.SubmitReviewResponse, error) {
+	h.log.Debug("gRPC: Submitting review request")
+
+	var v violations
+	v.maxLength("repository", req.GetRepository(), maxRepositoryLength)
+	v.required("pull_request_id", req.GetPullRequestId(), maxIDLength)
+	v.required("user_id", req.GetUserId(), maxIDLength)
+	verdict, ok := verdicts[req.GetVerdict()]
+	if !ok {
+		v.add("verdict", "must be one of APPROVED, CHANGES_REQUESTED, COMMENTED")
+	}
+	v.nonNegative("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	version, err := h.prService.SubmitReview(ctx, req.GetRepository(), req.GetPullRequestId(), req.GetUserId(), verdict, req.GetVersion())
+	if err != nil {
+		h.log.Error("gRPC: Failed to submit review", "error", err, "pr_id", req.GetPullRequestId())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Review submitted successfully", "pr_id", req.GetPullRequestId(), "reviewer_id", req.GetUserId())
+	return &reviewerv1.SubmitReviewResponse{Version: version}, nil
+}
+
+func (h *PullRequestHandler) GetUserReviews(ctx context.Context, req *reviewerv1.GetUserReviewsRequest) (*reviewerv1.GetUserReviewsResponse, error) {
+	h.log.Debug("gRPC: Getting pull requests by reviewer request")
+
+	var v violations
+	v.required("user_id", req.GetUserId(), maxIDLength)
+	query := pullRequestQuery(&v, req)
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	page, err := h.prService.GetPullRequestsByReviewer(ctx, req.GetUserId(), query)
+	if err != nil {
+		h.log.Error("gRPC: Failed to get pull requests by reviewer", "error", err, "reviewer_id", req.GetUserId())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Successfully retrieved pull requests by reviewer", "reviewer_id", req.GetUserId(), "count", len(page.PullRequests))
+	return &reviewerv1.GetUserReviewsResponse{
+		PullRequests:  pullRequestShortsToProto(page.PullRequests),
+		NextPageToken: nextPageToken(page),
+	}, nil
+}
+
+func (h *PullRequestHandler) SearchPullRequests(ctx context.Context, req *reviewerv1.SearchPullRequestsRequest) (*reviewerv1.SearchPullRequestsResponse, error) {
+	h.log.Debug("gRPC: Searching pull requests request")
+
+	var v violations
+	v.maxLength("reviewer_id", req.GetReviewerId(), maxIDLength)
+	query := pullRequestQuery(&v, req)
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+	query.ReviewerId = req.GetReviewerId()
+
+	page, err := h.prService.SearchPullRequests(ctx, query)
+	if err != nil {
+		h.log.Error("gRPC: Failed to search pull requests", "error", err)
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Successfully searched pull requests", "count", len(page.PullRequests))
+	return &reviewerv1.SearchPullRequestsResponse{
+		PullRequests:  pullRequestShortsToProto(page.PullRequests),
+		NextPageToken: nextPageToken(page),
+	}, nil
+}
+
+func (h *PullRequestHandler) GetReviewStatistics(ctx context.Context, req *reviewerv1.GetReviewStatisticsRequest) (*reviewerv1.GetReviewStatisticsResponse, error) {
+	h.log.Debug("gRPC: Getting review statistics request")
+
+	stats, err := h.prService.GetReviewStatistics(ctx)
+	if err != nil {
+		h.log.Error("gRPC: Failed to get review statistics", "error", err)
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Review statistics retrieved successfully")
+	return statisticsToProto(stats), nil
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/grpc/reviewerv1"
+	"avito-autumn-2025/internal/models"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// pullRequestListRequest is implemented by the requests of pull request lists.
+type pullRequestListRequest interface {
+	GetFilter() *reviewerv1.PullRequestFilter
+	GetSort() reviewerv1.SortField
+	GetOrder() reviewerv1.SortOrder
+	GetPageSize() int32
+	GetPageToken() string
+}
+
+// pullRequestQuery reads the filter, sort and pagination of a pull request
+// list request the way the HTTP API reads its query parameters.
+func pullRequestQuery(v *violations, req pullRequestListRequest) *models.PullRequestQuery {
+	filter := req.GetFilter()
+	query := &models.PullRequestQuery{
+		AuthorId:   filter.GetAuthorId(),
+		TeamName:   filter.GetTeamName(),
+		Repository: filter.GetRepository(),
+		Limit:      models.DefaultPageLimit,
+	}
+	v.maxLength("filter.author_id", query.AuthorId, maxIDLength)
+	v.maxLength("filter.team_name", query.TeamName, maxTeamNameLength)
+	v.maxLength("filter.repository", query.Repository, maxRepositoryLength)
+
+	for i, status := range filter.GetStatuses() {
+		if s, ok := statuses[status]; ok {
+			query.Statuses = append(query.Statuses, s)
+		} else {
+			v.add(fmt.Sprintf("filter.statuses.%d", i), "must be one of OPEN, MERGED, CLOSED")
+		}
+	}
+	query.CreatedFrom = timestampFromProto(v, "filter.created_from", filter.GetCreatedFrom())
+	query.CreatedTo = timestampFromProto(v, "filter.created_to", filter.GetCreatedTo())
+
+	var ok bool
+	if query.Sort, ok = sorts[req.GetSort()]; !ok {
+		v.add("sort", "must be one of created_at, updated_at")
+	}
+	if query.Order, ok = orders[req.GetOrder()]; !ok {
+		v.add("order", "must be one of asc, desc")
+	}
+
+	if size := req.GetPageSize(); size != 0 {
+		if size < 1 || size > models.MaxPageLimit {
+			v.add("page_size", "must be between 1 and %d", models.MaxPageLimit)
+		}
+		query.Limit = int(size)
+	}
+
+	if token := req.GetPageToken(); token != "" {
+		cursor, err := models.DecodePullRequestCursor(token)
+		switch {
+		case err != nil:
+			v.add("page_token", "is malformed")
+		case cursor.Sort != query.Sort || cursor.Order != query.Order:
+			v.add("page_token", "was issued for sort=%s and order=%s", cursor.Sort, cursor.Order)
+		default:
+			query.After = cursor
+		}
+	}
+
+	return query
+}
+
+func timestampFromProto(v *violations, field string, ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		v.add(field, "is not a valid timestamp")
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// nextPageToken returns the token of the page after page, or an empty string
+// on the last page.
+func nextPageToken(page *models.PullRequestPage) string {
+	if page.NextCursor == nil {
+		return ""
+	}
+	return page.NextCursor.Encode()
+}
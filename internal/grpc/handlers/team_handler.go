@@ -0,0 +1,179 @@
+// Package handlers implements the services of the gRPC API on top of the
+// same services as the HTTP handlers.
+package handlers
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/grpc/reviewerv1"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service"
+	"context"
+	"fmt"
+)
+
+type TeamHandler struct {
+	reviewerv1.UnimplementedTeamServiceServer
+	teamService service.Team
+	log         logger.Logger
+}
+
+func NewTeamHandler(teamService service.Team, log logger.Logger) *TeamHandler {
+	return &TeamHandler{
+		teamService: teamService,
+		log:         log,
+	}
+}
+
+func (h *TeamHandler) CreateTeam(ctx context.Context, req *reviewerv1.CreateTeamRequest) (*reviewerv1.CreateTeamResponse, error) {
+	h.log.Debug("gRPC: Creating team request")
+
+	var v violations
+	v.required("team_name", req.GetTeamName(), maxTeamNameLength)
+	v.maxLength("parent_team", req.GetParentTeam(), maxTeamNameLength)
+	team := &models.Team{Name: req.GetTeamName(), ParentTeam: req.GetParentTeam()}
+	for i, member := range req.GetMembers() {
+		team.Users = append(team.Users, memberFromProto(&v, fmt.Sprintf("members.%d", i), member))
+	}
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	team, err := h.teamService.CreateTeam(ctx, team)
+	if err != nil {
+		h.log.Error("gRPC: Failed to create team", "error", err)
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Team created successfully", "team_name", team.Name)
+	return &reviewerv1.CreateTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (h *TeamHandler) GetTeam(ctx context.Context, req *reviewerv1.GetTeamRequest) (*reviewerv1.GetTeamResponse, error) {
+	h.log.Debug("gRPC: Getting team by name request")
+
+	var v violations
+	v.required("team_name", req.GetTeamName(), maxTeamNameLength)
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	var team *models.Team
+	var err error
+	if req.GetIncludeDescendants() {
+		team, err = h.teamService.GetTeamWithDescendants(ctx, req.GetTeamName())
+	} else {
+		team, err = h.teamService.GetTeamWithMembers(ctx, req.GetTeamName())
+	}
+	if err != nil {
+		h.log.Error("gRPC: Failed to get team", "error", err, "team_name", req.GetTeamName())
+		return nil, err
+	}
+
+	if team == nil {
+		h.log.Info("gRPC: Team not found", "team_name", req.GetTeamName())
+		return nil, apperr.NotFound(apperr.CodeTeamNotFound, "team %s not found", req.GetTeamName())
+	}
+
+	h.log.Info("gRPC: Team retrieved successfully", "team_name", team.Name)
+	return &reviewerv1.GetTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (h *TeamHandler) UpdateTeam(ctx context.Context, req *reviewerv1.UpdateTeamRequest) (*reviewerv1.UpdateTeamResponse, error) {
+	h.log.Debug("gRPC: Updating team request")
+
+	var v violations
+	v.required("team_name", req.GetTeamName(), maxTeamNameLength)
+	v.maxLength("new_team_name", req.GetNewTeamName(), maxTeamNameLength)
+	v.maxLength("parent_team", req.GetParentTeam(), maxTeamNameLength)
+	v.nonNegative("version", req.GetVersion())
+	if req.GetNewTeamName() == "" && req.ParentTeam == nil {
+		v.add("", "new_team_name or parent_team is required")
+	}
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	update := &models.TeamUpdate{Name: req.GetNewTeamName(), ParentTeam: req.ParentTeam}
+	team, err := h.teamService.UpdateTeam(ctx, req.GetTeamName(), update, req.GetVersion())
+	if err != nil {
+		h.log.Error("gRPC: Failed to update team", "error", err, "team_name", req.GetTeamName())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Team updated successfully", "team_name", team.Name)
+	return &reviewerv1.UpdateTeamResponse{Team: teamToProto(team)}, nil
+}
+
+func (h *TeamHandler) DeleteTeam(ctx context.Context, req *reviewerv1.DeleteTeamRequest) (*reviewerv1.DeleteTeamResponse, error) {
+	h.log.Debug("gRPC: Deleting team request")
+
+	var v violations
+	v.required("team_name", req.GetTeamName(), maxTeamNameLength)
+	v.nonNegative("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	onOpenReviews := models.OpenReviewsReject
+	if req.GetReassignOpenReviews() {
+		onOpenReviews = models.OpenReviewsReassign
+	}
+
+	reassigned, err := h.teamService.DeleteTeam(ctx, req.GetTeamName(), onOpenReviews, req.GetVersion())
+	if err != nil {
+		h.log.Error("gRPC: Failed to delete team", "error", err, "team_name", req.GetTeamName())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Team deleted successfully", "team_name", req.GetTeamName(), "reassigned_reviews", reassigned)
+	return &reviewerv1.DeleteTeamResponse{ReassignedReviews: int32(reassigned)}, nil
+}
+
+func (h *TeamHandler) AddTeamMember(ctx context.Context, req *reviewerv1.AddTeamMemberRequest) (*reviewerv1.AddTeamMemberResponse, error) {
+	h.log.Debug("gRPC: Adding team member request")
+
+	var v violations
+	v.required("team_name", req.GetTeamName(), maxTeamNameLength)
+	member := memberFromProto(&v, "member", req.GetMember())
+	v.nonNegative("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	team, err := h.teamService.AddTeamMember(ctx, req.GetTeamName(), member, req.GetIsPrimary(), req.GetVersion())
+	if err != nil {
+		h.log.Error("gRPC: Failed to add team member", "error", err, "team_name", req.GetTeamName(), "user_id", member.Id)
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Team member added successfully", "team_name", req.GetTeamName(), "user_id", member.Id)
+	return &reviewerv1.AddTeamMemberResponse{Team: teamToProto(team)}, nil
+}
+
+func (h *TeamHandler) RemoveTeamMember(ctx context.Context, req *reviewerv1.RemoveTeamMemberRequest) (*reviewerv1.RemoveTeamMemberResponse, error) {
+	h.log.Debug("gRPC: Removing team member request")
+
+	var v violations
+	v.required("team_name", req.GetTeamName(), maxTeamNameLength)
+	v.required("user_id", req.GetUserId(), maxIDLength)
+	v.nonNegative("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	reassigned, err := h.teamService.RemoveTeamMember(ctx, req.GetTeamName(), req.GetUserId(), req.GetVersion())
+	if err != nil {
+		h.log.Error("gRPC: Failed to remove team member", "error", err, "team_name", req.GetTeamName(), "user_id", req.GetUserId())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: Team member removed successfully", "team_name", req.GetTeamName(), "user_id", req.GetUserId(), "reassigned_reviews", reassigned)
+	return &reviewerv1.RemoveTeamMemberResponse{ReassignedReviews: int32(reassigned)}, nil
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/grpc/reviewerv1"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service"
+	"context"
+)
+
+type UserHandler struct {
+	reviewerv1.UnimplementedUserServiceServer
+	userService service.User
+	log         logger.Logger
+}
+
+func NewUserHandler(userService service.User, log logger.Logger) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		log:         log,
+	}
+}
+
+func (h *UserHandler) CreateUser(ctx context.Context, req *reviewerv1.CreateUserRequest) (*reviewerv1.CreateUserResponse, error) {
+	h.log.Debug("gRPC: Creating user request")
+
+	var v violations
+	if req.GetUser() == nil {
+		v.add("user", "is required")
+	} else {
+		v.required("user.id", req.GetUser().GetId(), maxIDLength)
+		v.required("user.username", req.GetUser().GetUsername(), maxUsernameLength)
+		v.maxLength("user.team_name", req.GetUser().GetTeamName(), maxTeamNameLength)
+	}
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	user, err := h.userService.CreateUser(ctx, &models.User{
+		Id:       req.GetUser().GetId(),
+		Username: req.GetUser().GetUsername(),
+		IsActive: req.GetUser().GetIsActive(),
+		TeamName: req.GetUser().GetTeamName(),
+	})
+	if err != nil {
+		h.log.Error("gRPC: Failed to create user", "error", err)
+		return nil, err
+	}
+
+	h.log.Info("gRPC: User created successfully", "user_id", user.Id)
+	return &reviewerv1.CreateUserResponse{User: userToProto(user)}, nil
+}
+
+func (h *UserHandler) GetUser(ctx context.Context, req *reviewerv1.GetUserRequest) (*reviewerv1.GetUserResponse, error) {
+	h.log.Debug("gRPC: Getting user by ID request")
+
+	var v violations
+	v.required("id", req.GetId(), maxIDLength)
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	user, err := h.userService.GetUserByID(ctx, req.GetId())
+	if err != nil {
+		h.log.Error("gRPC: Failed to get user", "error", err, "user_id", req.GetId())
+		return nil, err
+	}
+
+	if user == nil {
+		h.log.Info("gRPC: User not found", "user_id", req.GetId())
+		return nil, apperr.NotFound(apperr.CodeUserNotFound, "user %s not found", req.GetId())
+	}
+
+	h.log.Info("gRPC: User retrieved successfully", "user_id", user.Id)
+	return &reviewerv1.GetUserResponse{User: userToProto(user)}, nil
+}
+
+func (h *UserHandler) UpdateUser(ctx context.Context, req *reviewerv1.UpdateUserRequest) (*reviewerv1.UpdateUserResponse, error) {
+	h.log.Debug("gRPC: Updating user request")
+
+	var v violations
+	v.required("id", req.GetId(), maxIDLength)
+	if req.Username == nil && req.IsActive == nil && req.TeamName == nil {
+		v.add("", "username, is_active or team_name is required")
+	}
+	if req.Username != nil {
+		v.required("username", req.GetUsername(), maxUsernameLength)
+	}
+	if req.TeamName != nil {
+		v.required("team_name", req.GetTeamName(), maxTeamNameLength)
+	}
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	update := &models.UserUpdate{Username: req.Username, IsActive: req.IsActive, TeamName: req.TeamName}
+	user, err := h.userService.UpdateUser(ctx, req.GetId(), update)
+	if err != nil {
+		h.log.Error("gRPC: Failed to update user", "error", err, "user_id", req.GetId())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: User updated successfully", "user_id", req.GetId())
+	return &reviewerv1.UpdateUserResponse{User: userToProto(user)}, nil
+}
+
+func (h *UserHandler) DeleteUser(ctx context.Context, req *reviewerv1.DeleteUserRequest) (*reviewerv1.DeleteUserResponse, error) {
+	h.log.Debug("gRPC: Deleting user request")
+
+	var v violations
+	v.required("id", req.GetId(), maxIDLength)
+	if err := v.err(); err != nil {
+		h.log.Error("gRPC: Invalid request", "error", err)
+		return nil, err
+	}
+
+	result, err := h.userService.DeleteUser(ctx, req.GetId())
+	if err != nil {
+		h.log.Error("gRPC: Failed to delete user", "error", err, "user_id", req.GetId())
+		return nil, err
+	}
+
+	h.log.Info("gRPC: User deleted successfully", "user_id", req.GetId(),
+		"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	return &reviewerv1.DeleteUserResponse{
+		ReassignedReviews: int32(result.Reassigned),
+		UnassignedReviews: int32(result.Unassigned),
+	}, nil
+}
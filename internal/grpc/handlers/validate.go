@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Length limits of the stored fields, the same as in the OpenAPI
+// specification of the HTTP API.
+const (
+	maxIDLength         = 50
+	maxUsernameLength   = 100
+	maxTeamNameLength   = 50
+	maxRepositoryLength = 100
+	maxTitleLength      = 200
+	maxURLLength        = 500
+)
+
+// violations collects the constraints a request message violates so that all
+// of them are reported at once.
+type violations []apperr.FieldError
+
+func (v *violations) add(field, format string, args ...any) {
+	*v = append(*v, apperr.FieldError{In: "body", Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// required checks that value is set and at most maxLength characters long.
+func (v *violations) required(field, value string, maxLength int) {
+	if value == "" {
+		v.add(field, "is required")
+		return
+	}
+	v.maxLength(field, value, maxLength)
+}
+
+func (v *violations) maxLength(field, value string, maxLength int) {
+	if utf8.RuneCountInString(value) > maxLength {
+		v.add(field, "must be at most %d characters", maxLength)
+	}
+}
+
+func (v *violations) nonNegative(field string, value int64) {
+	if value < 0 {
+		v.add(field, "must not be negative")
+	}
+}
+
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return apperr.InvalidFields(apperr.CodeInvalidRequest, v)
+}
@@ -0,0 +1,38 @@
+package interceptors
+
+import (
+	"avito-autumn-2025/internal/actor"
+	"avito-autumn-2025/internal/apperr"
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ActorMetadata names the caller on whose behalf a call is made, like the
+// X-Actor-Id header of the HTTP API.
+const ActorMetadata = "x-actor-id"
+
+const maxActorLength = 50
+
+// Actor stores the caller from ActorMetadata in the call context.
+func Actor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := firstMetadata(ctx, ActorMetadata)
+		if len(id) > maxActorLength {
+			return nil, apperr.Invalid(apperr.CodeInvalidRequest, "%s must be at most %d characters", ActorMetadata, maxActorLength)
+		}
+		if id != "" {
+			ctx = actor.WithID(ctx, id)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// firstMetadata returns the first value of the incoming metadata key.
+func firstMetadata(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
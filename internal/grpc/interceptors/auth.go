@@ -0,0 +1,36 @@
+package interceptors
+
+import (
+	"avito-autumn-2025/internal/actor"
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/auth"
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Auth requires a bearer token in the authorization metadata and makes its
+// user both the authenticated caller and the actor of the call, overriding
+// ActorMetadata. Health checks stay public.
+func Auth(authenticator *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+			return handler(ctx, req)
+		}
+
+		token, ok := strings.CutPrefix(firstMetadata(ctx, "authorization"), "Bearer ")
+		if !ok || token == "" {
+			return nil, apperr.Unauthorized(apperr.CodeUnauthorized, "authorization bearer token is required")
+		}
+
+		principal, err := authenticator.Verify(token)
+		if err != nil {
+			return nil, apperr.Unauthorized(apperr.CodeInvalidToken, "%v", err)
+		}
+
+		ctx = auth.WithPrincipal(ctx, principal)
+		return handler(actor.WithID(ctx, principal.UserID), req)
+	}
+}
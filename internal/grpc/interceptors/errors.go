@@ -0,0 +1,111 @@
+// Package interceptors holds the unary server interceptors of the gRPC API.
+// They mirror the middleware of the HTTP API.
+package interceptors
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is the domain of the ErrorInfo details attached to errors.
+const ErrorDomain = "reviewer-service"
+
+var statusCodes = []struct {
+	kind error
+	code codes.Code
+}{
+	{apperr.ErrInvalid, codes.InvalidArgument},
+	{apperr.ErrUnsupportedMediaType, codes.InvalidArgument},
+	{apperr.ErrUnauthorized, codes.Unauthenticated},
+	{apperr.ErrForbidden, codes.PermissionDenied},
+	{apperr.ErrNotFound, codes.NotFound},
+	{apperr.ErrConflict, codes.FailedPrecondition},
+	{apperr.ErrPreconditionFailed, codes.FailedPrecondition},
+	{apperr.ErrNoCandidates, codes.FailedPrecondition},
+	{apperr.ErrVersionMismatch, codes.Aborted},
+}
+
+// Conflicts with an existing resource rather than with its state.
+var alreadyExists = map[string]bool{
+	apperr.CodeUserExists:        true,
+	apperr.CodeTeamExists:        true,
+	apperr.CodePullRequestExists: true,
+}
+
+// Errors converts the errors of handlers into statuses with the code of their
+// kind, the apperr code as the reason of an ErrorInfo detail and the invalid
+// fields as a BadRequest detail. Errors of no known kind are logged and
+// reported as internal.
+func Errors(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return nil, status.FromContextError(err).Err()
+		}
+		if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+			return nil, err
+		}
+
+		code := statusCode(err)
+		if code == codes.Internal {
+			log.Error("Request failed", "error", err, "method", info.FullMethod)
+		}
+		return nil, newStatus(code, err).Err()
+	}
+}
+
+// Recovery reports panics in handlers as internal errors instead of crashing
+// the server. It must run inside Errors.
+func Recovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic in %s: %v", info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func statusCode(err error) codes.Code {
+	for _, s := range statusCodes {
+		if errors.Is(err, s.kind) {
+			if s.kind == apperr.ErrConflict && alreadyExists[apperr.Code(err)] {
+				return codes.AlreadyExists
+			}
+			return s.code
+		}
+	}
+	return codes.Internal
+}
+
+func newStatus(code codes.Code, err error) *status.Status {
+	response := apperr.NewResponse(err)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: response.Error.Code, Domain: ErrorDomain}}
+	if len(response.Error.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(response.Error.Fields))
+		for _, field := range response.Error.Fields {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message})
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	st := status.New(code, response.Error.Message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
@@ -0,0 +1,3610 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: reviewer/v1/reviewer.proto
+
+// The gRPC API of the reviewer service. It exposes the operations of the HTTP
+// API under /api/v1 on a separate port and reports errors with the same
+// stable codes, carried as the reason of a google.rpc.ErrorInfo detail.
+
+package reviewerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MembershipRole int32
+
+const (
+	// Unspecified means member.
+	MembershipRole_MEMBERSHIP_ROLE_UNSPECIFIED MembershipRole = 0
+	MembershipRole_MEMBERSHIP_ROLE_LEAD        MembershipRole = 1
+	MembershipRole_MEMBERSHIP_ROLE_MEMBER      MembershipRole = 2
+	MembershipRole_MEMBERSHIP_ROLE_OBSERVER    MembershipRole = 3
+)
+
+// Enum value maps for MembershipRole.
+var (
+	MembershipRole_name = map[int32]string{
+		0: "MEMBERSHIP_ROLE_UNSPECIFIED",
+		1: "MEMBERSHIP_ROLE_LEAD",
+		2: "MEMBERSHIP_ROLE_MEMBER",
+		3: "MEMBERSHIP_ROLE_OBSERVER",
+	}
+	MembershipRole_value = map[string]int32{
+		"MEMBERSHIP_ROLE_UNSPECIFIED": 0,
+		"MEMBERSHIP_ROLE_LEAD":        1,
+		"MEMBERSHIP_ROLE_MEMBER":      2,
+		"MEMBERSHIP_ROLE_OBSERVER":    3,
+	}
+)
+
+func (x MembershipRole) Enum() *MembershipRole {
+	p := new(MembershipRole)
+	*p = x
+	return p
+}
+
+func (x MembershipRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MembershipRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_reviewer_v1_reviewer_proto_enumTypes[0].Descriptor()
+}
+
+func (MembershipRole) Type() protoreflect.EnumType {
+	return &file_reviewer_v1_reviewer_proto_enumTypes[0]
+}
+
+func (x MembershipRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MembershipRole.Descriptor instead.
+func (MembershipRole) EnumDescriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{0}
+}
+
+type PullRequestStatus int32
+
+const (
+	PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED PullRequestStatus = 0
+	PullRequestStatus_PULL_REQUEST_STATUS_OPEN        PullRequestStatus = 1
+	PullRequestStatus_PULL_REQUEST_STATUS_MERGED      PullRequestStatus = 2
+	PullRequestStatus_PULL_REQUEST_STATUS_CLOSED      PullRequestStatus = 3
+)
+
+// Enum value maps for PullRequestStatus.
+var (
+	PullRequestStatus_name = map[int32]string{
+		0: "PULL_REQUEST_STATUS_UNSPECIFIED",
+		1: "PULL_REQUEST_STATUS_OPEN",
+		2: "PULL_REQUEST_STATUS_MERGED",
+		3: "PULL_REQUEST_STATUS_CLOSED",
+	}
+	PullRequestStatus_value = map[string]int32{
+		"PULL_REQUEST_STATUS_UNSPECIFIED": 0,
+		"PULL_REQUEST_STATUS_OPEN":        1,
+		"PULL_REQUEST_STATUS_MERGED":      2,
+		"PULL_REQUEST_STATUS_CLOSED":      3,
+	}
+)
+
+func (x PullRequestStatus) Enum() *PullRequestStatus {
+	p := new(PullRequestStatus)
+	*p = x
+	return p
+}
+
+func (x PullRequestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_reviewer_v1_reviewer_proto_enumTypes[1].Descriptor()
+}
+
+func (PullRequestStatus) Type() protoreflect.EnumType {
+	return &file_reviewer_v1_reviewer_proto_enumTypes[1]
+}
+
+func (x PullRequestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestStatus.Descriptor instead.
+func (PullRequestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{1}
+}
+
+type PullRequestPriority int32
+
+const (
+	// Unspecified means normal.
+	PullRequestPriority_PULL_REQUEST_PRIORITY_UNSPECIFIED PullRequestPriority = 0
+	PullRequestPriority_PULL_REQUEST_PRIORITY_LOW         PullRequestPriority = 1
+	PullRequestPriority_PULL_REQUEST_PRIORITY_NORMAL      PullRequestPriority = 2
+	PullRequestPriority_PULL_REQUEST_PRIORITY_HIGH        PullRequestPriority = 3
+	PullRequestPriority_PULL_REQUEST_PRIORITY_URGENT      PullRequestPriority = 4
+)
+
+// Enum value maps for PullRequestPriority.
+var (
+	PullRequestPriority_name = map[int32]string{
+		0: "PULL_REQUEST_PRIORITY_UNSPECIFIED",
+		1: "PULL_REQUEST_PRIORITY_LOW",
+		2: "PULL_REQUEST_PRIORITY_NORMAL",
+		3: "PULL_REQUEST_PRIORITY_HIGH",
+		4: "PULL_REQUEST_PRIORITY_URGENT",
+	}
+	PullRequestPriority_value = map[string]int32{
+		"PULL_REQUEST_PRIORITY_UNSPECIFIED": 0,
+		"PULL_REQUEST_PRIORITY_LOW":         1,
+		"PULL_REQUEST_PRIORITY_NORMAL":      2,
+		"PULL_REQUEST_PRIORITY_HIGH":        3,
+		"PULL_REQUEST_PRIORITY_URGENT":      4,
+	}
+)
+
+func (x PullRequestPriority) Enum() *PullRequestPriority {
+	p := new(PullRequestPriority)
+	*p = x
+	return p
+}
+
+func (x PullRequestPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_reviewer_v1_reviewer_proto_enumTypes[2].Descriptor()
+}
+
+func (PullRequestPriority) Type() protoreflect.EnumType {
+	return &file_reviewer_v1_reviewer_proto_enumTypes[2]
+}
+
+func (x PullRequestPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestPriority.Descriptor instead.
+func (PullRequestPriority) EnumDescriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{2}
+}
+
+type ReviewVerdict int32
+
+const (
+	ReviewVerdict_REVIEW_VERDICT_UNSPECIFIED       ReviewVerdict = 0
+	ReviewVerdict_REVIEW_VERDICT_APPROVED          ReviewVerdict = 1
+	ReviewVerdict_REVIEW_VERDICT_CHANGES_REQUESTED ReviewVerdict = 2
+	ReviewVerdict_REVIEW_VERDICT_COMMENTED         ReviewVerdict = 3
+)
+
+// Enum value maps for ReviewVerdict.
+var (
+	ReviewVerdict_name = map[int32]string{
+		0: "REVIEW_VERDICT_UNSPECIFIED",
+		1: "REVIEW_VERDICT_APPROVED",
+		2: "REVIEW_VERDICT_CHANGES_REQUESTED",
+		3: "REVIEW_VERDICT_COMMENTED",
+	}
+	ReviewVerdict_value = map[string]int32{
+		"REVIEW_VERDICT_UNSPECIFIED":       0,
+		"REVIEW_VERDICT_APPROVED":          1,
+		"REVIEW_VERDICT_CHANGES_REQUESTED": 2,
+		"REVIEW_VERDICT_COMMENTED":         3,
+	}
+)
+
+func (x ReviewVerdict) Enum() *ReviewVerdict {
+	p := new(ReviewVerdict)
+	*p = x
+	return p
+}
+
+func (x ReviewVerdict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReviewVerdict) Descriptor() protoreflect.EnumDescriptor {
+	return file_reviewer_v1_reviewer_proto_enumTypes[3].Descriptor()
+}
+
+func (ReviewVerdict) Type() protoreflect.EnumType {
+	return &file_reviewer_v1_reviewer_proto_enumTypes[3]
+}
+
+func (x ReviewVerdict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReviewVerdict.Descriptor instead.
+func (ReviewVerdict) EnumDescriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{3}
+}
+
+type SortField int32
+
+const (
+	// Unspecified means creation time.
+	SortField_SORT_FIELD_UNSPECIFIED SortField = 0
+	SortField_SORT_FIELD_CREATED_AT  SortField = 1
+	SortField_SORT_FIELD_UPDATED_AT  SortField = 2
+)
+
+// Enum value maps for SortField.
+var (
+	SortField_name = map[int32]string{
+		0: "SORT_FIELD_UNSPECIFIED",
+		1: "SORT_FIELD_CREATED_AT",
+		2: "SORT_FIELD_UPDATED_AT",
+	}
+	SortField_value = map[string]int32{
+		"SORT_FIELD_UNSPECIFIED": 0,
+		"SORT_FIELD_CREATED_AT":  1,
+		"SORT_FIELD_UPDATED_AT":  2,
+	}
+)
+
+func (x SortField) Enum() *SortField {
+	p := new(SortField)
+	*p = x
+	return p
+}
+
+func (x SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_reviewer_v1_reviewer_proto_enumTypes[4].Descriptor()
+}
+
+func (SortField) Type() protoreflect.EnumType {
+	return &file_reviewer_v1_reviewer_proto_enumTypes[4]
+}
+
+func (x SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortField.Descriptor instead.
+func (SortField) EnumDescriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{4}
+}
+
+type SortOrder int32
+
+const (
+	// Unspecified means descending.
+	SortOrder_SORT_ORDER_UNSPECIFIED SortOrder = 0
+	SortOrder_SORT_ORDER_ASC         SortOrder = 1
+	SortOrder_SORT_ORDER_DESC        SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_ASC",
+		2: "SORT_ORDER_DESC",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED": 0,
+		"SORT_ORDER_ASC":         1,
+		"SORT_ORDER_DESC":        2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_reviewer_v1_reviewer_proto_enumTypes[5].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_reviewer_v1_reviewer_proto_enumTypes[5]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{5}
+}
+
+type User struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsActive bool                   `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	// Primary team of the user.
+	TeamName string `protobuf:"bytes,4,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// Every team the user belongs to.
+	Teams []string `protobuf:"bytes,5,rep,name=teams,proto3" json:"teams,omitempty"`
+	// Membership role when the user is listed as a team member.
+	Role          MembershipRole `protobuf:"varint,6,opt,name=role,proto3,enum=reviewer.v1.MembershipRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *User) GetTeams() []string {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+func (x *User) GetRole() MembershipRole {
+	if x != nil {
+		return x.Role
+	}
+	return MembershipRole_MEMBERSHIP_ROLE_UNSPECIFIED
+}
+
+type Team struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TeamName   string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ParentTeam string                 `protobuf:"bytes,2,opt,name=parent_team,json=parentTeam,proto3" json:"parent_team,omitempty"`
+	Members    []*User                `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	// Filled when the team is requested with its descendants.
+	Subteams []*Team `protobuf:"bytes,4,rep,name=subteams,proto3" json:"subteams,omitempty"`
+	// Version of the team and its members, not of its subteams. Mutations can
+	// be conditioned on it.
+	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Team) Reset() {
+	*x = Team{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Team) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Team) ProtoMessage() {}
+
+func (x *Team) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Team.ProtoReflect.Descriptor instead.
+func (*Team) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Team) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *Team) GetParentTeam() string {
+	if x != nil {
+		return x.ParentTeam
+	}
+	return ""
+}
+
+func (x *Team) GetMembers() []*User {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Team) GetSubteams() []*Team {
+	if x != nil {
+		return x.Subteams
+	}
+	return nil
+}
+
+func (x *Team) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type PullRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId     string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName   string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status            PullRequestStatus      `protobuf:"varint,4,opt,name=status,proto3,enum=reviewer.v1.PullRequestStatus" json:"status,omitempty"`
+	Repository        string                 `protobuf:"bytes,5,opt,name=repository,proto3" json:"repository,omitempty"`
+	TeamName          string                 `protobuf:"bytes,6,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Labels            []string               `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty"`
+	Priority          PullRequestPriority    `protobuf:"varint,8,opt,name=priority,proto3,enum=reviewer.v1.PullRequestPriority" json:"priority,omitempty"`
+	Url               string                 `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	Additions         int32                  `protobuf:"varint,10,opt,name=additions,proto3" json:"additions,omitempty"`
+	Deletions         int32                  `protobuf:"varint,11,opt,name=deletions,proto3" json:"deletions,omitempty"`
+	ChangedFiles      int32                  `protobuf:"varint,12,opt,name=changed_files,json=changedFiles,proto3" json:"changed_files,omitempty"`
+	AssignedReviewers []string               `protobuf:"bytes,13,rep,name=assigned_reviewers,json=assignedReviewers,proto3" json:"assigned_reviewers,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	MergedAt          *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=merged_at,json=mergedAt,proto3" json:"merged_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StaleAt           *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=stale_at,json=staleAt,proto3" json:"stale_at,omitempty"`
+	ClosedAt          *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	Version           int64                  `protobuf:"varint,19,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PullRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequest) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *PullRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *PullRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *PullRequest) GetPriority() PullRequestPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return PullRequestPriority_PULL_REQUEST_PRIORITY_UNSPECIFIED
+}
+
+func (x *PullRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PullRequest) GetAdditions() int32 {
+	if x != nil {
+		return x.Additions
+	}
+	return 0
+}
+
+func (x *PullRequest) GetDeletions() int32 {
+	if x != nil {
+		return x.Deletions
+	}
+	return 0
+}
+
+func (x *PullRequest) GetChangedFiles() int32 {
+	if x != nil {
+		return x.ChangedFiles
+	}
+	return 0
+}
+
+func (x *PullRequest) GetAssignedReviewers() []string {
+	if x != nil {
+		return x.AssignedReviewers
+	}
+	return nil
+}
+
+func (x *PullRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetMergedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MergedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetStaleAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StaleAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *PullRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type PullRequestShort struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Repository      string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	PullRequestId   string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,3,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status          PullRequestStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=reviewer.v1.PullRequestStatus" json:"status,omitempty"`
+	TeamName        string                 `protobuf:"bytes,6,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PullRequestShort) Reset() {
+	*x = PullRequestShort{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestShort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestShort) ProtoMessage() {}
+
+func (x *PullRequestShort) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestShort.ProtoReflect.Descriptor instead.
+func (*PullRequestShort) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PullRequestShort) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetStatus() PullRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *PullRequestShort) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *PullRequestShort) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequestShort) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateTeamRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TeamName      string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ParentTeam    string                 `protobuf:"bytes,2,opt,name=parent_team,json=parentTeam,proto3" json:"parent_team,omitempty"`
+	Members       []*User                `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamRequest) Reset() {
+	*x = CreateTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamRequest) ProtoMessage() {}
+
+func (x *CreateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamRequest.ProtoReflect.Descriptor instead.
+func (*CreateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *CreateTeamRequest) GetParentTeam() string {
+	if x != nil {
+		return x.ParentTeam
+	}
+	return ""
+}
+
+func (x *CreateTeamRequest) GetMembers() []*User {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type CreateTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTeamResponse) Reset() {
+	*x = CreateTeamResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTeamResponse) ProtoMessage() {}
+
+func (x *CreateTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTeamResponse.ProtoReflect.Descriptor instead.
+func (*CreateTeamResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type GetTeamRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TeamName           string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	IncludeDescendants bool                   `protobuf:"varint,2,opt,name=include_descendants,json=includeDescendants,proto3" json:"include_descendants,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetTeamRequest) Reset() {
+	*x = GetTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamRequest) ProtoMessage() {}
+
+func (x *GetTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamRequest.ProtoReflect.Descriptor instead.
+func (*GetTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *GetTeamRequest) GetIncludeDescendants() bool {
+	if x != nil {
+		return x.IncludeDescendants
+	}
+	return false
+}
+
+type GetTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTeamResponse) Reset() {
+	*x = GetTeamResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTeamResponse) ProtoMessage() {}
+
+func (x *GetTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTeamResponse.ProtoReflect.Descriptor instead.
+func (*GetTeamResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type UpdateTeamRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// New name of the team; empty keeps the name.
+	NewTeamName string `protobuf:"bytes,2,opt,name=new_team_name,json=newTeamName,proto3" json:"new_team_name,omitempty"`
+	// New parent team; an empty string detaches the team.
+	ParentTeam *string `protobuf:"bytes,3,opt,name=parent_team,json=parentTeam,proto3,oneof" json:"parent_team,omitempty"`
+	// Version the update is conditioned on; zero applies it unconditionally.
+	Version       int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTeamRequest) Reset() {
+	*x = UpdateTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamRequest) ProtoMessage() {}
+
+func (x *UpdateTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *UpdateTeamRequest) GetNewTeamName() string {
+	if x != nil {
+		return x.NewTeamName
+	}
+	return ""
+}
+
+func (x *UpdateTeamRequest) GetParentTeam() string {
+	if x != nil && x.ParentTeam != nil {
+		return *x.ParentTeam
+	}
+	return ""
+}
+
+func (x *UpdateTeamRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateTeamResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTeamResponse) Reset() {
+	*x = UpdateTeamResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTeamResponse) ProtoMessage() {}
+
+func (x *UpdateTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTeamResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTeamResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateTeamResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type DeleteTeamRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	// Reassign open reviews held by members instead of rejecting the deletion.
+	ReassignOpenReviews bool `protobuf:"varint,2,opt,name=reassign_open_reviews,json=reassignOpenReviews,proto3" json:"reassign_open_reviews,omitempty"`
+	// Version the deletion is conditioned on; zero applies it unconditionally.
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTeamRequest) Reset() {
+	*x = DeleteTeamRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamRequest) ProtoMessage() {}
+
+func (x *DeleteTeamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTeamRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteTeamRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *DeleteTeamRequest) GetReassignOpenReviews() bool {
+	if x != nil {
+		return x.ReassignOpenReviews
+	}
+	return false
+}
+
+func (x *DeleteTeamRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteTeamResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ReassignedReviews int32                  `protobuf:"varint,1,opt,name=reassigned_reviews,json=reassignedReviews,proto3" json:"reassigned_reviews,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeleteTeamResponse) Reset() {
+	*x = DeleteTeamResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTeamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTeamResponse) ProtoMessage() {}
+
+func (x *DeleteTeamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTeamResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTeamResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteTeamResponse) GetReassignedReviews() int32 {
+	if x != nil {
+		return x.ReassignedReviews
+	}
+	return 0
+}
+
+type AddTeamMemberRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Member   *User                  `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	// Make the team the member's primary team.
+	IsPrimary bool `protobuf:"varint,3,opt,name=is_primary,json=isPrimary,proto3" json:"is_primary,omitempty"`
+	// Version the change is conditioned on; zero applies it unconditionally.
+	Version       int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMemberRequest) Reset() {
+	*x = AddTeamMemberRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamMemberRequest) ProtoMessage() {}
+
+func (x *AddTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AddTeamMemberRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *AddTeamMemberRequest) GetMember() *User {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+func (x *AddTeamMemberRequest) GetIsPrimary() bool {
+	if x != nil {
+		return x.IsPrimary
+	}
+	return false
+}
+
+func (x *AddTeamMemberRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type AddTeamMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Team          *Team                  `protobuf:"bytes,1,opt,name=team,proto3" json:"team,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddTeamMemberResponse) Reset() {
+	*x = AddTeamMemberResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddTeamMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddTeamMemberResponse) ProtoMessage() {}
+
+func (x *AddTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddTeamMemberResponse) GetTeam() *Team {
+	if x != nil {
+		return x.Team
+	}
+	return nil
+}
+
+type RemoveTeamMemberRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Version the change is conditioned on; zero applies it unconditionally.
+	Version       int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveTeamMemberRequest) Reset() {
+	*x = RemoveTeamMemberRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTeamMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTeamMemberRequest) ProtoMessage() {}
+
+func (x *RemoveTeamMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTeamMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMemberRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RemoveTeamMemberRequest) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *RemoveTeamMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveTeamMemberRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RemoveTeamMemberResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ReassignedReviews int32                  `protobuf:"varint,1,opt,name=reassigned_reviews,json=reassignedReviews,proto3" json:"reassigned_reviews,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RemoveTeamMemberResponse) Reset() {
+	*x = RemoveTeamMemberResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveTeamMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveTeamMemberResponse) ProtoMessage() {}
+
+func (x *RemoveTeamMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveTeamMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveTeamMemberResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RemoveTeamMemberResponse) GetReassignedReviews() int32 {
+	if x != nil {
+		return x.ReassignedReviews
+	}
+	return 0
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateUserRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type CreateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserResponse) Reset() {
+	*x = CreateUserResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserResponse) ProtoMessage() {}
+
+func (x *CreateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserResponse.ProtoReflect.Descriptor instead.
+func (*CreateUserResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserResponse) Reset() {
+	*x = GetUserResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserResponse) ProtoMessage() {}
+
+func (x *GetUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserResponse.ProtoReflect.Descriptor instead.
+func (*GetUserResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// UpdateUserRequest changes the fields that are set and leaves the others.
+type UpdateUserRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	IsActive *bool                  `protobuf:"varint,3,opt,name=is_active,json=isActive,proto3,oneof" json:"is_active,omitempty"`
+	// Makes the team the user's primary team.
+	TeamName      *string `protobuf:"bytes,4,opt,name=team_name,json=teamName,proto3,oneof" json:"team_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetIsActive() bool {
+	if x != nil && x.IsActive != nil {
+		return *x.IsActive
+	}
+	return false
+}
+
+func (x *UpdateUserRequest) GetTeamName() string {
+	if x != nil && x.TeamName != nil {
+		return *x.TeamName
+	}
+	return ""
+}
+
+type UpdateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserResponse) ProtoMessage() {}
+
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DeleteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserRequest) ProtoMessage() {}
+
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteUserResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ReassignedReviews int32                  `protobuf:"varint,1,opt,name=reassigned_reviews,json=reassignedReviews,proto3" json:"reassigned_reviews,omitempty"`
+	// Open reviews dropped because nobody could take them over.
+	UnassignedReviews int32 `protobuf:"varint,2,opt,name=unassigned_reviews,json=unassignedReviews,proto3" json:"unassigned_reviews,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserResponse) ProtoMessage() {}
+
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DeleteUserResponse) GetReassignedReviews() int32 {
+	if x != nil {
+		return x.ReassignedReviews
+	}
+	return 0
+}
+
+func (x *DeleteUserResponse) GetUnassignedReviews() int32 {
+	if x != nil {
+		return x.UnassignedReviews
+	}
+	return 0
+}
+
+type CreatePullRequestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PullRequestId   string                 `protobuf:"bytes,1,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	PullRequestName string                 `protobuf:"bytes,2,opt,name=pull_request_name,json=pullRequestName,proto3" json:"pull_request_name,omitempty"`
+	AuthorId        string                 `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Repository      string                 `protobuf:"bytes,4,opt,name=repository,proto3" json:"repository,omitempty"`
+	Labels          []string               `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
+	Priority        PullRequestPriority    `protobuf:"varint,6,opt,name=priority,proto3,enum=reviewer.v1.PullRequestPriority" json:"priority,omitempty"`
+	Url             string                 `protobuf:"bytes,7,opt,name=url,proto3" json:"url,omitempty"`
+	Additions       int32                  `protobuf:"varint,8,opt,name=additions,proto3" json:"additions,omitempty"`
+	Deletions       int32                  `protobuf:"varint,9,opt,name=deletions,proto3" json:"deletions,omitempty"`
+	ChangedFiles    int32                  `protobuf:"varint,10,opt,name=changed_files,json=changedFiles,proto3" json:"changed_files,omitempty"`
+	// Return the existing pull request instead of failing when it exists.
+	ReturnExisting bool `protobuf:"varint,11,opt,name=return_existing,json=returnExisting,proto3" json:"return_existing,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestRequest) Reset() {
+	*x = CreatePullRequestRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestRequest) ProtoMessage() {}
+
+func (x *CreatePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetPullRequestName() string {
+	if x != nil {
+		return x.PullRequestName
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreatePullRequestRequest) GetPriority() PullRequestPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return PullRequestPriority_PULL_REQUEST_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreatePullRequestRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreatePullRequestRequest) GetAdditions() int32 {
+	if x != nil {
+		return x.Additions
+	}
+	return 0
+}
+
+func (x *CreatePullRequestRequest) GetDeletions() int32 {
+	if x != nil {
+		return x.Deletions
+	}
+	return 0
+}
+
+func (x *CreatePullRequestRequest) GetChangedFiles() int32 {
+	if x != nil {
+		return x.ChangedFiles
+	}
+	return 0
+}
+
+func (x *CreatePullRequestRequest) GetReturnExisting() bool {
+	if x != nil {
+		return x.ReturnExisting
+	}
+	return false
+}
+
+type CreatePullRequestResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	// False when an existing pull request was returned.
+	Created       bool `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePullRequestResponse) Reset() {
+	*x = CreatePullRequestResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePullRequestResponse) ProtoMessage() {}
+
+func (x *CreatePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*CreatePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreatePullRequestResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+func (x *CreatePullRequestResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+type GetPullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repository    string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestRequest) Reset() {
+	*x = GetPullRequestRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestRequest) ProtoMessage() {}
+
+func (x *GetPullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestRequest.ProtoReflect.Descriptor instead.
+func (*GetPullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetPullRequestRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *GetPullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+type GetPullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PullRequest   *PullRequest           `protobuf:"bytes,1,opt,name=pull_request,json=pullRequest,proto3" json:"pull_request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPullRequestResponse) Reset() {
+	*x = GetPullRequestResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPullRequestResponse) ProtoMessage() {}
+
+func (x *GetPullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPullRequestResponse.ProtoReflect.Descriptor instead.
+func (*GetPullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetPullRequestResponse) GetPullRequest() *PullRequest {
+	if x != nil {
+		return x.PullRequest
+	}
+	return nil
+}
+
+type MergePullRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repository    string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Merge even when changes are requested.
+	Force bool `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	// Version the merge is conditioned on; zero applies it unconditionally.
+	Version       int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestRequest) Reset() {
+	*x = MergePullRequestRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestRequest) ProtoMessage() {}
+
+func (x *MergePullRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestRequest.ProtoReflect.Descriptor instead.
+func (*MergePullRequestRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MergePullRequestRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *MergePullRequestRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *MergePullRequestRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *MergePullRequestRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type MergePullRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergePullRequestResponse) Reset() {
+	*x = MergePullRequestResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergePullRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergePullRequestResponse) ProtoMessage() {}
+
+func (x *MergePullRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergePullRequestResponse.ProtoReflect.Descriptor instead.
+func (*MergePullRequestResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MergePullRequestResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ReassignReviewerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repository    string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Reviewer to replace.
+	OldUserId string `protobuf:"bytes,3,opt,name=old_user_id,json=oldUserId,proto3" json:"old_user_id,omitempty"`
+	// Version the change is conditioned on; zero applies it unconditionally.
+	Version       int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerRequest) Reset() {
+	*x = ReassignReviewerRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerRequest) ProtoMessage() {}
+
+func (x *ReassignReviewerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerRequest.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReassignReviewerRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetOldUserId() string {
+	if x != nil {
+		return x.OldUserId
+	}
+	return ""
+}
+
+func (x *ReassignReviewerRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ReassignReviewerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReassignReviewerResponse) Reset() {
+	*x = ReassignReviewerResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReassignReviewerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReassignReviewerResponse) ProtoMessage() {}
+
+func (x *ReassignReviewerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReassignReviewerResponse.ProtoReflect.Descriptor instead.
+func (*ReassignReviewerResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReassignReviewerResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SubmitReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repository    string                 `protobuf:"bytes,1,opt,name=repository,proto3" json:"repository,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	// Reviewer submitting the verdict.
+	UserId  string        `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Verdict ReviewVerdict `protobuf:"varint,4,opt,name=verdict,proto3,enum=reviewer.v1.ReviewVerdict" json:"verdict,omitempty"`
+	// Version the review is conditioned on; zero applies it unconditionally.
+	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitReviewRequest) Reset() {
+	*x = SubmitReviewRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitReviewRequest) ProtoMessage() {}
+
+func (x *SubmitReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitReviewRequest.ProtoReflect.Descriptor instead.
+func (*SubmitReviewRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SubmitReviewRequest) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *SubmitReviewRequest) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *SubmitReviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SubmitReviewRequest) GetVerdict() ReviewVerdict {
+	if x != nil {
+		return x.Verdict
+	}
+	return ReviewVerdict_REVIEW_VERDICT_UNSPECIFIED
+}
+
+func (x *SubmitReviewRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SubmitReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitReviewResponse) Reset() {
+	*x = SubmitReviewResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitReviewResponse) ProtoMessage() {}
+
+func (x *SubmitReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitReviewResponse.ProtoReflect.Descriptor instead.
+func (*SubmitReviewResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SubmitReviewResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// PullRequestFilter narrows pull request lists. Empty fields match everything.
+type PullRequestFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	TeamName      string                 `protobuf:"bytes,2,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	Repository    string                 `protobuf:"bytes,3,opt,name=repository,proto3" json:"repository,omitempty"`
+	Statuses      []PullRequestStatus    `protobuf:"varint,4,rep,packed,name=statuses,proto3,enum=reviewer.v1.PullRequestStatus" json:"statuses,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequestFilter) Reset() {
+	*x = PullRequestFilter{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestFilter) ProtoMessage() {}
+
+func (x *PullRequestFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestFilter.ProtoReflect.Descriptor instead.
+func (*PullRequestFilter) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PullRequestFilter) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PullRequestFilter) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *PullRequestFilter) GetRepository() string {
+	if x != nil {
+		return x.Repository
+	}
+	return ""
+}
+
+func (x *PullRequestFilter) GetStatuses() []PullRequestStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *PullRequestFilter) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *PullRequestFilter) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+type GetUserReviewsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filter *PullRequestFilter     `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Sort   SortField              `protobuf:"varint,3,opt,name=sort,proto3,enum=reviewer.v1.SortField" json:"sort,omitempty"`
+	Order  SortOrder              `protobuf:"varint,4,opt,name=order,proto3,enum=reviewer.v1.SortOrder" json:"order,omitempty"`
+	// Defaults to 50, at most 200.
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, issued for the same sort and order.
+	PageToken     string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserReviewsRequest) Reset() {
+	*x = GetUserReviewsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserReviewsRequest) ProtoMessage() {}
+
+func (x *GetUserReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserReviewsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetUserReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserReviewsRequest) GetFilter() *PullRequestFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetUserReviewsRequest) GetSort() SortField {
+	if x != nil {
+		return x.Sort
+	}
+	return SortField_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *GetUserReviewsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *GetUserReviewsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetUserReviewsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetUserReviewsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests []*PullRequestShort    `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserReviewsResponse) Reset() {
+	*x = GetUserReviewsResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserReviewsResponse) ProtoMessage() {}
+
+func (x *GetUserReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserReviewsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetUserReviewsResponse) GetPullRequests() []*PullRequestShort {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *GetUserReviewsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchPullRequestsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only pull requests this user is assigned to review.
+	ReviewerId string             `protobuf:"bytes,1,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	Filter     *PullRequestFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Sort       SortField          `protobuf:"varint,3,opt,name=sort,proto3,enum=reviewer.v1.SortField" json:"sort,omitempty"`
+	Order      SortOrder          `protobuf:"varint,4,opt,name=order,proto3,enum=reviewer.v1.SortOrder" json:"order,omitempty"`
+	// Defaults to 50, at most 200.
+	PageSize int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page, issued for the same sort and order.
+	PageToken     string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPullRequestsRequest) Reset() {
+	*x = SearchPullRequestsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPullRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPullRequestsRequest) ProtoMessage() {}
+
+func (x *SearchPullRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPullRequestsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPullRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SearchPullRequestsRequest) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *SearchPullRequestsRequest) GetFilter() *PullRequestFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SearchPullRequestsRequest) GetSort() SortField {
+	if x != nil {
+		return x.Sort
+	}
+	return SortField_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *SearchPullRequestsRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *SearchPullRequestsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchPullRequestsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchPullRequestsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	PullRequests []*PullRequestShort    `protobuf:"bytes,1,rep,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPullRequestsResponse) Reset() {
+	*x = SearchPullRequestsResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPullRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPullRequestsResponse) ProtoMessage() {}
+
+func (x *SearchPullRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPullRequestsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPullRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SearchPullRequestsResponse) GetPullRequests() []*PullRequestShort {
+	if x != nil {
+		return x.PullRequests
+	}
+	return nil
+}
+
+func (x *SearchPullRequestsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetReviewStatisticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewStatisticsRequest) Reset() {
+	*x = GetReviewStatisticsRequest{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewStatisticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewStatisticsRequest) ProtoMessage() {}
+
+func (x *GetReviewStatisticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewStatisticsRequest.ProtoReflect.Descriptor instead.
+func (*GetReviewStatisticsRequest) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{39}
+}
+
+type ReviewerStatistics struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ReviewerId       string                 `protobuf:"bytes,1,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	ReviewerName     string                 `protobuf:"bytes,2,opt,name=reviewer_name,json=reviewerName,proto3" json:"reviewer_name,omitempty"`
+	AssignedPrsCount int32                  `protobuf:"varint,3,opt,name=assigned_prs_count,json=assignedPrsCount,proto3" json:"assigned_prs_count,omitempty"`
+	LastAssignedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_assigned_at,json=lastAssignedAt,proto3" json:"last_assigned_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReviewerStatistics) Reset() {
+	*x = ReviewerStatistics{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewerStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewerStatistics) ProtoMessage() {}
+
+func (x *ReviewerStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewerStatistics.ProtoReflect.Descriptor instead.
+func (*ReviewerStatistics) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReviewerStatistics) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *ReviewerStatistics) GetReviewerName() string {
+	if x != nil {
+		return x.ReviewerName
+	}
+	return ""
+}
+
+func (x *ReviewerStatistics) GetAssignedPrsCount() int32 {
+	if x != nil {
+		return x.AssignedPrsCount
+	}
+	return 0
+}
+
+func (x *ReviewerStatistics) GetLastAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAssignedAt
+	}
+	return nil
+}
+
+// TeamStatistics counts the team itself and, in the subtree fields, the team
+// together with all of its descendants. Deleted teams are listed with deleted
+// set and keep only their reviews.
+type TeamStatistics struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	TeamName                 string                 `protobuf:"bytes,1,opt,name=team_name,json=teamName,proto3" json:"team_name,omitempty"`
+	ParentTeam               string                 `protobuf:"bytes,2,opt,name=parent_team,json=parentTeam,proto3" json:"parent_team,omitempty"`
+	MemberCount              int32                  `protobuf:"varint,3,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	ActiveMemberCount        int32                  `protobuf:"varint,4,opt,name=active_member_count,json=activeMemberCount,proto3" json:"active_member_count,omitempty"`
+	PrsCreated               int32                  `protobuf:"varint,5,opt,name=prs_created,json=prsCreated,proto3" json:"prs_created,omitempty"`
+	ReviewsAssigned          int32                  `protobuf:"varint,6,opt,name=reviews_assigned,json=reviewsAssigned,proto3" json:"reviews_assigned,omitempty"`
+	SubtreeMemberCount       int32                  `protobuf:"varint,7,opt,name=subtree_member_count,json=subtreeMemberCount,proto3" json:"subtree_member_count,omitempty"`
+	SubtreeActiveMemberCount int32                  `protobuf:"varint,8,opt,name=subtree_active_member_count,json=subtreeActiveMemberCount,proto3" json:"subtree_active_member_count,omitempty"`
+	SubtreePrsCreated        int32                  `protobuf:"varint,9,opt,name=subtree_prs_created,json=subtreePrsCreated,proto3" json:"subtree_prs_created,omitempty"`
+	SubtreeReviewsAssigned   int32                  `protobuf:"varint,10,opt,name=subtree_reviews_assigned,json=subtreeReviewsAssigned,proto3" json:"subtree_reviews_assigned,omitempty"`
+	Deleted                  bool                   `protobuf:"varint,11,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *TeamStatistics) Reset() {
+	*x = TeamStatistics{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamStatistics) ProtoMessage() {}
+
+func (x *TeamStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamStatistics.ProtoReflect.Descriptor instead.
+func (*TeamStatistics) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TeamStatistics) GetTeamName() string {
+	if x != nil {
+		return x.TeamName
+	}
+	return ""
+}
+
+func (x *TeamStatistics) GetParentTeam() string {
+	if x != nil {
+		return x.ParentTeam
+	}
+	return ""
+}
+
+func (x *TeamStatistics) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *TeamStatistics) GetActiveMemberCount() int32 {
+	if x != nil {
+		return x.ActiveMemberCount
+	}
+	return 0
+}
+
+func (x *TeamStatistics) GetPrsCreated() int32 {
+	if x != nil {
+		return x.PrsCreated
+	}
+	return 0
+}
+
+func (x *TeamStatistics) GetReviewsAssigned() int32 {
+	if x != nil {
+		return x.ReviewsAssigned
+	}
+	return 0
+}
+
+func (x *TeamStatistics) GetSubtreeMemberCount() int32 {
+	if x != nil {
+		return x.SubtreeMemberCount
+	}
+	return 0
+}
+
+func (x *TeamStatistics) GetSubtreeActiveMemberCount() int32 {
+	if x != nil {
+		return x.SubtreeActiveMemberCount
+	}
+	return 0
+}
+
+func (x *TeamStatistics) GetSubtreePrsCreated() int32 {
+	if x != nil {
+		return x.SubtreePrsCreated
+	}
+	return 0
+}
+
+func (x *TeamStatistics) GetSubtreeReviewsAssigned() int32 {
+	if x != nil {
+		return x.SubtreeReviewsAssigned
+	}
+	return 0
+}
+
+func (x *TeamStatistics) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+type GetReviewStatisticsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TotalPrs      int32                  `protobuf:"varint,1,opt,name=total_prs,json=totalPrs,proto3" json:"total_prs,omitempty"`
+	OpenPrs       int32                  `protobuf:"varint,2,opt,name=open_prs,json=openPrs,proto3" json:"open_prs,omitempty"`
+	MergedPrs     int32                  `protobuf:"varint,3,opt,name=merged_prs,json=mergedPrs,proto3" json:"merged_prs,omitempty"`
+	ClosedPrs     int32                  `protobuf:"varint,4,opt,name=closed_prs,json=closedPrs,proto3" json:"closed_prs,omitempty"`
+	ReviewerStats []*ReviewerStatistics  `protobuf:"bytes,5,rep,name=reviewer_stats,json=reviewerStats,proto3" json:"reviewer_stats,omitempty"`
+	TeamStats     []*TeamStatistics      `protobuf:"bytes,6,rep,name=team_stats,json=teamStats,proto3" json:"team_stats,omitempty"`
+	GeneratedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReviewStatisticsResponse) Reset() {
+	*x = GetReviewStatisticsResponse{}
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReviewStatisticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReviewStatisticsResponse) ProtoMessage() {}
+
+func (x *GetReviewStatisticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_reviewer_v1_reviewer_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReviewStatisticsResponse.ProtoReflect.Descriptor instead.
+func (*GetReviewStatisticsResponse) Descriptor() ([]byte, []int) {
+	return file_reviewer_v1_reviewer_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetReviewStatisticsResponse) GetTotalPrs() int32 {
+	if x != nil {
+		return x.TotalPrs
+	}
+	return 0
+}
+
+func (x *GetReviewStatisticsResponse) GetOpenPrs() int32 {
+	if x != nil {
+		return x.OpenPrs
+	}
+	return 0
+}
+
+func (x *GetReviewStatisticsResponse) GetMergedPrs() int32 {
+	if x != nil {
+		return x.MergedPrs
+	}
+	return 0
+}
+
+func (x *GetReviewStatisticsResponse) GetClosedPrs() int32 {
+	if x != nil {
+		return x.ClosedPrs
+	}
+	return 0
+}
+
+func (x *GetReviewStatisticsResponse) GetReviewerStats() []*ReviewerStatistics {
+	if x != nil {
+		return x.ReviewerStats
+	}
+	return nil
+}
+
+func (x *GetReviewStatisticsResponse) GetTeamStats() []*TeamStatistics {
+	if x != nil {
+		return x.TeamStats
+	}
+	return nil
+}
+
+func (x *GetReviewStatisticsResponse) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+var File_reviewer_v1_reviewer_proto protoreflect.FileDescriptor
+
+const file_reviewer_v1_reviewer_proto_rawDesc = "" +
+	"\n" +
+	"\x1areviewer/v1/reviewer.proto\x12\vreviewer.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1b\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\x12\x1b\n" +
+	"\tteam_name\x18\x04 \x01(\tR\bteamName\x12\x14\n" +
+	"\x05teams\x18\x05 \x03(\tR\x05teams\x12/\n" +
+	"\x04role\x18\x06 \x01(\x0e2\x1b.reviewer.v1.MembershipRoleR\x04role\"\xba\x01\n" +
+	"\x04Team\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vparent_team\x18\x02 \x01(\tR\n" +
+	"parentTeam\x12+\n" +
+	"\amembers\x18\x03 \x03(\v2\x11.reviewer.v1.UserR\amembers\x12-\n" +
+	"\bsubteams\x18\x04 \x03(\v2\x11.reviewer.v1.TeamR\bsubteams\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\"\xa4\x06\n" +
+	"\vPullRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x126\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1e.reviewer.v1.PullRequestStatusR\x06status\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x05 \x01(\tR\n" +
+	"repository\x12\x1b\n" +
+	"\tteam_name\x18\x06 \x01(\tR\bteamName\x12\x16\n" +
+	"\x06labels\x18\a \x03(\tR\x06labels\x12<\n" +
+	"\bpriority\x18\b \x01(\x0e2 .reviewer.v1.PullRequestPriorityR\bpriority\x12\x10\n" +
+	"\x03url\x18\t \x01(\tR\x03url\x12\x1c\n" +
+	"\tadditions\x18\n" +
+	" \x01(\x05R\tadditions\x12\x1c\n" +
+	"\tdeletions\x18\v \x01(\x05R\tdeletions\x12#\n" +
+	"\rchanged_files\x18\f \x01(\x05R\fchangedFiles\x12-\n" +
+	"\x12assigned_reviewers\x18\r \x03(\tR\x11assignedReviewers\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
+	"\tmerged_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\bmergedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x125\n" +
+	"\bstale_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\astaleAt\x127\n" +
+	"\tclosed_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12\x18\n" +
+	"\aversion\x18\x13 \x01(\x03R\aversion\"\xee\x02\n" +
+	"\x10PullRequestShort\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x03 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x04 \x01(\tR\bauthorId\x126\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1e.reviewer.v1.PullRequestStatusR\x06status\x12\x1b\n" +
+	"\tteam_name\x18\x06 \x01(\tR\bteamName\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"~\n" +
+	"\x11CreateTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vparent_team\x18\x02 \x01(\tR\n" +
+	"parentTeam\x12+\n" +
+	"\amembers\x18\x03 \x03(\v2\x11.reviewer.v1.UserR\amembers\";\n" +
+	"\x12CreateTeamResponse\x12%\n" +
+	"\x04team\x18\x01 \x01(\v2\x11.reviewer.v1.TeamR\x04team\"^\n" +
+	"\x0eGetTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12/\n" +
+	"\x13include_descendants\x18\x02 \x01(\bR\x12includeDescendants\"8\n" +
+	"\x0fGetTeamResponse\x12%\n" +
+	"\x04team\x18\x01 \x01(\v2\x11.reviewer.v1.TeamR\x04team\"\xa4\x01\n" +
+	"\x11UpdateTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\"\n" +
+	"\rnew_team_name\x18\x02 \x01(\tR\vnewTeamName\x12$\n" +
+	"\vparent_team\x18\x03 \x01(\tH\x00R\n" +
+	"parentTeam\x88\x01\x01\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversionB\x0e\n" +
+	"\f_parent_team\";\n" +
+	"\x12UpdateTeamResponse\x12%\n" +
+	"\x04team\x18\x01 \x01(\v2\x11.reviewer.v1.TeamR\x04team\"~\n" +
+	"\x11DeleteTeamRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x122\n" +
+	"\x15reassign_open_reviews\x18\x02 \x01(\bR\x13reassignOpenReviews\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"C\n" +
+	"\x12DeleteTeamResponse\x12-\n" +
+	"\x12reassigned_reviews\x18\x01 \x01(\x05R\x11reassignedReviews\"\x97\x01\n" +
+	"\x14AddTeamMemberRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12)\n" +
+	"\x06member\x18\x02 \x01(\v2\x11.reviewer.v1.UserR\x06member\x12\x1d\n" +
+	"\n" +
+	"is_primary\x18\x03 \x01(\bR\tisPrimary\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\">\n" +
+	"\x15AddTeamMemberResponse\x12%\n" +
+	"\x04team\x18\x01 \x01(\v2\x11.reviewer.v1.TeamR\x04team\"i\n" +
+	"\x17RemoveTeamMemberRequest\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"I\n" +
+	"\x18RemoveTeamMemberResponse\x12-\n" +
+	"\x12reassigned_reviews\x18\x01 \x01(\x05R\x11reassignedReviews\":\n" +
+	"\x11CreateUserRequest\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.reviewer.v1.UserR\x04user\";\n" +
+	"\x12CreateUserResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.reviewer.v1.UserR\x04user\" \n" +
+	"\x0eGetUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"8\n" +
+	"\x0fGetUserResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.reviewer.v1.UserR\x04user\"\xb1\x01\n" +
+	"\x11UpdateUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12 \n" +
+	"\tis_active\x18\x03 \x01(\bH\x01R\bisActive\x88\x01\x01\x12 \n" +
+	"\tteam_name\x18\x04 \x01(\tH\x02R\bteamName\x88\x01\x01B\v\n" +
+	"\t_usernameB\f\n" +
+	"\n" +
+	"_is_activeB\f\n" +
+	"\n" +
+	"_team_name\";\n" +
+	"\x12UpdateUserResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.reviewer.v1.UserR\x04user\"#\n" +
+	"\x11DeleteUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"r\n" +
+	"\x12DeleteUserResponse\x12-\n" +
+	"\x12reassigned_reviews\x18\x01 \x01(\x05R\x11reassignedReviews\x12-\n" +
+	"\x12unassigned_reviews\x18\x02 \x01(\x05R\x11unassignedReviews\"\x9d\x03\n" +
+	"\x18CreatePullRequestRequest\x12&\n" +
+	"\x0fpull_request_id\x18\x01 \x01(\tR\rpullRequestId\x12*\n" +
+	"\x11pull_request_name\x18\x02 \x01(\tR\x0fpullRequestName\x12\x1b\n" +
+	"\tauthor_id\x18\x03 \x01(\tR\bauthorId\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x04 \x01(\tR\n" +
+	"repository\x12\x16\n" +
+	"\x06labels\x18\x05 \x03(\tR\x06labels\x12<\n" +
+	"\bpriority\x18\x06 \x01(\x0e2 .reviewer.v1.PullRequestPriorityR\bpriority\x12\x10\n" +
+	"\x03url\x18\a \x01(\tR\x03url\x12\x1c\n" +
+	"\tadditions\x18\b \x01(\x05R\tadditions\x12\x1c\n" +
+	"\tdeletions\x18\t \x01(\x05R\tdeletions\x12#\n" +
+	"\rchanged_files\x18\n" +
+	" \x01(\x05R\fchangedFiles\x12'\n" +
+	"\x0freturn_existing\x18\v \x01(\bR\x0ereturnExisting\"r\n" +
+	"\x19CreatePullRequestResponse\x12;\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x18.reviewer.v1.PullRequestR\vpullRequest\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"_\n" +
+	"\x15GetPullRequestRequest\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\"U\n" +
+	"\x16GetPullRequestResponse\x12;\n" +
+	"\fpull_request\x18\x01 \x01(\v2\x18.reviewer.v1.PullRequestR\vpullRequest\"\x91\x01\n" +
+	"\x17MergePullRequestRequest\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\"4\n" +
+	"\x18MergePullRequestResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\"\x9b\x01\n" +
+	"\x17ReassignReviewerRequest\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x1e\n" +
+	"\vold_user_id\x18\x03 \x01(\tR\toldUserId\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\"4\n" +
+	"\x18ReassignReviewerResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\"\xc6\x01\n" +
+	"\x13SubmitReviewRequest\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x01 \x01(\tR\n" +
+	"repository\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x124\n" +
+	"\averdict\x18\x04 \x01(\x0e2\x1a.reviewer.v1.ReviewVerdictR\averdict\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\"0\n" +
+	"\x14SubmitReviewResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\"\xa3\x02\n" +
+	"\x11PullRequestFilter\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x1b\n" +
+	"\tteam_name\x18\x02 \x01(\tR\bteamName\x12\x1e\n" +
+	"\n" +
+	"repository\x18\x03 \x01(\tR\n" +
+	"repository\x12:\n" +
+	"\bstatuses\x18\x04 \x03(\x0e2\x1e.reviewer.v1.PullRequestStatusR\bstatuses\x12=\n" +
+	"\fcreated_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\"\xfe\x01\n" +
+	"\x15GetUserReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x126\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1e.reviewer.v1.PullRequestFilterR\x06filter\x12*\n" +
+	"\x04sort\x18\x03 \x01(\x0e2\x16.reviewer.v1.SortFieldR\x04sort\x12,\n" +
+	"\x05order\x18\x04 \x01(\x0e2\x16.reviewer.v1.SortOrderR\x05order\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"\x84\x01\n" +
+	"\x16GetUserReviewsResponse\x12B\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x1d.reviewer.v1.PullRequestShortR\fpullRequests\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8a\x02\n" +
+	"\x19SearchPullRequestsRequest\x12\x1f\n" +
+	"\vreviewer_id\x18\x01 \x01(\tR\n" +
+	"reviewerId\x126\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1e.reviewer.v1.PullRequestFilterR\x06filter\x12*\n" +
+	"\x04sort\x18\x03 \x01(\x0e2\x16.reviewer.v1.SortFieldR\x04sort\x12,\n" +
+	"\x05order\x18\x04 \x01(\x0e2\x16.reviewer.v1.SortOrderR\x05order\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"\x88\x01\n" +
+	"\x1aSearchPullRequestsResponse\x12B\n" +
+	"\rpull_requests\x18\x01 \x03(\v2\x1d.reviewer.v1.PullRequestShortR\fpullRequests\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x1c\n" +
+	"\x1aGetReviewStatisticsRequest\"\xce\x01\n" +
+	"\x12ReviewerStatistics\x12\x1f\n" +
+	"\vreviewer_id\x18\x01 \x01(\tR\n" +
+	"reviewerId\x12#\n" +
+	"\rreviewer_name\x18\x02 \x01(\tR\freviewerName\x12,\n" +
+	"\x12assigned_prs_count\x18\x03 \x01(\x05R\x10assignedPrsCount\x12D\n" +
+	"\x10last_assigned_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastAssignedAt\"\xe2\x03\n" +
+	"\x0eTeamStatistics\x12\x1b\n" +
+	"\tteam_name\x18\x01 \x01(\tR\bteamName\x12\x1f\n" +
+	"\vparent_team\x18\x02 \x01(\tR\n" +
+	"parentTeam\x12!\n" +
+	"\fmember_count\x18\x03 \x01(\x05R\vmemberCount\x12.\n" +
+	"\x13active_member_count\x18\x04 \x01(\x05R\x11activeMemberCount\x12\x1f\n" +
+	"\vprs_created\x18\x05 \x01(\x05R\n" +
+	"prsCreated\x12)\n" +
+	"\x10reviews_assigned\x18\x06 \x01(\x05R\x0freviewsAssigned\x120\n" +
+	"\x14subtree_member_count\x18\a \x01(\x05R\x12subtreeMemberCount\x12=\n" +
+	"\x1bsubtree_active_member_count\x18\b \x01(\x05R\x18subtreeActiveMemberCount\x12.\n" +
+	"\x13subtree_prs_created\x18\t \x01(\x05R\x11subtreePrsCreated\x128\n" +
+	"\x18subtree_reviews_assigned\x18\n" +
+	" \x01(\x05R\x16subtreeReviewsAssigned\x12\x18\n" +
+	"\adeleted\x18\v \x01(\bR\adeleted\"\xd6\x02\n" +
+	"\x1bGetReviewStatisticsResponse\x12\x1b\n" +
+	"\ttotal_prs\x18\x01 \x01(\x05R\btotalPrs\x12\x19\n" +
+	"\bopen_prs\x18\x02 \x01(\x05R\aopenPrs\x12\x1d\n" +
+	"\n" +
+	"merged_prs\x18\x03 \x01(\x05R\tmergedPrs\x12\x1d\n" +
+	"\n" +
+	"closed_prs\x18\x04 \x01(\x05R\tclosedPrs\x12F\n" +
+	"\x0ereviewer_stats\x18\x05 \x03(\v2\x1f.reviewer.v1.ReviewerStatisticsR\rreviewerStats\x12:\n" +
+	"\n" +
+	"team_stats\x18\x06 \x03(\v2\x1b.reviewer.v1.TeamStatisticsR\tteamStats\x12=\n" +
+	"\fgenerated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt*\x85\x01\n" +
+	"\x0eMembershipRole\x12\x1f\n" +
+	"\x1bMEMBERSHIP_ROLE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14MEMBERSHIP_ROLE_LEAD\x10\x01\x12\x1a\n" +
+	"\x16MEMBERSHIP_ROLE_MEMBER\x10\x02\x12\x1c\n" +
+	"\x18MEMBERSHIP_ROLE_OBSERVER\x10\x03*\x96\x01\n" +
+	"\x11PullRequestStatus\x12#\n" +
+	"\x1fPULL_REQUEST_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18PULL_REQUEST_STATUS_OPEN\x10\x01\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_MERGED\x10\x02\x12\x1e\n" +
+	"\x1aPULL_REQUEST_STATUS_CLOSED\x10\x03*\xbf\x01\n" +
+	"\x13PullRequestPriority\x12%\n" +
+	"!PULL_REQUEST_PRIORITY_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PULL_REQUEST_PRIORITY_LOW\x10\x01\x12 \n" +
+	"\x1cPULL_REQUEST_PRIORITY_NORMAL\x10\x02\x12\x1e\n" +
+	"\x1aPULL_REQUEST_PRIORITY_HIGH\x10\x03\x12 \n" +
+	"\x1cPULL_REQUEST_PRIORITY_URGENT\x10\x04*\x90\x01\n" +
+	"\rReviewVerdict\x12\x1e\n" +
+	"\x1aREVIEW_VERDICT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17REVIEW_VERDICT_APPROVED\x10\x01\x12$\n" +
+	" REVIEW_VERDICT_CHANGES_REQUESTED\x10\x02\x12\x1c\n" +
+	"\x18REVIEW_VERDICT_COMMENTED\x10\x03*]\n" +
+	"\tSortField\x12\x1a\n" +
+	"\x16SORT_FIELD_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15SORT_FIELD_CREATED_AT\x10\x01\x12\x19\n" +
+	"\x15SORT_FIELD_UPDATED_AT\x10\x02*P\n" +
+	"\tSortOrder\x12\x1a\n" +
+	"\x16SORT_ORDER_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSORT_ORDER_ASC\x10\x01\x12\x13\n" +
+	"\x0fSORT_ORDER_DESC\x10\x022\xf9\x03\n" +
+	"\vTeamService\x12M\n" +
+	"\n" +
+	"CreateTeam\x12\x1e.reviewer.v1.CreateTeamRequest\x1a\x1f.reviewer.v1.CreateTeamResponse\x12D\n" +
+	"\aGetTeam\x12\x1b.reviewer.v1.GetTeamRequest\x1a\x1c.reviewer.v1.GetTeamResponse\x12M\n" +
+	"\n" +
+	"UpdateTeam\x12\x1e.reviewer.v1.UpdateTeamRequest\x1a\x1f.reviewer.v1.UpdateTeamResponse\x12M\n" +
+	"\n" +
+	"DeleteTeam\x12\x1e.reviewer.v1.DeleteTeamRequest\x1a\x1f.reviewer.v1.DeleteTeamResponse\x12V\n" +
+	"\rAddTeamMember\x12!.reviewer.v1.AddTeamMemberRequest\x1a\".reviewer.v1.AddTeamMemberResponse\x12_\n" +
+	"\x10RemoveTeamMember\x12$.reviewer.v1.RemoveTeamMemberRequest\x1a%.reviewer.v1.RemoveTeamMemberResponse2\xc0\x02\n" +
+	"\vUserService\x12M\n" +
+	"\n" +
+	"CreateUser\x12\x1e.reviewer.v1.CreateUserRequest\x1a\x1f.reviewer.v1.CreateUserResponse\x12D\n" +
+	"\aGetUser\x12\x1b.reviewer.v1.GetUserRequest\x1a\x1c.reviewer.v1.GetUserResponse\x12M\n" +
+	"\n" +
+	"UpdateUser\x12\x1e.reviewer.v1.UpdateUserRequest\x1a\x1f.reviewer.v1.UpdateUserResponse\x12M\n" +
+	"\n" +
+	"DeleteUser\x12\x1e.reviewer.v1.DeleteUserRequest\x1a\x1f.reviewer.v1.DeleteUserResponse2\x96\x06\n" +
+	"\x12PullRequestService\x12b\n" +
+	"\x11CreatePullRequest\x12%.reviewer.v1.CreatePullRequestRequest\x1a&.reviewer.v1.CreatePullRequestResponse\x12Y\n" +
+	"\x0eGetPullRequest\x12\".reviewer.v1.GetPullRequestRequest\x1a#.reviewer.v1.GetPullRequestResponse\x12_\n" +
+	"\x10MergePullRequest\x12$.reviewer.v1.MergePullRequestRequest\x1a%.reviewer.v1.MergePullRequestResponse\x12_\n" +
+	"\x10ReassignReviewer\x12$.reviewer.v1.ReassignReviewerRequest\x1a%.reviewer.v1.ReassignReviewerResponse\x12S\n" +
+	"\fSubmitReview\x12 .reviewer.v1.SubmitReviewRequest\x1a!.reviewer.v1.SubmitReviewResponse\x12Y\n" +
+	"\x0eGetUserReviews\x12\".reviewer.v1.GetUserReviewsRequest\x1a#.reviewer.v1.GetUserReviewsResponse\x12e\n" +
+	"\x12SearchPullRequests\x12&.reviewer.v1.SearchPullRequestsRequest\x1a'.reviewer.v1.SearchPullRequestsResponse\x12h\n" +
+	"\x13GetReviewStatistics\x12'.reviewer.v1.GetReviewStatisticsRequest\x1a(.reviewer.v1.GetReviewStatisticsResponseB7Z5avito-autumn-2025/internal/grpc/reviewerv1;reviewerv1b\x06proto3"
+
+var (
+	file_reviewer_v1_reviewer_proto_rawDescOnce sync.Once
+	file_reviewer_v1_reviewer_proto_rawDescData []byte
+)
+
+func file_reviewer_v1_reviewer_proto_rawDescGZIP() []byte {
+	file_reviewer_v1_reviewer_proto_rawDescOnce.Do(func() {
+		file_reviewer_v1_reviewer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reviewer_v1_reviewer_proto_rawDesc), len(file_reviewer_v1_reviewer_proto_rawDesc)))
+	})
+	return file_reviewer_v1_reviewer_proto_rawDescData
+}
+
+var file_reviewer_v1_reviewer_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_reviewer_v1_reviewer_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_reviewer_v1_reviewer_proto_goTypes = []any{
+	(MembershipRole)(0),                 // 0: reviewer.v1.MembershipRole
+	(PullRequestStatus)(0),              // 1: reviewer.v1.PullRequestStatus
+	(PullRequestPriority)(0),            // 2: reviewer.v1.PullRequestPriority
+	(ReviewVerdict)(0),                  // 3: reviewer.v1.ReviewVerdict
+	(SortField)(0),                      // 4: reviewer.v1.SortField
+	(SortOrder)(0),                      // 5: reviewer.v1.SortOrder
+	(*User)(nil),                        // 6: reviewer.v1.User
+	(*Team)(nil),                        // 7: reviewer.v1.Team
+	(*PullRequest)(nil),                 // 8: reviewer.v1.PullRequest
+	(*PullRequestShort)(nil),            // 9: reviewer.v1.PullRequestShort
+	(*CreateTeamRequest)(nil),           // 10: reviewer.v1.CreateTeamRequest
+	(*CreateTeamResponse)(nil),          // 11: reviewer.v1.CreateTeamResponse
+	(*GetTeamRequest)(nil),              // 12: reviewer.v1.GetTeamRequest
+	(*GetTeamResponse)(nil),             // 13: reviewer.v1.GetTeamResponse
+	(*UpdateTeamRequest)(nil),           // 14: reviewer.v1.UpdateTeamRequest
+	(*UpdateTeamResponse)(nil),          // 15: reviewer.v1.UpdateTeamResponse
+	(*DeleteTeamRequest)(nil),           // 16: reviewer.v1.DeleteTeamRequest
+	(*DeleteTeamResponse)(nil),          // 17: reviewer.v1.DeleteTeamResponse
+	(*AddTeamMemberRequest)(nil),        // 18: reviewer.v1.AddTeamMemberRequest
+	(*AddTeamMemberResponse)(nil),       // 19: reviewer.v1.AddTeamMemberResponse
+	(*RemoveTeamMemberRequest)(nil),     // 20: reviewer.v1.RemoveTeamMemberRequest
+	(*RemoveTeamMemberResponse)(nil),    // 21: reviewer.v1.RemoveTeamMemberResponse
+	(*CreateUserRequest)(nil),           // 22: reviewer.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),          // 23: reviewer.v1.CreateUserResponse
+	(*GetUserRequest)(nil),              // 24: reviewer.v1.GetUserRequest
+	(*GetUserResponse)(nil),             // 25: reviewer.v1.GetUserResponse
+	(*UpdateUserRequest)(nil),           // 26: reviewer.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),          // 27: reviewer.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),           // 28: reviewer.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),          // 29: reviewer.v1.DeleteUserResponse
+	(*CreatePullRequestRequest)(nil),    // 30: reviewer.v1.CreatePullRequestRequest
+	(*CreatePullRequestResponse)(nil),   // 31: reviewer.v1.CreatePullRequestResponse
+	(*GetPullRequestRequest)(nil),       // 32: reviewer.v1.GetPullRequestRequest
+	(*GetPullRequestResponse)(nil),      // 33: reviewer.v1.GetPullRequestResponse
+	(*MergePullRequestRequest)(nil),     // 34: reviewer.v1.MergePullRequestRequest
+	(*MergePullRequestResponse)(nil),    // 35: reviewer.v1.MergePullRequestResponse
+	(*ReassignReviewerRequest)(nil),     // 36: reviewer.v1.ReassignReviewerRequest
+	(*ReassignReviewerResponse)(nil),    // 37: reviewer.v1.ReassignReviewerResponse
+	(*SubmitReviewRequest)(nil),         // 38: reviewer.v1.SubmitReviewRequest
+	(*SubmitReviewResponse)(nil),        // 39: reviewer.v1.SubmitReviewResponse
+	(*PullRequestFilter)(nil),           // 40: reviewer.v1.PullRequestFilter
+	(*GetUserReviewsRequest)(nil),       // 41: reviewer.v1.GetUserReviewsRequest
+	(*GetUserReviewsResponse)(nil),      // 42: reviewer.v1.GetUserReviewsResponse
+	(*SearchPullRequestsRequest)(nil),   // 43: reviewer.v1.SearchPullRequestsRequest
+	(*SearchPullRequestsResponse)(nil),  // 44: reviewer.v1.SearchPullRequestsResponse
+	(*GetReviewStatisticsRequest)(nil),  // 45: reviewer.v1.GetReviewStatisticsRequest
+	(*ReviewerStatistics)(nil),          // 46: reviewer.v1.ReviewerStatistics
+	(*TeamStatistics)(nil),              // 47: reviewer.v1.TeamStatistics
+	(*GetReviewStatisticsResponse)(nil), // 48: reviewer.v1.GetReviewStatisticsResponse
+	(*timestamppb.Timestamp)(nil),       // 49: google.protobuf.Timestamp
+}
+var file_reviewer_v1_reviewer_proto_depIdxs = []int32{
+	0,  // 0: reviewer.v1.User.role:type_name -> reviewer.v1.MembershipRole
+	6,  // 1: reviewer.v1.Team.members:type_name -> reviewer.v1.User
+	7,  // 2: reviewer.v1.Team.subteams:type_name -> reviewer.v1.Team
+	1,  // 3: reviewer.v1.PullRequest.status:type_name -> reviewer.v1.PullRequestStatus
+	2,  // 4: reviewer.v1.PullRequest.priority:type_name -> reviewer.v1.PullRequestPriority
+	49, // 5: reviewer.v1.PullRequest.created_at:type_name -> google.protobuf.Timestamp
+	49, // 6: reviewer.v1.PullRequest.merged_at:type_name -> google.protobuf.Timestamp
+	49, // 7: reviewer.v1.PullRequest.updated_at:type_name -> google.protobuf.Timestamp
+	49, // 8: reviewer.v1.PullRequest.stale_at:type_name -> google.protobuf.Timestamp
+	49, // 9: reviewer.v1.PullRequest.closed_at:type_name -> google.protobuf.Timestamp
+	1,  // 10: reviewer.v1.PullRequestShort.status:type_name -> reviewer.v1.PullRequestStatus
+	49, // 11: reviewer.v1.PullRequestShort.created_at:type_name -> google.protobuf.Timestamp
+	49, // 12: reviewer.v1.PullRequestShort.updated_at:type_name -> google.protobuf.Timestamp
+	6,  // 13: reviewer.v1.CreateTeamRequest.members:type_name -> reviewer.v1.User
+	7,  // 14: reviewer.v1.CreateTeamResponse.team:type_name -> reviewer.v1.Team
+	7,  // 15: reviewer.v1.GetTeamResponse.team:type_name -> reviewer.v1.Team
+	7,  // 16: reviewer.v1.UpdateTeamResponse.team:type_name -> reviewer.v1.Team
+	6,  // 17: reviewer.v1.AddTeamMemberRequest.member:type_name -> reviewer.v1.User
+	7,  // 18: reviewer.v1.AddTeamMemberResponse.team:type_name -> reviewer.v1.Team
+	6,  // 19: reviewer.v1.CreateUserRequest.user:type_name -> reviewer.v1.User
+	6,  // 20: reviewer.v1.CreateUserResponse.user:type_name -> reviewer.v1.User
+	6,  // 21: reviewer.v1.GetUserResponse.user:type_name -> reviewer.v1.User
+	6,  // 22: reviewer.v1.UpdateUserResponse.user:type_name -> reviewer.v1.User
+	2,  // 23: reviewer.v1.CreatePullRequestRequest.priority:type_name -> reviewer.v1.PullRequestPriority
+	8,  // 24: reviewer.v1.CreatePullRequestResponse.pull_request:type_name -> reviewer.v1.PullRequest
+	8,  // 25: reviewer.v1.GetPullRequestResponse.pull_request:type_name -> reviewer.v1.PullRequest
+	3,  // 26: reviewer.v1.SubmitReviewRequest.verdict:type_name -> reviewer.v1.ReviewVerdict
+	1,  // 27: reviewer.v1.PullRequestFilter.statuses:type_name -> reviewer.v1.PullRequestStatus
+	49, // 28: reviewer.v1.PullRequestFilter.created_from:type_name -> google.protobuf.Timestamp
+	49, // 29: reviewer.v1.PullRequestFilter.created_to:type_name -> google.protobuf.Timestamp
+	40, // 30: reviewer.v1.GetUserReviewsRequest.filter:type_name -> reviewer.v1.PullRequestFilter
+	4,  // 31: reviewer.v1.GetUserReviewsRequest.sort:type_name -> reviewer.v1.SortField
+	5,  // 32: reviewer.v1.GetUserReviewsRequest.order:type_name -> reviewer.v1.SortOrder
+	9,  // 33: reviewer.v1.GetUserReviewsResponse.pull_requests:type_name -> reviewer.v1.PullRequestShort
+	40, // 34: reviewer.v1.SearchPullRequestsRequest.filter:type_name -> reviewer.v1.PullRequestFilter
+	4,  // 35: reviewer.v1.SearchPullRequestsRequest.sort:type_name -> reviewer.v1.SortField
+	5,  // 36: reviewer.v1.SearchPullRequestsRequest.order:type_name -> reviewer.v1.SortOrder
+	9,  // 37: reviewer.v1.SearchPullRequestsResponse.pull_requests:type_name -> reviewer.v1.PullRequestShort
+	49, // 38: reviewer.v1.ReviewerStatistics.last_assigned_at:type_name -> google.protobuf.Timestamp
+	46, // 39: reviewer.v1.GetReviewStatisticsResponse.reviewer_stats:type_name -> reviewer.v1.ReviewerStatistics
+	47, // 40: reviewer.v1.GetReviewStatisticsResponse.team_stats:type_name -> reviewer.v1.TeamStatistics
+	49, // 41: reviewer.v1.GetReviewStatisticsResponse.generated_at:type_name -> google.protobuf.Timestamp
+	10, // 42: reviewer.v1.TeamService.CreateTeam:input_type -> reviewer.v1.CreateTeamRequest
+	12, // 43: reviewer.v1.TeamService.GetTeam:input_type -> reviewer.v1.GetTeamRequest
+	14, // 44: reviewer.v1.TeamService.UpdateTeam:input_type -> reviewer.v1.UpdateTeamRequest
+	16, // 45: reviewer.v1.TeamService.DeleteTeam:input_type -> reviewer.v1.DeleteTeamRequest
+	18, // 46: reviewer.v1.TeamService.AddTeamMember:input_type -> reviewer.v1.AddTeamMemberRequest
+	20, // 47: reviewer.v1.TeamService.RemoveTeamMember:input_type -> reviewer.v1.RemoveTeamMemberRequest
+	22, // 48: reviewer.v1.UserService.CreateUser:input_type -> reviewer.v1.CreateUserRequest
+	24, // 49: reviewer.v1.UserService.GetUser:input_type -> reviewer.v1.GetUserRequest
+	26, // 50: reviewer.v1.UserService.UpdateUser:input_type -> reviewer.v1.UpdateUserRequest
+	28, // 51: reviewer.v1.UserService.DeleteUser:input_type -> reviewer.v1.DeleteUserRequest
+	30, // 52: reviewer.v1.PullRequestService.CreatePullRequest:input_type -> reviewer.v1.CreatePullRequestRequest
+	32, // 53: reviewer.v1.PullRequestService.GetPullRequest:input_type -> reviewer.v1.GetPullRequestRequest
+	34, // 54: reviewer.v1.PullRequestService.MergePullRequest:input_type -> reviewer.v1.MergePullRequestRequest
+	36, // 55: reviewer.v1.PullRequestService.ReassignReviewer:input_type -> reviewer.v1.ReassignReviewerRequest
+	38, // 56: reviewer.v1.PullRequestService.SubmitReview:input_type -> reviewer.v1.SubmitReviewRequest
+	41, // 57: reviewer.v1.PullRequestService.GetUserReviews:input_type -> reviewer.v1.GetUserReviewsRequest
+	43, // 58: reviewer.v1.PullRequestService.SearchPullRequests:input_type -> reviewer.v1.SearchPullRequestsRequest
+	45, // 59: reviewer.v1.PullRequestService.GetReviewStatistics:input_type -> reviewer.v1.GetReviewStatisticsRequest
+	11, // 60: reviewer.v1.TeamService.CreateTeam:output_type -> reviewer.v1.CreateTeamResponse
+	13, // 61: reviewer.v1.TeamService.GetTeam:output_type -> reviewer.v1.GetTeamResponse
+	15, // 62: reviewer.v1.TeamService.UpdateTeam:output_type -> reviewer.v1.UpdateTeamResponse
+	17, // 63: reviewer.v1.TeamService.DeleteTeam:output_type -> reviewer.v1.DeleteTeamResponse
+	19, // 64: reviewer.v1.TeamService.AddTeamMember:output_type -> reviewer.v1.AddTeamMemberResponse
+	21, // 65: reviewer.v1.TeamService.RemoveTeamMember:output_type -> reviewer.v1.RemoveTeamMemberResponse
+	23, // 66: reviewer.v1.UserService.CreateUser:output_type -> reviewer.v1.CreateUserResponse
+	25, // 67: reviewer.v1.UserService.GetUser:output_type -> reviewer.v1.GetUserResponse
+	27, // 68: reviewer.v1.UserService.UpdateUser:output_type -> reviewer.v1.UpdateUserResponse
+	29, // 69: reviewer.v1.UserService.DeleteUser:output_type -> reviewer.v1.DeleteUserResponse
+	31, // 70: reviewer.v1.PullRequestService.CreatePullRequest:output_type -> reviewer.v1.CreatePullRequestResponse
+	33, // 71: reviewer.v1.PullRequestService.GetPullRequest:output_type -> reviewer.v1.GetPullRequestResponse
+	35, // 72: reviewer.v1.PullRequestService.MergePullRequest:output_type -> reviewer.v1.MergePullRequestResponse
+	37, // 73: reviewer.v1.PullRequestService.ReassignReviewer:output_type -> reviewer.v1.ReassignReviewerResponse
+	39, // 74: reviewer.v1.PullRequestService.SubmitReview:output_type -> reviewer.v1.SubmitReviewResponse
+	42, // 75: reviewer.v1.PullRequestService.GetUserReviews:output_type -> reviewer.v1.GetUserReviewsResponse
+	44, // 76: reviewer.v1.PullRequestService.SearchPullRequests:output_type -> reviewer.v1.SearchPullRequestsResponse
+	48, // 77: reviewer.v1.PullRequestService.GetReviewStatistics:output_type -> reviewer.v1.GetReviewStatisticsResponse
+	60, // [60:78] is the sub-list for method output_type
+	42, // [42:60] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
+}
+
+func init() { file_reviewer_v1_reviewer_proto_init() }
+func file_reviewer_v1_reviewer_proto_init() {
+	if File_reviewer_v1_reviewer_proto != nil {
+		return
+	}
+	file_reviewer_v1_reviewer_proto_msgTypes[8].OneofWrappers = []any{}
+	file_reviewer_v1_reviewer_proto_msgTypes[20].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reviewer_v1_reviewer_proto_rawDesc), len(file_reviewer_v1_reviewer_proto_rawDesc)),
+			NumEnums:      6,
+			NumMessages:   43,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_reviewer_v1_reviewer_proto_goTypes,
+		DependencyIndexes: file_reviewer_v1_reviewer_proto_depIdxs,
+		EnumInfos:         file_reviewer_v1_reviewer_proto_enumTypes,
+		MessageInfos:      file_reviewer_v1_reviewer_proto_msgTypes,
+	}.Build()
+	File_reviewer_v1_reviewer_proto = out.File
+	file_reviewer_v1_reviewer_proto_goTypes = nil
+	file_reviewer_v1_reviewer_proto_depIdxs = nil
+}
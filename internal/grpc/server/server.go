@@ -0,0 +1,99 @@
+package server
+
+import (
+	"avito-autumn-2025/internal/auth"
+	"avito-autumn-2025/internal/grpc/handlers"
+	"avito-autumn-2025/internal/grpc/interceptors"
+	"avito-autumn-2025/internal/grpc/reviewerv1"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/service"
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type Server struct {
+	server *grpc.Server
+	health *health.Server
+	log    logger.Logger
+}
+
+// NewServer creates the gRPC server with the team, user and pull request
+// services, health checking and server reflection. A nil authenticator
+// disables authentication and team-level permission checks and trusts the
+// actor named in the metadata; it is meant for development only.
+func NewServer(
+	userService service.User,
+	teamService service.Team,
+	prService service.PullRequest,
+	log logger.Logger,
+	authenticator *auth.Authenticator,
+) *Server {
+	chain := []grpc.UnaryServerInterceptor{
+		interceptors.Errors(log),
+		interceptors.Recovery(),
+	}
+	if authenticator != nil {
+		chain = append(chain, interceptors.Auth(authenticator))
+	} else {
+		chain = append(chain, interceptors.Actor())
+	}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(chain...))
+	reviewerv1.RegisterUserServiceServer(server, handlers.NewUserHandler(userService, log))
+	reviewerv1.RegisterTeamServiceServer(server, handlers.NewTeamHandler(teamService, log))
+	reviewerv1.RegisterPullRequestServiceServer(server, handlers.NewPullRequestHandler(prService, log))
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	return &Server{
+		server: server,
+		health: healthServer,
+		log:    log,
+	}
+}
+
+func (s *Server) Run(addr string) error {
+	s.log.Info("Starting gRPC server", "address", addr)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve accepts connections on listener until the server is shut down.
+func (s *Server) Serve(listener net.Listener) error {
+	return s.server.Serve(listener)
+}
+
+// Shutdown reports the server as not serving to health checks and waits for
+// pending calls to finish. When ctx is done first, the remaining calls are
+// cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.log.Info("Shutting down gRPC server")
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag reports the version of the returned resource as its ETag.
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// ifMatchVersion returns the version the If-Match header conditions the request
+// on, or 0 when there is no condition. Only a single ETag or "*" is accepted.
+func ifMatchVersion(c *gin.Context) (int64, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	tag := strings.TrimPrefix(value, "W/")
+	if unquoted, err := strconv.Unquote(tag); err == nil && strings.HasPrefix(tag, `"`) {
+		if version, err := strconv.ParseInt(unquoted, 10, 64); err == nil && version > 0 {
+			return version, nil
+		}
+	}
+	return 0, apperr.Invalid(apperr.CodeInvalidRequest, "If-Match must be a single ETag returned by the API, got %s", value)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/graphql"
+	"avito-autumn-2025/internal/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GraphQLHandler struct {
+	executor *graphql.Executor
+	log      logger.Logger
+}
+
+func NewGraphQLHandler(executor *graphql.Executor, log logger.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		executor: executor,
+		log:      log,
+	}
+}
+
+// PostGraphQL runs a query. Query and resolver errors are part of the GraphQL
+// response, which is always sent with 200.
+func (h *GraphQLHandler) PostGraphQL(c *gin.Context) {
+	h.log.Debug("Handler: GraphQL request")
+
+	var req graphql.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Handler: Invalid request body", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
+		return
+	}
+
+	if req.Query == "" {
+		h.log.Error("Handler: GraphQL query is required")
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "query is required"))
+		return
+	}
+
+	response := h.executor.Execute(c.Request.Context(), &req)
+	if len(response.Errors) > 0 {
+		h.log.Info("Handler: GraphQL query finished with errors", "operation", req.OperationName, "errors", len(response.Errors))
+	} else {
+		h.log.Info("Handler: GraphQL query executed successfully", "operation", req.OperationName)
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"avito-autumn-2025/internal/apperr"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"avito-autumn-2025/internal/service"
@@ -28,13 +29,37 @@ func (h *PullRequestHandler) PostPullRequestCreate(c *gin.Context) {
 	var req models.PullRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Error("Handler: Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
 		return
 	}
 
 	if req.PullRequestId == "" || req.PullRequestName == "" || req.AuthorId == "" {
 		h.log.Error("Handler: Required fields missing")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "pull_request_id, pull_request_name and author_id are required"})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "pull_request_id, pull_request_name and author_id are required"))
+		return
+	}
+
+	if req.Priority == "" {
+		req.Priority = models.NORMAL
+	}
+	switch req.Priority {
+	case models.LOW, models.NORMAL, models.HIGH, models.URGENT:
+	default:
+		h.log.Error("Handler: Invalid priority", "priority", req.Priority)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "priority must be one of LOW, NORMAL, HIGH, URGENT"))
+		return
+	}
+
+	if req.Additions < 0 || req.Deletions < 0 || req.ChangedFiles < 0 {
+		h.log.Error("Handler: Negative size metrics")
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "additions, deletions and changed_files must not be negative"))
+		return
+	}
+
+	onExists := models.ExistingPullRequestAction(c.DefaultQuery("on_exists", string(models.ExistingPullRequestReject)))
+	if onExists != models.ExistingPullRequestReject && onExists != models.ExistingPullRequestReturn {
+		h.log.Error("Handler: Invalid on_exists", "on_exists", onExists)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "on_exists must be one of reject, return"))
 		return
 	}
 
@@ -42,10 +67,24 @@ func (h *PullRequestHandler) PostPullRequestCreate(c *gin.Context) {
 	now := time.Now()
 	req.CreatedAt = &now
 
-	pr, err := h.prService.CreatePullRequest(c.Request.Context(), &req)
+	var pr *models.PullRequest
+	var err error
+	created := true
+	if onExists == models.ExistingPullRequestReturn {
+		pr, created, err = h.prService.CreateOrGetPullRequest(c.Request.Context(), &req)
+	} else {
+		pr, err = h.prService.CreatePullRequest(c.Request.Context(), &req)
+	}
 	if err != nil {
 		h.log.Error("Handler: Failed to create pull request", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	setETag(c, pr.Version)
+	if !created {
+		h.log.Info("Handler: Pull request already exists", "pr_id", pr.PullRequestId)
+		c.JSON(http.StatusOK, pr)
 		return
 	}
 
@@ -57,28 +96,38 @@ func (h *PullRequestHandler) PostPullRequestMerge(c *gin.Context) {
 	h.log.Debug("Handler: Merging pull request request")
 
 	var req struct {
+		Repository    string `json:"repository"`
 		PullRequestId string `json:"pull_request_id" binding:"required"`
+		Force         bool   `json:"force"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Error("Handler: Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
 		return
 	}
 
 	if req.PullRequestId == "" {
 		h.log.Error("Handler: pull_request_id is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "pull_request_id is required"})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "pull_request_id is required"))
 		return
 	}
 
-	err := h.prService.MergePullRequest(c.Request.Context(), req.PullRequestId)
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid If-Match", "error", err)
+		c.Error(err)
+		return
+	}
+
+	version, err = h.prService.MergePullRequest(c.Request.Context(), req.Repository, req.PullRequestId, req.Force, version)
 	if err != nil {
 		h.log.Error("Handler: Failed to merge pull request", "error", err, "pr_id", req.PullRequestId)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	h.log.Info("Handler: Pull request merged successfully", "pr_id", req.PullRequestId)
+	setETag(c, version)
 	c.JSON(http.StatusOK, gin.H{"message": "Pull request merged successfully"})
 }
 
@@ -86,32 +135,130 @@ func (h *PullRequestHandler) PostPullRequestReassign(c *gin.Context) {
 	h.log.Debug("Handler: Reassigning reviewer request")
 
 	var req struct {
+		Repository    string `json:"repository"`
 		PullRequestId string `json:"pull_request_id" binding:"required"`
 		OldUserId     string `json:"old_user_id" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Error("Handler: Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
 		return
 	}
 
 	if req.PullRequestId == "" || req.OldUserId == "" {
 		h.log.Error("Handler: Required fields missing")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "pull_request_id and old_user_id are required"})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "pull_request_id and old_user_id are required"))
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid If-Match", "error", err)
+		c.Error(err)
 		return
 	}
 
-	err := h.prService.ReassignReviewer(c.Request.Context(), req.PullRequestId, req.OldUserId)
+	version, err = h.prService.ReassignReviewer(c.Request.Context(), req.Repository, req.PullRequestId, req.OldUserId, version)
 	if err != nil {
 		h.log.Error("Handler: Failed to reassign reviewer", "error", err, "pr_id", req.PullRequestId)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	h.log.Info("Handler: Successfully reassigned reviewer", "pr_id", req.PullRequestId, "old_reviewer", req.OldUserId)
+	setETag(c, version)
 	c.JSON(http.StatusOK, gin.H{"message": "Reviewer reassigned successfully"})
 }
 
+func (h *PullRequestHandler) PostPullRequestReview(c *gin.Context) {
+	h.log.Debug("Handler: Submitting review request")
+
+	var req struct {
+		Repository    string               `json:"repository"`
+		PullRequestId string               `json:"pull_request_id" binding:"required"`
+		UserId        string               `json:"user_id" binding:"required"`
+		Verdict       models.ReviewVerdict `json:"verdict" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Handler: Invalid request body", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
+		return
+	}
+
+	switch req.Verdict {
+	case models.APPROVED, models.CHANGES_REQUESTED, models.COMMENTED:
+	default:
+		h.log.Error("Handler: Invalid verdict", "verdict", req.Verdict)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "verdict must be one of APPROVED, CHANGES_REQUESTED, COMMENTED"))
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid If-Match", "error", err)
+		c.Error(err)
+		return
+	}
+
+	version, err = h.prService.SubmitReview(c.Request.Context(), req.Repository, req.PullRequestId, req.UserId, req.Verdict, version)
+	if err != nil {
+		h.log.Error("Handler: Failed to submit review", "error", err, "pr_id", req.PullRequestId)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Review submitted successfully", "pr_id", req.PullRequestId, "reviewer_id", req.UserId)
+	setETag(c, version)
+	c.JSON(http.StatusOK, gin.H{"message": "Review submitted successfully"})
+}
+
+func (h *PullRequestHandler) GetPullRequest(c *gin.Context) {
+	h.log.Debug("Handler: Getting pull request request")
+
+	prID := c.Param("id")
+	if prID == "" {
+		h.log.Error("Handler: pull request ID parameter is required")
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "pull request ID parameter is required"))
+		return
+	}
+
+	repository := c.Query("repository")
+
+	pr, err := h.prService.GetPullRequest(c.Request.Context(), repository, prID)
+	if err != nil {
+		h.log.Error("Handler: Failed to get pull request", "error", err, "pr_id", prID)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Pull request retrieved successfully", "pr_id", prID)
+	setETag(c, pr.Version)
+	c.JSON(http.StatusOK, pr)
+}
+
+func (h *PullRequestHandler) GetPullRequestEvents(c *gin.Context) {
+	h.log.Debug("Handler: Getting pull request events request")
+
+	prID := c.Param("id")
+	if prID == "" {
+		h.log.Error("Handler: pull request ID parameter is required")
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "pull request ID parameter is required"))
+		return
+	}
+
+	repository := c.Query("repository")
+
+	events, err := h.prService.GetPullRequestEvents(c.Request.Context(), repository, prID)
+	if err != nil {
+		h.log.Error("Handler: Failed to get pull request events", "error", err, "pr_id", prID)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Pull request events retrieved successfully", "pr_id", prID, "count", len(events))
+	c.JSON(http.StatusOK, events)
+}
+
 func (h *PullRequestHandler) GetUsersGetReview(c *gin.Context) {
 	h.log.Debug("Handler: Getting pull requests by reviewer request")
 
@@ -121,20 +268,63 @@ func (h *PullRequestHandler) GetUsersGetReview(c *gin.Context) {
 	}
 	if reviewerID == "" {
 		h.log.Error("Handler: user_id or reviewer_id parameter is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id or reviewer_id parameter is required"})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "user_id or reviewer_id parameter is required"))
+		return
+	}
+
+	query, err := parsePullRequestQuery(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid pull request query", "error", err)
+		c.Error(err)
 		return
 	}
 
 	h.log.Debug("Handler: Getting pull requests for reviewer", "reviewer_id", reviewerID)
 
-	prs, err := h.prService.GetPullRequestsByReviewer(c.Request.Context(), reviewerID)
+	page, err := h.prService.GetPullRequestsByReviewer(c.Request.Context(), reviewerID, query)
 	if err != nil {
 		h.log.Error("Handler: Failed to get pull requests by reviewer", "error", err, "reviewer_id", reviewerID)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get pull requests by reviewer"})
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Successfully retrieved pull requests by reviewer", "reviewer_id", reviewerID, "count", len(page.PullRequests))
+	writePullRequestPage(c, page)
+}
+
+func (h *PullRequestHandler) SearchPullRequests(c *gin.Context) {
+	h.log.Debug("Handler: Searching pull requests request")
+
+	query, err := parsePullRequestQuery(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid pull request query", "error", err)
+		c.Error(err)
+		return
+	}
+	query.ReviewerId = c.Query("reviewer_id")
+
+	page, err := h.prService.SearchPullRequests(c.Request.Context(), query)
+	if err != nil {
+		h.log.Error("Handler: Failed to search pull requests", "error", err)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Successfully searched pull requests", "count", len(page.PullRequests))
+	writePullRequestPage(c, page)
+}
+
+func (h *PullRequestHandler) GetStalePullRequests(c *gin.Context) {
+	h.log.Debug("Handler: Getting stale pull requests request")
+
+	prs, err := h.prService.GetStalePullRequests(c.Request.Context())
+	if err != nil {
+		h.log.Error("Handler: Failed to get stale pull requests", "error", err)
+		c.Error(err)
 		return
 	}
 
-	h.log.Info("Handler: Successfully retrieved pull requests by reviewer", "reviewer_id", reviewerID, "count", len(prs))
+	h.log.Info("Handler: Stale pull requests retrieved successfully", "count", len(prs))
 	c.JSON(http.StatusOK, prs)
 }
 
@@ -144,7 +334,7 @@ func (h *PullRequestHandler) GetReviewStatistics(c *gin.Context) {
 	stats, err := h.prService.GetReviewStatistics(c.Request.Context())
 	if err != nil {
 		h.log.Error("Handler: Failed to get review statistics", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get review statistics"})
+		c.Error(err)
 		return
 	}
 
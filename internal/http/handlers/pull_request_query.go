@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NextCursorHeader carries the cursor of the next page of a list response. It
+// is absent on the last page.
+const NextCursorHeader = "X-Next-Cursor"
+
+// parsePullRequestQuery reads the filter, sort and pagination query parameters
+// shared by pull request list endpoints.
+func parsePullRequestQuery(c *gin.Context) (*models.PullRequestQuery, error) {
+	query := &models.PullRequestQuery{
+		AuthorId:   c.Query("author_id"),
+		TeamName:   c.Query("team_name"),
+		Repository: c.Query("repository"),
+		Sort:       models.PullRequestSort(c.DefaultQuery("sort", string(models.SortByCreatedAt))),
+		Order:      models.SortOrder(c.DefaultQuery("order", string(models.SortDesc))),
+		Limit:      models.DefaultPageLimit,
+	}
+
+	for _, value := range c.QueryArray("status") {
+		for _, status := range strings.Split(value, ",") {
+			switch status := models.PullRequestStatus(strings.ToUpper(strings.TrimSpace(status))); status {
+			case models.OPEN, models.MERGED, models.CLOSED:
+				query.Statuses = append(query.Statuses, status)
+			default:
+				return nil, apperr.Invalid(apperr.CodeInvalidRequest, "status must be one of OPEN, MERGED, CLOSED")
+			}
+		}
+	}
+
+	switch query.Sort {
+	case models.SortByCreatedAt, models.SortByUpdatedAt:
+	default:
+		return nil, apperr.Invalid(apperr.CodeInvalidRequest, "sort must be one of created_at, updated_at")
+	}
+	switch query.Order {
+	case models.SortAsc, models.SortDesc:
+	default:
+		return nil, apperr.Invalid(apperr.CodeInvalidRequest, "order must be one of asc, desc")
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > models.MaxPageLimit {
+			return nil, apperr.Invalid(apperr.CodeInvalidRequest, "limit must be an integer between 1 and %d", models.MaxPageLimit)
+		}
+		query.Limit = limit
+	}
+
+	var err error
+	if query.CreatedFrom, err = parseQueryTime(c, "created_from"); err != nil {
+		return nil, err
+	}
+	if query.CreatedTo, err = parseQueryTime(c, "created_to"); err != nil {
+		return nil, err
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := models.DecodePullRequestCursor(value)
+		if err != nil {
+			return nil, apperr.Invalid(apperr.CodeInvalidRequest, "cursor is malformed")
+		}
+		if cursor.Sort != query.Sort || cursor.Order != query.Order {
+			return nil, apperr.Invalid(apperr.CodeInvalidRequest, "cursor was issued for sort=%s&order=%s", cursor.Sort, cursor.Order)
+		}
+		query.After = cursor
+	}
+
+	return query, nil
+}
+
+// parseQueryTime reads an RFC 3339 timestamp or a YYYY-MM-DD date from the
+// query parameter name.
+func parseQueryTime(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t, nil
+		}
+	}
+	return nil, apperr.Invalid(apperr.CodeInvalidRequest, "%s must be an RFC 3339 timestamp or a YYYY-MM-DD date", name)
+}
+
+// writePullRequestPage responds with the pull requests of page and points
+// NextCursorHeader at the following page.
+func writePullRequestPage(c *gin.Context, page *models.PullRequestPage) {
+	if page.NextCursor != nil {
+		c.Header(NextCursorHeader, page.NextCursor.Encode())
+	}
+	c.JSON(http.StatusOK, page.PullRequests)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RepositoryHandler struct {
+	repoService service.Repository
+	log         logger.Logger
+}
+
+func NewRepositoryHandler(repoService service.Repository, log logger.Logger) *RepositoryHandler {
+	return &RepositoryHandler{
+		repoService: repoService,
+		log:         log,
+	}
+}
+
+func (h *RepositoryHandler) PostRepositoryAdd(c *gin.Context) {
+	h.log.Debug("Handler: Setting repository request")
+
+	req := models.Repository{FallbackToAuthorTeam: true}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Handler: Invalid request body", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
+		return
+	}
+
+	if req.Name == "" {
+		h.log.Error("Handler: Repository name is required")
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "repository is required"))
+		return
+	}
+
+	if req.ReviewersCount < 0 {
+		h.log.Error("Handler: Invalid reviewers count", "reviewers_count", req.ReviewersCount)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "reviewers_count must not be negative"))
+		return
+	}
+
+	repo, err := h.repoService.SetRepository(c.Request.Context(), &req)
+	if err != nil {
+		h.log.Error("Handler: Failed to set repository", "error", err, "repository", req.Name)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Repository set successfully", "repository", repo.Name)
+	c.JSON(http.StatusOK, repo)
+}
+
+func (h *RepositoryHandler) GetRepositoryName(c *gin.Context) {
+	h.log.Debug("Handler: Getting repository request")
+
+	name := c.Param("repositoryName")
+
+	repo, err := h.repoService.GetRepository(c.Request.Context(), name)
+	if err != nil {
+		h.log.Error("Handler: Failed to get repository", "error", err, "repository", name)
+		c.Error(err)
+		return
+	}
+
+	if repo == nil {
+		h.log.Info("Handler: Repository not found", "repository", name)
+		c.Error(apperr.NotFound(apperr.CodeRepositoryNotFound, "repository %s not found", name))
+		return
+	}
+
+	h.log.Info("Handler: Repository retrieved successfully", "repository", name)
+	c.JSON(http.StatusOK, repo)
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/scim"
+	"avito-autumn-2025/internal/service"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+const scimContentType = "application/scim+json"
+
+type SCIMHandler struct {
+	scimService service.SCIM
+	log         logger.Logger
+}
+
+func NewSCIMHandler(scimService service.SCIM, log logger.Logger) *SCIMHandler {
+	return &SCIMHandler{
+		scimService: scimService,
+		log:         log,
+	}
+}
+
+func (h *SCIMHandler) GetServiceProviderConfig(c *gin.Context) {
+	h.respond(c, http.StatusOK, scim.ServiceProviderConfig())
+}
+
+func (h *SCIMHandler) ListUsers(c *gin.Context) {
+	h.log.Debug("Handler: Listing SCIM users request")
+
+	query, err := scim.NewListQuery(c.Query("filter"), c.Query("startIndex"), c.Query("count"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response, err := h.scimService.ListUsers(c.Request.Context(), query)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	for _, resource := range response.Resources {
+		h.locateUser(c, resource.(*scim.User))
+	}
+
+	h.log.Debug("Handler: SCIM users listed", "total_results", response.TotalResults)
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *SCIMHandler) GetUser(c *gin.Context) {
+	h.log.Debug("Handler: Getting SCIM user request")
+
+	user, err := h.scimService.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, h.locateUser(c, user))
+}
+
+func (h *SCIMHandler) CreateUser(c *gin.Context) {
+	h.log.Debug("Handler: Creating SCIM user request")
+
+	var req scim.User
+	if !h.bind(c, &req) {
+		return
+	}
+
+	user, err := h.scimService.CreateUser(c.Request.Context(), &req)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.log.Info("Handler: SCIM user created", "user_id", user.Id)
+	h.locateUser(c, user)
+	c.Header("Location", user.Meta.Location)
+	h.respond(c, http.StatusCreated, user)
+}
+
+func (h *SCIMHandler) PutUser(c *gin.Context) {
+	h.log.Debug("Handler: Replacing SCIM user request")
+
+	var req scim.User
+	if !h.bind(c, &req) {
+		return
+	}
+
+	user, err := h.scimService.ReplaceUser(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.log.Info("Handler: SCIM user replaced", "user_id", user.Id)
+	h.respond(c, http.StatusOK, h.locateUser(c, user))
+}
+
+func (h *SCIMHandler) PatchUser(c *gin.Context) {
+	h.log.Debug("Handler: Patching SCIM user request")
+
+	var req scim.PatchRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	user, err := h.scimService.PatchUser(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.log.Info("Handler: SCIM user patched", "user_id", user.Id)
+	h.respond(c, http.StatusOK, h.locateUser(c, user))
+}
+
+func (h *SCIMHandler) DeleteUser(c *gin.Context) {
+	h.log.Debug("Handler: Deleting SCIM user request")
+
+	if err := h.scimService.DeleteUser(c.Request.Context(), c.Param("id")); err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.log.Info("Handler: SCIM user deleted", "user_id", c.Param("id"))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SCIMHandler) ListGroups(c *gin.Context) {
+	h.log.Debug("Handler: Listing SCIM groups request")
+
+	query, err := scim.NewListQuery(c.Query("filter"), c.Query("startIndex"), c.Query("count"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	response, err := h.scimService.ListGroups(c.Request.Context(), query)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	for _, resource := range response.Resources {
+		h.locateGroup(c, resource.(*scim.Group))
+	}
+
+	h.log.Debug("Handler: SCIM groups listed", "total_results", response.TotalResults)
+	h.respond(c, http.StatusOK, response)
+}
+
+func (h *SCIMHandler) GetGroup(c *gin.Context) {
+	h.log.Debug("Handler: Getting SCIM group request")
+
+	group, err := h.scimService.GetGroup(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.respond(c, http.StatusOK, h.locateGroup(c, group))
+}
+
+func (h *SCIMHandler) CreateGroup(c *gin.Context) {
+	h.log.Debug("Handler: Creating SCIM group request")
+
+	var req scim.Group
+	if !h.bind(c, &req) {
+		return
+	}
+
+	group, err := h.scimService.CreateGroup(c.Request.Context(), &req)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.log.Info("Handler: SCIM group created", "team_name", group.Id)
+	h.locateGroup(c, group)
+	c.Header("Location", group.Meta.Location)
+	h.respond(c, http.StatusCreated, group)
+}
+
+func (h *SCIMHandler) PutGroup(c *gin.Context) {
+	h.log.Debug("Handler: Replacing SCIM group request")
+
+	var req scim.Group
+	if !h.bind(c, &req) {
+		return
+	}
+
+	group, err := h.scimService.ReplaceGroup(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.log.Info("Handler: SCIM group replaced", "team_name", group.Id)
+	h.respond(c, http.StatusOK, h.locateGroup(c, group))
+}
+
+func (h *SCIMHandler) PatchGroup(c *gin.Context) {
+	h.log.Debug("Handler: Patching SCIM group request")
+
+	var req scim.PatchRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	group, err := h.scimService.PatchGroup(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.log.Info("Handler: SCIM group patched", "team_name", group.Id)
+	h.respond(c, http.StatusOK, h.locateGroup(c, group))
+}
+
+func (h *SCIMHandler) DeleteGroup(c *gin.Context) {
+	h.log.Debug("Handler: Deleting SCIM group request")
+
+	if err := h.scimService.DeleteGroup(c.Request.Context(), c.Param("id")); err != nil {
+		h.fail(c, err)
+		return
+	}
+
+	h.log.Info("Handler: SCIM group deleted", "team_name", c.Param("id"))
+	c.Status(http.StatusNoContent)
+}
+
+func (h *SCIMHandler) bind(c *gin.Context, req any) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.log.Error("Handler: Invalid SCIM request body", "error", err)
+		h.fail(c, scim.InvalidSyntax(err.Error()))
+		return false
+	}
+	return true
+}
+
+func (h *SCIMHandler) respond(c *gin.Context, status int, body any) {
+	c.Header("Content-Type", scimContentType)
+	c.JSON(status, body)
+}
+
+// fail renders err as a SCIM error. Errors that are not SCIM errors keep the
+// status and message of the regular API.
+func (h *SCIMHandler) fail(c *gin.Context, err error) {
+	var scimErr *scim.Error
+	if !errors.As(err, &scimErr) {
+		h.log.Error("Handler: SCIM request failed", "error", err)
+		scimErr = scim.NewError(apperr.Status(err), "", apperr.NewResponse(err).Error.Message)
+	}
+	h.respond(c, scimErr.Status, scimErr.Body())
+}
+
+func (h *SCIMHandler) locateUser(c *gin.Context, user *scim.User) *scim.User {
+	user.Meta.Location = resourceURL(c, "Users", user.Id)
+	return user
+}
+
+func (h *SCIMHandler) locateGroup(c *gin.Context, group *scim.Group) *scim.Group {
+	group.Meta.Location = resourceURL(c, "Groups", group.Id)
+	return group
+}
+
+func resourceURL(c *gin.Context, resourceType, id string) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host + "/scim/v2/" + resourceType + "/" + url.PathEscape(id)
+}
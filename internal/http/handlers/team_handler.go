@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/importer"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"avito-autumn-2025/internal/service"
@@ -27,52 +29,382 @@ func (h *TeamHandler) PostTeamAdd(c *gin.Context) {
 	var req models.Team
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Error("Handler: Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
 		return
 	}
 
 	if req.Name == "" {
 		h.log.Error("Handler: Team name is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "team_name is required"})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "team_name is required"))
 		return
 	}
 
+	for _, member := range req.Users {
+		if !validRole(member.Role) {
+			h.log.Error("Handler: Invalid member role", "user_id", member.Id, "role", member.Role)
+			c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "role must be one of lead, member, observer"))
+			return
+		}
+	}
+
 	team, err := h.teamService.CreateTeam(c.Request.Context(), &req)
 	if err != nil {
 		h.log.Error("Handler: Failed to create team", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	h.log.Info("Handler: Team created successfully", "team_name", team.Name)
+	setETag(c, team.Version)
 	c.JSON(http.StatusCreated, team)
 }
 
+func (h *TeamHandler) PostTeamImport(c *gin.Context) {
+	h.log.Debug("Handler: Importing teams request")
+
+	imp, ok := h.parseImport(c)
+	if !ok {
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	result, err := h.teamService.ImportTeams(c.Request.Context(), imp, dryRun)
+	if err != nil {
+		h.log.Error("Handler: Failed to import teams", "error", err)
+		c.Error(err)
+		return
+	}
+
+	h.respondImport(c, result)
+}
+
+func (h *TeamHandler) PostTeamReconcile(c *gin.Context) {
+	h.log.Debug("Handler: Reconciling teams request")
+
+	imp, ok := h.parseImport(c)
+	if !ok {
+		return
+	}
+
+	if len(imp.Teams) == 0 {
+		h.log.Error("Handler: Empty reconcile document")
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "reconcile document must list at least one team"))
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	result, err := h.teamService.ReconcileTeams(c.Request.Context(), imp, dryRun)
+	if err != nil {
+		h.log.Error("Handler: Failed to reconcile teams", "error", err)
+		c.Error(err)
+		return
+	}
+
+	h.respondImport(c, result)
+}
+
+// parseImport reads an import document in the format of the Content-Type
+// header. It responds with an error and returns false when that fails.
+func (h *TeamHandler) parseImport(c *gin.Context) (*models.Import, bool) {
+	format, err := importer.FormatFromContentType(c.GetHeader("Content-Type"))
+	if err != nil {
+		h.log.Error("Handler: Unsupported import format", "error", err)
+		c.Error(apperr.UnsupportedMediaType(apperr.CodeUnsupportedMediaType, "%v", err))
+		return nil, false
+	}
+
+	imp, err := importer.Parse(format, c.Request.Body)
+	if err != nil {
+		h.log.Error("Handler: Invalid import file", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid import file: %v", err))
+		return nil, false
+	}
+
+	return imp, true
+}
+
+func (h *TeamHandler) respondImport(c *gin.Context, result *models.ImportResult) {
+	if result.Failed() {
+		h.log.Info("Handler: Team import rejected", "rows_count", len(result.Rows))
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	h.log.Info("Handler: Team import processed", "applied", result.Applied, "rows_count", len(result.Rows))
+	c.JSON(http.StatusOK, result)
+}
+
 func (h *TeamHandler) GetTeamTeamName(c *gin.Context) {
 	h.log.Debug("Handler: Getting team by name request")
 
 	teamName := c.Param("teamName")
 	if teamName == "" {
 		h.log.Error("Handler: team name parameter is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Team name parameter is required"})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "team name parameter is required"))
 		return
 	}
 
 	h.log.Debug("Handler: Getting team", "team_name", teamName)
 
-	team, err := h.teamService.GetTeamWithMembers(c.Request.Context(), teamName)
+	var team *models.Team
+	var err error
+	includeDescendants := c.Query("include_descendants") == "true"
+	if includeDescendants {
+		team, err = h.teamService.GetTeamWithDescendants(c.Request.Context(), teamName)
+	} else {
+		team, err = h.teamService.GetTeamWithMembers(c.Request.Context(), teamName)
+	}
 	if err != nil {
 		h.log.Error("Handler: Failed to get team", "error", err, "team_name", teamName)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	if team == nil {
 		h.log.Info("Handler: Team not found", "team_name", teamName)
-		c.JSON(http.StatusNotFound, gin.H{"error": "Team not found"})
+		c.Error(apperr.NotFound(apperr.CodeTeamNotFound, "team %s not found", teamName))
 		return
 	}
 
 	h.log.Info("Handler: Team retrieved successfully", "team_name", team.Name)
+	// The version does not cover subteams
+	if !includeDescendants {
+		setETag(c, team.Version)
+	}
 	c.JSON(http.StatusOK, team)
 }
+
+func (h *TeamHandler) PatchTeam(c *gin.Context) {
+	h.log.Debug("Handler: Updating team request")
+
+	teamName := c.Param("teamName")
+
+	var req models.TeamUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Handler: Invalid request body", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
+		return
+	}
+
+	if req.Name == "" && req.ParentTeam == nil {
+		h.log.Error("Handler: Nothing to update", "team_name", teamName)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "team_name or parent_team is required"))
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid If-Match", "error", err)
+		c.Error(err)
+		return
+	}
+
+	team, err := h.teamService.UpdateTeam(c.Request.Context(), teamName, &req, version)
+	if err != nil {
+		h.log.Error("Handler: Failed to update team", "error", err, "team_name", teamName)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Team updated successfully", "team_name", team.Name)
+	setETag(c, team.Version)
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	h.log.Debug("Handler: Deleting team request")
+
+	teamName := c.Param("teamName")
+
+	onOpenReviews := models.OpenReviewsAction(c.DefaultQuery("on_open_reviews", string(models.OpenReviewsReject)))
+	if onOpenReviews != models.OpenReviewsReject && onOpenReviews != models.OpenReviewsReassign {
+		h.log.Error("Handler: Invalid on_open_reviews", "on_open_reviews", onOpenReviews)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "on_open_reviews must be one of reject, reassign"))
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid If-Match", "error", err)
+		c.Error(err)
+		return
+	}
+
+	reassigned, err := h.teamService.DeleteTeam(c.Request.Context(), teamName, onOpenReviews, version)
+	if err != nil {
+		h.log.Error("Handler: Failed to delete team", "error", err, "team_name", teamName)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Team deleted successfully", "team_name", teamName, "reassigned_reviews", reassigned)
+	c.JSON(http.StatusOK, gin.H{"message": "Team deleted successfully", "reassigned_reviews": reassigned})
+}
+
+func (h *TeamHandler) PostTeamMember(c *gin.Context) {
+	h.log.Debug("Handler: Adding team member request")
+
+	teamName := c.Param("teamName")
+
+	var req struct {
+		models.User
+		IsPrimary bool `json:"is_primary"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Handler: Invalid request body", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
+		return
+	}
+
+	if !validRole(req.Role) {
+		h.log.Error("Handler: Invalid member role", "user_id", req.Id, "role", req.Role)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "role must be one of lead, member, observer"))
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid If-Match", "error", err)
+		c.Error(err)
+		return
+	}
+
+	team, err := h.teamService.AddTeamMember(c.Request.Context(), teamName, &req.User, req.IsPrimary, version)
+	if err != nil {
+		h.log.Error("Handler: Failed to add team member", "error", err, "team_name", teamName, "user_id", req.Id)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Team member added successfully", "team_name", teamName, "user_id", req.Id)
+	setETag(c, team.Version)
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) DeleteTeamMember(c *gin.Context) {
+	h.log.Debug("Handler: Removing team member request")
+
+	teamName := c.Param("teamName")
+	userID := c.Param("userId")
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		h.log.Error("Handler: Invalid If-Match", "error", err)
+		c.Error(err)
+		return
+	}
+
+	reassigned, err := h.teamService.RemoveTeamMember(c.Request.Context(), teamName, userID, version)
+	if err != nil {
+		h.log.Error("Handler: Failed to remove team member", "error", err, "team_name", teamName, "user_id", userID)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Team member removed successfully", "team_name", teamName, "user_id", userID, "reassigned_reviews", reassigned)
+	c.JSON(http.StatusOK, gin.H{"message": "Team member removed successfully", "reassigned_reviews": reassigned})
+}
+
+func (h *TeamHandler) GetTeamMembersHistory(c *gin.Context) {
+	h.log.Debug("Handler: Getting team membership history request")
+
+	teamName := c.Param("teamName")
+
+	history, err := h.teamService.GetMembershipHistory(c.Request.Context(), teamName)
+	if err != nil {
+		h.log.Error("Handler: Failed to get team membership history", "error", err, "team_name", teamName)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Team membership history retrieved successfully", "team_name", teamName, "count", len(history))
+	c.JSON(http.StatusOK, history)
+}
+
+func (h *TeamHandler) GetTeamPolicy(c *gin.Context) {
+	h.log.Debug("Handler: Getting team policy request")
+
+	teamName := c.Param("teamName")
+
+	policy, err := h.teamService.GetTeamPolicy(c.Request.Context(), teamName)
+	if err != nil {
+		h.log.Error("Handler: Failed to get team policy", "error", err, "team_name", teamName)
+		c.Error(err)
+		return
+	}
+
+	if policy == nil {
+		h.log.Info("Handler: Team policy not found", "team_name", teamName)
+		c.Error(apperr.NotFound(apperr.CodePolicyNotFound, "team %s has no policy", teamName))
+		return
+	}
+
+	h.log.Info("Handler: Team policy retrieved successfully", "team_name", teamName)
+	c.JSON(http.StatusOK, policy)
+}
+
+func (h *TeamHandler) PutTeamPolicy(c *gin.Context) {
+	h.log.Debug("Handler: Setting team policy request")
+
+	teamName := c.Param("teamName")
+
+	req := models.TeamPolicy{
+		FirstResponseHours: 24,
+		BusinessHoursOnly:  true,
+		WorkdayStart:       models.DefaultWorkdayStart,
+		WorkdayEnd:         models.DefaultWorkdayEnd,
+		TimeZone:           models.DefaultTimeZone,
+		SLAAction:          models.SLAActionReassign,
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Handler: Invalid request body", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
+		return
+	}
+	req.TeamName = teamName
+
+	if req.FirstResponseHours <= 0 {
+		h.log.Error("Handler: Invalid first response hours", "first_response_hours", req.FirstResponseHours)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "first_response_hours must be positive"))
+		return
+	}
+
+	if err := req.ValidateBusinessHours(); err != nil {
+		h.log.Error("Handler: Invalid business hours", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "%v", err))
+		return
+	}
+
+	if req.SLAAction != models.SLAActionReassign && req.SLAAction != models.SLAActionEscalate {
+		h.log.Error("Handler: Invalid SLA action", "sla_action", req.SLAAction)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "sla_action must be one of REASSIGN, ESCALATE"))
+		return
+	}
+
+	if req.StaleAfterDays < 0 || req.CloseAfterDays < 0 {
+		h.log.Error("Handler: Invalid stale thresholds", "stale_after_days", req.StaleAfterDays, "close_after_days", req.CloseAfterDays)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "stale_after_days and close_after_days must not be negative"))
+		return
+	}
+
+	policy, err := h.teamService.SetTeamPolicy(c.Request.Context(), &req)
+	if err != nil {
+		h.log.Error("Handler: Failed to set team policy", "error", err, "team_name", teamName)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: Team policy set successfully", "team_name", teamName)
+	c.JSON(http.StatusOK, policy)
+}
+
+// validRole accepts the membership roles and an empty role, which means member.
+func validRole(role models.MembershipRole) bool {
+	switch role {
+	case "", models.RoleLead, models.RoleMember, models.RoleObserver:
+		return true
+	}
+	return false
+}
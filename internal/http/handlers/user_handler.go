@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"avito-autumn-2025/internal/apperr"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"avito-autumn-2025/internal/service"
@@ -27,20 +28,20 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	var req models.User
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.log.Error("Handler: Invalid request body", "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
 		return
 	}
 
 	if req.Id == "" || req.Username == "" {
 		h.log.Error("Handler: Required fields missing")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "id and username are required"})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "id and username are required"))
 		return
 	}
 
 	user, err := h.userService.CreateUser(c.Request.Context(), &req)
 	if err != nil {
 		h.log.Error("Handler: Failed to create user", "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -54,7 +55,7 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 	userID := c.Param("id")
 	if userID == "" {
 		h.log.Error("Handler: user ID parameter is required")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "User ID parameter is required"})
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "user ID parameter is required"))
 		return
 	}
 
@@ -63,16 +64,72 @@ func (h *UserHandler) GetUserByID(c *gin.Context) {
 	user, err := h.userService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
 		h.log.Error("Handler: Failed to get user", "error", err, "user_id", userID)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
 	if user == nil {
 		h.log.Info("Handler: User not found", "user_id", userID)
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		c.Error(apperr.NotFound(apperr.CodeUserNotFound, "user %s not found", userID))
 		return
 	}
 
 	h.log.Info("Handler: User retrieved successfully", "user_id", user.Id)
 	c.JSON(http.StatusOK, user)
 }
+
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	h.log.Debug("Handler: Updating user request")
+
+	userID := c.Param("id")
+
+	var req models.UserUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error("Handler: Invalid request body", "error", err)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "invalid request body: %v", err))
+		return
+	}
+
+	if req.Username == nil && req.IsActive == nil && req.TeamName == nil {
+		h.log.Error("Handler: Nothing to update", "user_id", userID)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "username, is_active or team_name is required"))
+		return
+	}
+
+	if (req.Username != nil && *req.Username == "") || (req.TeamName != nil && *req.TeamName == "") {
+		h.log.Error("Handler: Empty username or team name", "user_id", userID)
+		c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "username and team_name must not be empty"))
+		return
+	}
+
+	user, err := h.userService.UpdateUser(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.log.Error("Handler: Failed to update user", "error", err, "user_id", userID)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: User updated successfully", "user_id", userID)
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	h.log.Debug("Handler: Deleting user request")
+
+	userID := c.Param("id")
+
+	result, err := h.userService.DeleteUser(c.Request.Context(), userID)
+	if err != nil {
+		h.log.Error("Handler: Failed to delete user", "error", err, "user_id", userID)
+		c.Error(err)
+		return
+	}
+
+	h.log.Info("Handler: User deleted successfully", "user_id", userID,
+		"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	c.JSON(http.StatusOK, gin.H{
+		"message":            "User deleted successfully",
+		"reassigned_reviews": result.Reassigned,
+		"unassigned_reviews": result.Unassigned,
+	})
+}
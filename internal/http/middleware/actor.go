@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"avito-autumn-2025/internal/actor"
+	"avito-autumn-2025/internal/apperr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHeader names the caller on whose behalf a request is made.
+const ActorHeader = "X-Actor-Id"
+
+const maxActorLength = 50
+
+// Actor stores the caller from ActorHeader in the request context.
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(ActorHeader)
+		if len(id) > maxActorLength {
+			c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "%s must be at most %d characters", ActorHeader, maxActorLength))
+			c.Abort()
+			return
+		}
+		if id != "" {
+			c.Request = c.Request.WithContext(actor.WithID(c.Request.Context(), id))
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"avito-autumn-2025/internal/actor"
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/auth"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Auth requires a bearer token and makes its user both the authenticated
+// caller and the actor of the request, overriding ActorHeader.
+func Auth(authenticator *auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			c.Error(apperr.Unauthorized(apperr.CodeUnauthorized, "authorization bearer token is required"))
+			c.Abort()
+			return
+		}
+
+		principal, err := authenticator.Verify(token)
+		if err != nil {
+			c.Error(apperr.Unauthorized(apperr.CodeInvalidToken, "%v", err))
+			c.Abort()
+			return
+		}
+
+		ctx := auth.WithPrincipal(c.Request.Context(), principal)
+		c.Request = c.Request.WithContext(actor.WithID(ctx, principal.UserID))
+		c.Next()
+	}
+}
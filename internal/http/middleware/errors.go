@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Errors renders the last error attached with c.Error as an apperr.Response
+// with the status of its kind. Handlers that already wrote a response are left
+// alone. Errors of no known kind are logged and reported as internal.
+func Errors(log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		renderError(c, log)
+	}
+}
+
+func renderError(c *gin.Context, log logger.Logger) {
+	if len(c.Errors) == 0 || c.Writer.Written() {
+		return
+	}
+
+	err := c.Errors.Last().Err
+	status := apperr.Status(err)
+	if status == http.StatusInternalServerError {
+		log.Error("Request failed", "error", err, "method", c.Request.Method, "path", c.Request.URL.Path)
+	}
+	c.JSON(status, apperr.NewResponse(err))
+}
+
+// NoRoute reports unknown routes in the same format as other errors.
+func NoRoute(c *gin.Context) {
+	c.Error(apperr.NotFound(apperr.CodeNotFound, "route %s %s not found", c.Request.Method, c.Request.URL.Path))
+}
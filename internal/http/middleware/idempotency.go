@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"avito-autumn-2025/internal/actor"
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader lets clients retry a request safely: the response to
+// the first request with a key is replayed for later requests with that key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader marks replayed responses.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+const maxIdempotencyKeyLength = 255
+
+// Idempotency replays the stored response to requests repeating an earlier
+// request of the same actor with the same IdempotencyKeyHeader within ttl.
+// Reusing a key for a different request is refused, as is a retry while the
+// first request is still in progress. Requests without the header and
+// responses with 5xx statuses are not stored.
+func Idempotency(store storage.Idempotency, ttl time.Duration, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "failed to read request body"))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		actorID := actor.FromContext(ctx)
+		fingerprint := requestFingerprint(c.Request, body)
+
+		record, err := store.ReserveIdempotencyKey(ctx, actorID, key, fingerprint, ttl)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if record != nil {
+			switch {
+			case record.Fingerprint != fingerprint:
+				c.Error(apperr.PreconditionFailed(apperr.CodeIdempotencyKeyReused, "idempotency key %s was used for a different request", key))
+			case !record.Completed:
+				c.Error(apperr.Conflict(apperr.CodeIdempotencyKeyInUse, "request with idempotency key %s is in progress", key))
+			default:
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(record.StatusCode, record.ContentType, record.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		// Errors are rendered here rather than by Errors so that they are recorded
+		renderError(c, log)
+
+		// The outcome is stored even if the client has gone away
+		ctx = context.WithoutCancel(ctx)
+		if recorder.Status() >= http.StatusInternalServerError {
+			if err := store.ReleaseIdempotencyKey(ctx, actorID, key); err != nil {
+				log.Error("Failed to release idempotency key", "error", err, "path", c.Request.URL.Path)
+			}
+			return
+		}
+
+		err = store.CompleteIdempotencyKey(ctx, &models.IdempotencyRecord{
+			ActorId:     actorID,
+			Key:         key,
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			log.Error("Failed to store idempotent response", "error", err, "path", c.Request.URL.Path)
+		}
+	}
+}
+
+// requestFingerprint identifies a request by its method, URL and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder copies the response body as it is written.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
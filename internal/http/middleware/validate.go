@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+var ginPathParam = regexp.MustCompile(`:([^/]+)`)
+
+// Validate checks the path, query and header parameters and the JSON body of a
+// request against the operation of doc that describes its route, and rejects
+// the request with every violated constraint listed in the error. Bodies in
+// other formats, such as team import files, are left to their handlers.
+// Authentication is checked by Auth, not by the security requirements of doc.
+func Validate(doc *openapi3.T) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := ginPathParam.ReplaceAllString(c.FullPath(), "{$1}")
+		item := doc.Paths.Find(path)
+		if item == nil || item.GetOperation(c.Request.Method) == nil {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(apperr.Invalid(apperr.CodeInvalidRequest, "failed to read request body: %v", err))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Handlers bind bodies without a Content-Type as JSON.
+		req := c.Request.Clone(c.Request.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		if mediaType == "" {
+			mediaType = "application/json"
+			req.Header.Set("Content-Type", mediaType)
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+
+		err = openapi3filter.ValidateRequest(c.Request.Context(), &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route: &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    c.Request.Method,
+				Operation: item.GetOperation(c.Request.Method),
+			},
+			Options: &openapi3filter.Options{
+				MultiError:         true,
+				ExcludeRequestBody: mediaType != "application/json",
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		})
+		if err != nil {
+			c.Error(apperr.InvalidFields(apperr.CodeInvalidRequest, fieldErrors(err)))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// fieldErrors flattens the errors of openapi3filter into one entry per
+// violated constraint.
+func fieldErrors(err error) []apperr.FieldError {
+	switch err := err.(type) {
+	case openapi3.MultiError:
+		var fields []apperr.FieldError
+		for _, e := range err {
+			fields = append(fields, fieldErrors(e)...)
+		}
+		return fields
+	case *openapi3filter.RequestError:
+		if err.Parameter != nil {
+			return []apperr.FieldError{{In: err.Parameter.In, Field: err.Parameter.Name, Message: reason(err.Err)}}
+		}
+		if err.RequestBody != nil {
+			fields := schemaErrors(err.Err)
+			if len(fields) == 0 {
+				fields = append(fields, apperr.FieldError{In: "body", Message: reason(err.Err)})
+			}
+			return fields
+		}
+		return []apperr.FieldError{{In: "body", Message: reason(err)}}
+	}
+	return []apperr.FieldError{{In: "body", Message: err.Error()}}
+}
+
+// schemaErrors lists the schema violations of a request body with the dotted
+// path of the offending field.
+func schemaErrors(err error) []apperr.FieldError {
+	var errs openapi3.MultiError
+	if !errors.As(err, &errs) {
+		errs = openapi3.MultiError{err}
+	}
+
+	var fields []apperr.FieldError
+	for _, e := range errs {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(e, &schemaErr) {
+			continue
+		}
+		fields = append(fields, apperr.FieldError{
+			In:      "body",
+			Field:   strings.Join(schemaErr.JSONPointer(), "."),
+			Message: schemaErr.Reason,
+		})
+	}
+	return fields
+}
+
+// reason returns the message of err without the wrapping added by
+// openapi3filter.
+func reason(err error) string {
+	var errs openapi3.MultiError
+	if errors.As(err, &errs) {
+		reasons := make([]string, 0, len(errs))
+		for _, e := range errs {
+			reasons = append(reasons, reason(e))
+		}
+		return strings.Join(reasons, "; ")
+	}
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return schemaErr.Reason
+	}
+	return err.Error()
+}
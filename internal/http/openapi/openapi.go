@@ -0,0 +1,38 @@
+// Package openapi embeds the OpenAPI 3 specification of the HTTP API. The
+// specification is maintained by hand next to the routes it describes.
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+// Path is where the specification is served.
+const Path = "/openapi.json"
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec returns the specification as JSON.
+func Spec() []byte {
+	return spec
+}
+
+// MustLoad parses the specification. It panics if the specification is
+// invalid, which the tests rule out.
+func MustLoad() *openapi3.T {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		panic(fmt.Sprintf("invalid OpenAPI specification: %v", err))
+	}
+	return doc
+}
+
+// Handler serves the specification.
+func Handler(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+}
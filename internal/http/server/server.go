@@ -1,64 +1,130 @@
 package server
 
 import (
+	"avito-autumn-2025/internal/auth"
+	"avito-autumn-2025/internal/graphql"
 	"avito-autumn-2025/internal/http/handlers"
+	"avito-autumn-2025/internal/http/middleware"
+	"avito-autumn-2025/internal/http/openapi"
 	"avito-autumn-2025/internal/logger"
-	"avito-autumn-2025/internal/service/pull_request"
-	"avito-autumn-2025/internal/service/team"
-	"avito-autumn-2025/internal/service/user"
-	"avito-autumn-2025/internal/storage/postgres"
+	"avito-autumn-2025/internal/service"
+	"avito-autumn-2025/internal/storage"
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Services are the services behind the routes. The caller builds them once
+// and shares them with the gRPC server.
+type Services struct {
+	Users        service.User
+	Teams        service.Team
+	Repositories service.Repository
+	PullRequests service.PullRequest
+	SCIM         service.SCIM
+	GraphQL      *graphql.Executor
+	Idempotency  storage.Idempotency
+}
+
 type Server struct {
-	router *gin.Engine
-	server *http.Server
-	db     *pgxpool.Pool
-	log    logger.Logger
+	router         *gin.Engine
+	server         *http.Server
+	services       Services
+	idempotencyTTL time.Duration
+	log            logger.Logger
 }
 
-func NewServer(db *pgxpool.Pool, log logger.Logger) *Server {
+// NewServer creates the HTTP server. A nil authenticator disables
+// authentication and team-level permission checks and trusts the actor named
+// in ActorHeader; it is meant for development only. Responses to requests
+// with an Idempotency-Key are replayed for idempotencyTTL.
+func NewServer(services Services, log logger.Logger, authenticator *auth.Authenticator, idempotencyTTL time.Duration) *Server {
 	router := gin.Default()
+	router.Use(middleware.Errors(log))
+	router.NoRoute(middleware.NoRoute)
+	// Registered before authentication so that the specification is public
+	router.GET(openapi.Path, openapi.Handler)
+	if authenticator != nil {
+		router.Use(middleware.Auth(authenticator))
+	} else {
+		router.Use(middleware.Actor())
+	}
 
 	return &Server{
-		router: router,
-		server: &http.Server{Handler: router},
-		db:     db,
-		log:    log,
+		router:         router,
+		server:         &http.Server{Handler: router},
+		services:       services,
+		idempotencyTTL: idempotencyTTL,
+		log:            log,
 	}
 }
 
 func (s *Server) SetupRoutes() {
-	userStorage := postgres.NewUserStorage(s.db, s.log)
-	teamStorage := postgres.NewTeamStorage(s.db, s.log)
-	prStorage := postgres.NewPullRequestStorage(s.db, s.log)
+	userHandler := handlers.NewUserHandler(s.services.Users, s.log)
+	teamHandler := handlers.NewTeamHandler(s.services.Teams, s.log)
+	repoHandler := handlers.NewRepositoryHandler(s.services.Repositories, s.log)
+	prHandler := handlers.NewPullRequestHandler(s.services.PullRequests, s.log)
+	scimHandler := handlers.NewSCIMHandler(s.services.SCIM, s.log)
+	graphqlHandler := handlers.NewGraphQLHandler(s.services.GraphQL, s.log)
 
-	userSvc := user.NewUserService(&userStorage, s.log)
-	teamSvc := team.NewTeamService(&teamStorage, s.log)
-	prSvc := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, s.log)
+	idempotent := middleware.Idempotency(s.services.Idempotency, s.idempotencyTTL, s.log)
 
-	userHandler := handlers.NewUserHandler(&userSvc, s.log)
-	teamHandler := handlers.NewTeamHandler(&teamSvc, s.log)
-	prHandler := handlers.NewPullRequestHandler(prSvc, s.log)
-
-	api := s.router.Group("/api/v1")
+	api := s.router.Group("/api/v1", middleware.Validate(openapi.MustLoad()))
 	{
-		api.POST("/users", userHandler.CreateUser)
+		api.POST("/users", idempotent, userHandler.CreateUser)
 		api.GET("/users/:id", userHandler.GetUserByID)
+		api.PATCH("/users/:id", userHandler.PatchUser)
+		api.DELETE("/users/:id", userHandler.DeleteUser)
 
-		api.POST("/team/add", teamHandler.PostTeamAdd)
+		api.POST("/team/add", idempotent, teamHandler.PostTeamAdd)
+		api.POST("/team/import", teamHandler.PostTeamImport)
+		api.POST("/team/reconcile", teamHandler.PostTeamReconcile)
 		api.GET("/team/:teamName", teamHandler.GetTeamTeamName)
+		api.PATCH("/team/:teamName", teamHandler.PatchTeam)
+		api.DELETE("/team/:teamName", teamHandler.DeleteTeam)
+		api.POST("/team/:teamName/members", teamHandler.PostTeamMember)
+		api.GET("/team/:teamName/members/history", teamHandler.GetTeamMembersHistory)
+		api.DELETE("/team/:teamName/members/:userId", teamHandler.DeleteTeamMember)
+		api.GET("/team/:teamName/policy", teamHandler.GetTeamPolicy)
+		api.PUT("/team/:teamName/policy", teamHandler.PutTeamPolicy)
+
+		api.POST("/repository/add", repoHandler.PostRepositoryAdd)
+		api.GET("/repository/:repositoryName", repoHandler.GetRepositoryName)
 
-		api.POST("/pull-request/create", prHandler.PostPullRequestCreate)
-		api.POST("/pull-request/merge", prHandler.PostPullRequestMerge)
-		api.POST("/pull-request/reassign", prHandler.PostPullRequestReassign)
+		api.POST("/pull-request/create", idempotent, prHandler.PostPullRequestCreate)
+		api.POST("/pull-request/merge", idempotent, prHandler.PostPullRequestMerge)
+		api.POST("/pull-request/reassign", idempotent, prHandler.PostPullRequestReassign)
+		api.POST("/pull-request/review", idempotent, prHandler.PostPullRequestReview)
+		api.GET("/pull-request/:id", prHandler.GetPullRequest)
+		api.GET("/pull-request/:id/events", prHandler.GetPullRequestEvents)
+		api.GET("/pull-requests", prHandler.SearchPullRequests)
+		api.GET("/pull-requests/stale", prHandler.GetStalePullRequests)
 		api.GET("/users/get-review", prHandler.GetUsersGetReview)
 		api.GET("/statistics", prHandler.GetReviewStatistics)
 	}
+
+	s.router.POST("/graphql", graphqlHandler.PostGraphQL)
+
+	scimAPI := s.router.Group("/scim/v2")
+	{
+		scimAPI.GET("/ServiceProviderConfig", scimHandler.GetServiceProviderConfig)
+
+		scimAPI.GET("/Users", scimHandler.ListUsers)
+		scimAPI.POST("/Users", scimHandler.CreateUser)
+		scimAPI.GET("/Users/:id", scimHandler.GetUser)
+		scimAPI.PUT("/Users/:id", scimHandler.PutUser)
+		scimAPI.PATCH("/Users/:id", scimHandler.PatchUser)
+		scimAPI.DELETE("/Users/:id", scimHandler.DeleteUser)
+
+		scimAPI.GET("/Groups", scimHandler.ListGroups)
+		scimAPI.POST("/Groups", scimHandler.CreateGroup)
+		scimAPI.GET("/Groups/:id", scimHandler.GetGroup)
+		scimAPI.PUT("/Groups/:id", scimHandler.PutGroup)
+		scimAPI.PATCH("/Groups/:id", scimHandler.PatchGroup)
+		scimAPI.DELETE("/Groups/:id", scimHandler.DeleteGroup)
+	}
 }
 
 func (s *Server) Run(addr string) error {
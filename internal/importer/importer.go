@@ -0,0 +1,207 @@
+// Package importer reads team imports from JSON, YAML and CSV files.
+package importer
+
+import (
+	"avito-autumn-2025/internal/models"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+	CSV  Format = "csv"
+)
+
+// csvHeader lists the CSV columns. Every row adds one member to a team; rows
+// without user_id only declare the team. Policies are not supported in CSV.
+var csvHeader = []string{"team_name", "parent_team", "user_id", "username", "is_active", "role", "is_primary"}
+
+// FormatFromContentType maps a request content type to a format.
+func FormatFromContentType(contentType string) (Format, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("invalid content type %q", contentType)
+	}
+	switch mediaType {
+	case "application/json":
+		return JSON, nil
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return YAML, nil
+	case "text/csv":
+		return CSV, nil
+	}
+	return "", fmt.Errorf("unsupported content type %q", mediaType)
+}
+
+// FormatFromPath picks the format by file extension.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	case ".csv":
+		return CSV, nil
+	}
+	return "", fmt.Errorf("unsupported file extension %q", filepath.Ext(path))
+}
+
+// Parse reads an import. JSON and YAML share the same structure; rows are
+// referenced as teams[i] and teams[i].members[j], CSV rows by line number.
+func Parse(format Format, r io.Reader) (*models.Import, error) {
+	var imp *models.Import
+	var err error
+	switch format {
+	case JSON:
+		imp, err = parseJSON(r)
+	case YAML:
+		imp, err = parseYAML(r)
+	case CSV:
+		return parseCSV(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, team := range imp.Teams {
+		if team == nil {
+			return nil, fmt.Errorf("teams[%d] is empty", i)
+		}
+		team.Row = fmt.Sprintf("teams[%d]", i)
+		for j, member := range team.Members {
+			if member == nil {
+				return nil, fmt.Errorf("teams[%d].members[%d] is empty", i, j)
+			}
+			member.Row = fmt.Sprintf("teams[%d].members[%d]", i, j)
+		}
+	}
+	return imp, nil
+}
+
+func parseJSON(r io.Reader) (*models.Import, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	imp := &models.Import{}
+	if err := decoder.Decode(imp); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return imp, nil
+}
+
+// parseYAML converts the document to JSON so that both formats share the
+// field names of the models.
+func parseYAML(r io.Reader) (*models.Import, error) {
+	var document any
+	if err := yaml.NewDecoder(r).Decode(&document); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &models.Import{}, nil
+		}
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	imp := &models.Import{}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(imp); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	return imp, nil
+}
+
+func parseCSV(r io.Reader) (*models.Import, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i, column := range csvHeader {
+		if strings.TrimSpace(header[i]) != column {
+			return nil, fmt.Errorf("invalid CSV header: expected %s", strings.Join(csvHeader, ","))
+		}
+	}
+
+	imp := &models.Import{}
+	teams := map[string]*models.ImportTeam{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		row := fmt.Sprintf("line %d", line)
+
+		teamName, parentTeam, userID := record[0], record[1], record[2]
+		team, ok := teams[teamName]
+		if !ok {
+			team = &models.ImportTeam{Row: row, Name: teamName, ParentTeam: parentTeam}
+			teams[teamName] = team
+			imp.Teams = append(imp.Teams, team)
+		} else if parentTeam != "" && team.ParentTeam != parentTeam {
+			if team.ParentTeam != "" {
+				return nil, fmt.Errorf("%s: team %s already has parent_team %s", row, teamName, team.ParentTeam)
+			}
+			team.ParentTeam = parentTeam
+		}
+
+		if userID == "" {
+			continue
+		}
+
+		var isActive *bool
+		if record[4] != "" {
+			value, err := strconv.ParseBool(record[4])
+			if err != nil {
+				return nil, fmt.Errorf("%s: is_active: %w", row, err)
+			}
+			isActive = &value
+		}
+		isPrimary, err := parseBool(record[6])
+		if err != nil {
+			return nil, fmt.Errorf("%s: is_primary: %w", row, err)
+		}
+
+		team.Members = append(team.Members, &models.ImportMember{
+			Row:       row,
+			Id:        userID,
+			Username:  record[3],
+			IsActive:  isActive,
+			Role:      models.MembershipRole(record[5]),
+			IsPrimary: isPrimary,
+		})
+	}
+	return imp, nil
+}
+
+// parseBool treats an empty cell as false, like an omitted is_primary field.
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
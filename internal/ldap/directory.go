@@ -0,0 +1,189 @@
+// Package ldap reads users and groups from an LDAP server.
+package ldap
+
+import (
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	dialTimeout = 10 * time.Second
+	pageSize    = 500
+)
+
+// Config selects the entries to read and maps their attributes.
+type Config struct {
+	URL          string
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	UserFilter   string
+	GroupFilter  string
+	// UserIdAttr holds the user id, UsernameAttr the username.
+	UserIdAttr   string
+	UsernameAttr string
+	// GroupNameAttr holds the team name. GroupMemberAttr lists the members
+	// either by DN, as member does, or by user id, as memberUid does.
+	GroupNameAttr   string
+	GroupMemberAttr string
+}
+
+// Conn is the part of an LDAP connection the directory uses. *goldap.Conn
+// implements it.
+type Conn interface {
+	Bind(username, password string) error
+	SearchWithPaging(searchRequest *goldap.SearchRequest, pagingSize uint32) (*goldap.SearchResult, error)
+	Close() error
+}
+
+type Directory struct {
+	cfg  Config
+	dial func() (Conn, error)
+	log  logger.Logger
+}
+
+func NewDirectory(cfg Config, log logger.Logger) *Directory {
+	return NewDirectoryWithDialer(cfg, func() (Conn, error) {
+		return goldap.DialURL(cfg.URL, goldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}))
+	}, log)
+}
+
+// NewDirectoryWithDialer reads the directory through the connections dial
+// opens, which lets tests run without an LDAP server.
+func NewDirectoryWithDialer(cfg Config, dial func() (Conn, error), log logger.Logger) *Directory {
+	return &Directory{
+		cfg:  cfg,
+		dial: dial,
+		log:  log,
+	}
+}
+
+// Read returns the users matching the user filter and the groups matching the
+// group filter. Entries without an id or name and members that are not among
+// the users are skipped.
+func (d *Directory) Read(ctx context.Context) (*models.Directory, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	conn, err := d.dial()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+	}
+	defer conn.Close()
+
+	if d.cfg.BindDN != "" {
+		if err := conn.Bind(d.cfg.BindDN, d.cfg.BindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind to LDAP server: %w", err)
+		}
+	}
+
+	userEntries, err := d.search(conn, d.cfg.UserFilter, d.cfg.UserIdAttr, d.cfg.UsernameAttr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search LDAP users: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	groupEntries, err := d.search(conn, d.cfg.GroupFilter, d.cfg.GroupNameAttr, d.cfg.GroupMemberAttr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search LDAP groups: %w", err)
+	}
+
+	dir := &models.Directory{}
+	byDN := map[string]*models.DirectoryUser{}
+	byID := map[string]*models.DirectoryUser{}
+	for _, entry := range userEntries {
+		id := entry.GetAttributeValue(d.cfg.UserIdAttr)
+		if id == "" {
+			d.log.Warn("Skipping LDAP user without id", "dn", entry.DN)
+			continue
+		}
+		if byID[id] != nil {
+			d.log.Warn("Skipping LDAP user with duplicate id", "dn", entry.DN, "user_id", id)
+			continue
+		}
+		username := entry.GetAttributeValue(d.cfg.UsernameAttr)
+		if username == "" {
+			username = id
+		}
+
+		user := &models.DirectoryUser{DN: entry.DN, Id: id, Username: username}
+		dir.Users = append(dir.Users, user)
+		byDN[normalizeDN(entry.DN)] = user
+		byID[id] = user
+	}
+
+	names := map[string]bool{}
+	for _, entry := range groupEntries {
+		name := entry.GetAttributeValue(d.cfg.GroupNameAttr)
+		if name == "" {
+			d.log.Warn("Skipping LDAP group without name", "dn", entry.DN)
+			continue
+		}
+		if names[name] {
+			d.log.Warn("Skipping LDAP group with duplicate name", "dn", entry.DN, "team_name", name)
+			continue
+		}
+		names[name] = true
+
+		group := &models.DirectoryGroup{DN: entry.DN, Name: name}
+		for _, value := range entry.GetAttributeValues(d.cfg.GroupMemberAttr) {
+			user := byDN[normalizeDN(value)]
+			if user == nil {
+				user = byID[value]
+			}
+			if user == nil {
+				d.log.Debug("Skipping LDAP group member that is not a user", "team_name", name, "member", value)
+				continue
+			}
+			if !slices.Contains(group.MemberIds, user.Id) {
+				group.MemberIds = append(group.MemberIds, user.Id)
+			}
+		}
+		dir.Groups = append(dir.Groups, group)
+	}
+
+	return dir, nil
+}
+
+func (d *Directory) search(conn Conn, filter string, attributes ...string) ([]*goldap.Entry, error) {
+	request := goldap.NewSearchRequest(
+		d.cfg.BaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		filter,
+		attributes,
+		nil,
+	)
+	result, err := conn.SearchWithPaging(request, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return result.Entries, nil
+}
+
+// normalizeDN makes equal DNs compare equal regardless of case and spacing.
+// Values that do not parse as DNs are only lowercased.
+func normalizeDN(dn string) string {
+	parsed, err := goldap.ParseDN(dn)
+	if err != nil {
+		return strings.ToLower(dn)
+	}
+	rdns := make([]string, 0, len(parsed.RDNs))
+	for _, rdn := range parsed.RDNs {
+		attributes := make([]string, 0, len(rdn.Attributes))
+		for _, attribute := range rdn.Attributes {
+			attributes = append(attributes, strings.ToLower(attribute.Type)+"="+strings.ToLower(attribute.Value))
+		}
+		rdns = append(rdns, strings.Join(attributes, "+"))
+	}
+	return strings.Join(rdns, ",")
+}
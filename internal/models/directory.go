@@ -0,0 +1,22 @@
+package models
+
+// Directory is a snapshot of users and groups read from an external directory
+// such as LDAP. Groups become teams; DN identifies the entry in the directory.
+type Directory struct {
+	Users  []*DirectoryUser
+	Groups []*DirectoryGroup
+}
+
+type DirectoryUser struct {
+	DN       string
+	Id       string
+	Username string
+}
+
+// DirectoryGroup lists its members by user id. Every member is one of the
+// users of the snapshot.
+type DirectoryGroup struct {
+	DN        string
+	Name      string
+	MemberIds []string
+}
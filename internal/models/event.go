@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type PullRequestEventType string
+
+const (
+	EventCreated            PullRequestEventType = "CREATED"
+	EventReviewerAssigned   PullRequestEventType = "REVIEWER_ASSIGNED"
+	EventReviewerReassigned PullRequestEventType = "REVIEWER_REASSIGNED"
+	EventReviewerUnassigned PullRequestEventType = "REVIEWER_UNASSIGNED"
+	EventVerdict            PullRequestEventType = "VERDICT"
+	EventMerged             PullRequestEventType = "MERGED"
+	EventMarkedStale        PullRequestEventType = "MARKED_STALE"
+	EventClosed             PullRequestEventType = "CLOSED"
+)
+
+// PullRequestEvent is an entry of the append-only pull request timeline. Before
+// and After hold the changed values; either may be empty for events that only
+// add or only remove state.
+type PullRequestEvent struct {
+	Id            int64                `db:"id" json:"id"`
+	Repository    string               `db:"repository" json:"repository"`
+	PullRequestId string               `db:"pr_id" json:"pull_request_id"`
+	Type          PullRequestEventType `db:"event_type" json:"type"`
+	Actor         string               `db:"actor" json:"actor"`
+	Before        map[string]any       `db:"before" json:"before,omitempty"`
+	After         map[string]any       `db:"after" json:"after,omitempty"`
+	CreatedAt     time.Time            `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyRecord is a request made with an idempotency key and, once it
+// completed, its response.
+type IdempotencyRecord struct {
+	ActorId     string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	Completed   bool
+	CreatedAt   time.Time
+}
@@ -0,0 +1,84 @@
+package models
+
+// Import describes teams, their members and policies to create or update in
+// one go. Teams and members missing from the import are left untouched, unless
+// the import is reconciled: then it is the full desired state of the
+// organization and everything missing from it is removed or deactivated.
+type Import struct {
+	Teams []*ImportTeam `json:"teams"`
+}
+
+type ImportTeam struct {
+	// Row locates the entry in the source file for error reporting.
+	Row        string          `json:"-"`
+	Name       string          `json:"team_name"`
+	ParentTeam string          `json:"parent_team"`
+	Policy     *TeamPolicy     `json:"policy"`
+	Members    []*ImportMember `json:"members"`
+}
+
+type ImportMember struct {
+	Row      string `json:"-"`
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	// IsActive keeps the current state of existing users when omitted; new
+	// users are created active.
+	IsActive  *bool          `json:"is_active"`
+	Role      MembershipRole `json:"role"`
+	IsPrimary bool           `json:"is_primary"`
+}
+
+type ImportRowKind string
+
+const (
+	ImportRowTeam   ImportRowKind = "team"
+	ImportRowPolicy ImportRowKind = "policy"
+	ImportRowMember ImportRowKind = "member"
+	ImportRowUser   ImportRowKind = "user"
+	ImportRowReview ImportRowKind = "review"
+)
+
+type ImportAction string
+
+const (
+	ImportCreate    ImportAction = "create"
+	ImportUpdate    ImportAction = "update"
+	ImportUnchanged ImportAction = "unchanged"
+	ImportInvalid   ImportAction = "invalid"
+	ImportFailed    ImportAction = "failed"
+	ImportSkipped   ImportAction = "skipped"
+	ImportDelete    ImportAction = "delete"
+)
+
+// ImportRowResult is the outcome of one entry. Before and After hold the
+// changed attributes only. Rows produced by reconciliation have no source row.
+type ImportRowResult struct {
+	Row           string         `json:"row,omitempty"`
+	Kind          ImportRowKind  `json:"kind"`
+	TeamName      string         `json:"team_name,omitempty"`
+	UserId        string         `json:"user_id,omitempty"`
+	Repository    string         `json:"repository,omitempty"`
+	PullRequestId string         `json:"pull_request_id,omitempty"`
+	Action        ImportAction   `json:"action"`
+	Before        map[string]any `json:"before,omitempty"`
+	After         map[string]any `json:"after,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// ImportResult reports every entry of an import. Nothing is applied when the
+// import is a dry run or any entry is invalid or fails.
+type ImportResult struct {
+	DryRun  bool               `json:"dry_run"`
+	Applied bool               `json:"applied"`
+	Rows    []*ImportRowResult `json:"rows"`
+}
+
+// Failed reports whether any entry is invalid or failed to apply.
+func (r *ImportResult) Failed() bool {
+	for _, row := range r.Rows {
+		if row.Error != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+package models
+
+// ListQuery selects a page of users or teams ordered by id. A non-empty Name
+// keeps only the user with that username or the team with that name, compared
+// case-insensitively.
+type ListQuery struct {
+	Name   string
+	Offset int
+	Limit  int
+}
@@ -7,21 +7,76 @@ type PullRequestStatus string
 const (
 	OPEN   PullRequestStatus = "OPEN"
 	MERGED PullRequestStatus = "MERGED"
+	CLOSED PullRequestStatus = "CLOSED"
+)
+
+type PullRequestPriority string
+
+const (
+	LOW    PullRequestPriority = "LOW"
+	NORMAL PullRequestPriority = "NORMAL"
+	HIGH   PullRequestPriority = "HIGH"
+	URGENT PullRequestPriority = "URGENT"
+)
+
+type ReviewVerdict string
+
+const (
+	APPROVED          ReviewVerdict = "APPROVED"
+	CHANGES_REQUESTED ReviewVerdict = "CHANGES_REQUESTED"
+	COMMENTED         ReviewVerdict = "COMMENTED"
+)
+
+// ExistingPullRequestAction decides what creating a pull request that already
+// exists does.
+type ExistingPullRequestAction string
+
+const (
+	ExistingPullRequestReject ExistingPullRequestAction = "reject"
+	ExistingPullRequestReturn ExistingPullRequestAction = "return"
 )
 
 type PullRequest struct {
-	PullRequestId     string            `db:"id" json:"pull_request_id" binding:"required"`
-	PullRequestName   string            `db:"title" json:"pull_request_name" binding:"required"`
-	AuthorId          string            `db:"author_id" json:"author_id" binding:"required"`
-	Status            PullRequestStatus `db:"status" json:"status"`
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	CreatedAt         *time.Time        `db:"created_at" json:"createdAt"`
-	MergedAt          *time.Time        `db:"merged_at" json:"mergedAt"`
+	PullRequestId     string              `db:"id" json:"pull_request_id" binding:"required"`
+	PullRequestName   string              `db:"title" json:"pull_request_name" binding:"required"`
+	AuthorId          string              `db:"author_id" json:"author_id" binding:"required"`
+	Status            PullRequestStatus   `db:"status" json:"status"`
+	Repository        string              `db:"repository" json:"repository"`
+	TeamName          string              `db:"team_name" json:"team_name,omitempty"`
+	Labels            []string            `db:"labels" json:"labels"`
+	Priority          PullRequestPriority `db:"priority" json:"priority"`
+	URL               string              `db:"url" json:"url"`
+	Additions         int                 `db:"additions" json:"additions"`
+	Deletions         int                 `db:"deletions" json:"deletions"`
+	ChangedFiles      int                 `db:"changed_files" json:"changed_files"`
+	AssignedReviewers []string            `json:"assigned_reviewers"`
+	SLAActions        []*SLAAction        `json:"sla_actions,omitempty"`
+	CreatedAt         *time.Time          `db:"created_at" json:"createdAt"`
+	MergedAt          *time.Time          `db:"merged_at" json:"mergedAt"`
+	UpdatedAt         *time.Time          `db:"updated_at" json:"updatedAt,omitempty"`
+	StaleAt           *time.Time          `db:"stale_at" json:"staleAt,omitempty"`
+	ClosedAt          *time.Time          `db:"closed_at" json:"closedAt,omitempty"`
+	Version           int64               `db:"version" json:"version"`
+}
+
+// PullRequestKey identifies a pull request within its repository.
+type PullRequestKey struct {
+	Repository    string
+	PullRequestId string
 }
 
 type PullRequestShort struct {
+	Repository      string            `db:"repository" json:"repository"`
 	PullRequestId   string            `db:"id" json:"pull_request_id"`
 	PullRequestName string            `db:"title" json:"pull_request_name"`
 	AuthorId        string            `db:"author_id" json:"author_id"`
 	Status          PullRequestStatus `db:"status" json:"status"`
+	TeamName        string            `db:"team_name" json:"team_name,omitempty"`
+	CreatedAt       *time.Time        `db:"created_at" json:"createdAt,omitempty"`
+	UpdatedAt       *time.Time        `db:"updated_at" json:"updatedAt,omitempty"`
+}
+
+// ChangedLines is the total size of the diff.
+func (pr *PullRequest) ChangedLines() int {
+	return pr.Additions + pr.Deletions
 }
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// PullRequestSort is the field pull request lists are ordered by.
+type PullRequestSort string
+
+const (
+	SortByCreatedAt PullRequestSort = "created_at"
+	SortByUpdatedAt PullRequestSort = "updated_at"
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// PullRequestQuery filters, orders and paginates a pull request list. Empty
+// filters match every pull request; CreatedFrom is inclusive and CreatedTo is
+// exclusive.
+type PullRequestQuery struct {
+	ReviewerId  string
+	AuthorId    string
+	TeamName    string
+	Repository  string
+	Statuses    []PullRequestStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Sort        PullRequestSort
+	Order       SortOrder
+	Limit       int
+	After       *PullRequestCursor
+}
+
+// PullRequestCursor points at the last pull request of a page. It remembers the
+// ordering it was issued for so it cannot be replayed against another one.
+type PullRequestCursor struct {
+	Sort          PullRequestSort `json:"s"`
+	Order         SortOrder       `json:"o"`
+	Value         time.Time       `json:"v"`
+	Repository    string          `json:"r"`
+	PullRequestId string          `json:"id"`
+}
+
+// Encode returns the opaque form of the cursor handed out to clients.
+func (c *PullRequestCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePullRequestCursor parses a cursor returned by Encode.
+func DecodePullRequestCursor(value string) (*PullRequestCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("malformed cursor")
+	}
+	cursor := &PullRequestCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.PullRequestId == "" {
+		return nil, errors.New("malformed cursor")
+	}
+	return cursor, nil
+}
+
+// PullRequestPage is one page of a pull request list. NextCursor is nil on the
+// last page.
+type PullRequestPage struct {
+	PullRequests []*PullRequestShort
+	NextCursor   *PullRequestCursor
+}
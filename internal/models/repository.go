@@ -0,0 +1,12 @@
+package models
+
+// Repository holds the reviewer pool and assignment rules of a source repository.
+// Pull requests of repositories without a configured pool are reviewed by the
+// repository's team, or by the author's primary team when it has none.
+type Repository struct {
+	Name                 string   `db:"name" json:"repository" binding:"required"`
+	TeamName             string   `db:"team_name" json:"team_name,omitempty"`
+	ReviewersCount       int      `db:"reviewers_count" json:"reviewers_count"`
+	FallbackToAuthorTeam bool     `db:"fallback_to_author_team" json:"fallback_to_author_team"`
+	Reviewers            []string `json:"reviewers"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// ReviewAssignment is a reviewer currently assigned to a pull request.
+// PullRequestStatus is the status of the pull request, TeamName the team the
+// review was attributed to when it was assigned. Verdict is empty until the
+// reviewer responds.
+type ReviewAssignment struct {
+	Repository        string            `db:"repository" json:"repository"`
+	PullRequestId     string            `db:"pr_id" json:"pull_request_id"`
+	PullRequestStatus PullRequestStatus `db:"status" json:"status"`
+	ReviewerId        string            `db:"user_id" json:"reviewer_id"`
+	TeamName          string            `db:"team_name" json:"team_name,omitempty"`
+	AssignedAt        time.Time         `db:"assigned_at" json:"assigned_at"`
+	RespondedAt       *time.Time        `db:"responded_at" json:"responded_at,omitempty"`
+	Verdict           ReviewVerdict     `db:"verdict" json:"verdict,omitempty"`
+	EscalatedAt       *time.Time        `db:"escalated_at" json:"escalated_at,omitempty"`
+}
+
+// ReassignmentResult counts the open reviews a departing user held. Reviews
+// nobody could take over are dropped from their pull requests and counted as
+// Unassigned.
+type ReassignmentResult struct {
+	Reassigned int `json:"reassigned_reviews"`
+	Unassigned int `json:"unassigned_reviews"`
+}
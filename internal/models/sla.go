@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type SLAActionType string
+
+const (
+	SLAActionReassign SLAActionType = "REASSIGN"
+	SLAActionEscalate SLAActionType = "ESCALATE"
+)
+
+type SLAAction struct {
+	Id            int64         `db:"id" json:"id"`
+	Repository    string        `db:"repository" json:"repository"`
+	PullRequestId string        `db:"pr_id" json:"pull_request_id"`
+	ReviewerId    string        `db:"reviewer_id" json:"reviewer_id"`
+	Action        SLAActionType `db:"action" json:"action"`
+	NewReviewerId string        `db:"new_reviewer_id" json:"new_reviewer_id,omitempty"`
+	Deadline      time.Time     `db:"deadline" json:"deadline"`
+	CreatedAt     time.Time     `db:"created_at" json:"created_at"`
+}
+
+// PendingReview is a reviewer assignment on an open pull request that has not
+// received a first response yet, together with the SLA policy of the author's team.
+type PendingReview struct {
+	Repository    string     `json:"repository"`
+	PullRequestId string     `json:"pull_request_id"`
+	ReviewerId    string     `json:"reviewer_id"`
+	AssignedAt    time.Time  `json:"assigned_at"`
+	Policy        TeamPolicy `json:"policy"`
+}
@@ -6,6 +6,7 @@ type ReviewStatistics struct {
 	TotalPRs      int                  `json:"total_prs"`
 	OpenPRs       int                  `json:"open_prs"`
 	MergedPRs     int                  `json:"merged_prs"`
+	ClosedPRs     int                  `json:"closed_prs"`
 	ReviewerStats []ReviewerStatistics `json:"reviewer_stats"`
 	TeamStats     []TeamStatistics     `json:"team_stats"`
 	GeneratedAt   time.Time            `json:"generated_at"`
@@ -18,9 +19,21 @@ type ReviewerStatistics struct {
 	LastAssignedAt   *time.Time `json:"last_assigned_at,omitempty"`
 }
 
+// TeamStatistics counts the team itself and, in the Subtree fields, the team
+// together with all of its descendants. Subtree member counts are distinct users.
+// Pull requests and reviews count towards the team they were attributed to when
+// they were created or assigned, not the current teams of their users. Deleted
+// teams keep their reviews; their pull requests lose the team on deletion.
 type TeamStatistics struct {
-	TeamName          string `json:"team_name"`
-	MemberCount       int    `json:"member_count"`
-	ActiveMemberCount int    `json:"active_member_count"`
-	PRsCreated        int    `json:"prs_created"`
+	TeamName                 string `json:"team_name"`
+	ParentTeam               string `json:"parent_team,omitempty"`
+	Deleted                  bool   `json:"deleted"`
+	MemberCount              int    `json:"member_count"`
+	ActiveMemberCount        int    `json:"active_member_count"`
+	PRsCreated               int    `json:"prs_created"`
+	ReviewsAssigned          int    `json:"reviews_assigned"`
+	SubtreeMemberCount       int    `json:"subtree_member_count"`
+	SubtreeActiveMemberCount int    `json:"subtree_active_member_count"`
+	SubtreePRsCreated        int    `json:"subtree_prs_created"`
+	SubtreeReviewsAssigned   int    `json:"subtree_reviews_assigned"`
 }
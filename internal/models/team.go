@@ -1,7 +1,86 @@
 package models
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 type Team struct {
-	Id    string  `db:"id" json:"id"`
-	Name  string  `db:"name" json:"team_name" binding:"required"`
-	Users []*User `json:"members" binding:"dive"`
+	Id         string  `db:"id" json:"id"`
+	Name       string  `db:"name" json:"team_name" binding:"required"`
+	ParentTeam string  `db:"parent_name" json:"parent_team,omitempty"`
+	Users      []*User `json:"members" binding:"dive"`
+	Subteams   []*Team `json:"subteams,omitempty"`
+	Version    int64   `json:"version,omitempty"`
+}
+
+// TeamUpdate holds the editable attributes of a team. Empty fields are left
+// unchanged; an empty ParentTeam detaches the team from its parent.
+type TeamUpdate struct {
+	Name       string  `json:"team_name"`
+	ParentTeam *string `json:"parent_team"`
+}
+
+// MembershipRole is the role of a user within one team. Leads manage the team,
+// observers see it but are never picked as reviewers.
+type MembershipRole string
+
+const (
+	RoleLead     MembershipRole = "lead"
+	RoleMember   MembershipRole = "member"
+	RoleObserver MembershipRole = "observer"
+)
+
+// TeamMembershipInterval is one period of a user's membership in a team.
+// LeftAt is nil while the user is still a member.
+type TeamMembershipInterval struct {
+	TeamName string     `json:"team_name"`
+	UserId   string     `json:"user_id"`
+	JoinedAt time.Time  `json:"joined_at"`
+	LeftAt   *time.Time `json:"left_at,omitempty"`
+}
+
+// OpenReviewsAction decides what happens to open reviews held by members of a
+// team that is being deleted.
+type OpenReviewsAction string
+
+const (
+	OpenReviewsReject   OpenReviewsAction = "reject"
+	OpenReviewsReassign OpenReviewsAction = "reassign"
+)
+
+// Working hours of policies that do not set their own.
+const (
+	DefaultWorkdayStart = 9
+	DefaultWorkdayEnd   = 18
+	DefaultTimeZone     = "UTC"
+)
+
+// TeamPolicy holds the review rules of a team. With BusinessHoursOnly only the
+// hours from WorkdayStart to WorkdayEnd o'clock of weekdays in TimeZone count
+// towards FirstResponseHours.
+type TeamPolicy struct {
+	TeamName             string        `db:"team_name" json:"team_name"`
+	FirstResponseHours   int           `db:"first_response_hours" json:"first_response_hours"`
+	BusinessHoursOnly    bool          `db:"business_hours_only" json:"business_hours_only"`
+	WorkdayStart         int           `db:"workday_start" json:"workday_start"`
+	WorkdayEnd           int           `db:"workday_end" json:"workday_end"`
+	TimeZone             string        `db:"time_zone" json:"time_zone"`
+	SLAAction            SLAActionType `db:"sla_action" json:"sla_action"`
+	EscalationReviewerId string        `db:"escalation_reviewer_id" json:"escalation_reviewer_id,omitempty"`
+	StaleAfterDays       int           `db:"stale_after_days" json:"stale_after_days"`
+	CloseAfterDays       int           `db:"close_after_days" json:"close_after_days"`
+}
+
+// ValidateBusinessHours checks the working-hours window of the policy.
+func (p *TeamPolicy) ValidateBusinessHours() error {
+	if p.WorkdayStart < 0 || p.WorkdayStart >= p.WorkdayEnd || p.WorkdayEnd > 24 {
+		return errors.New("workday_start and workday_end must satisfy 0 <= workday_start < workday_end <= 24")
+	}
+	// An empty name and Local would depend on the server
+	if _, err := time.LoadLocation(p.TimeZone); err != nil || p.TimeZone == "" || p.TimeZone == "Local" {
+		return fmt.Errorf("time_zone %q is not a known time zone", p.TimeZone)
+	}
+	return nil
 }
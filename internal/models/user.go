@@ -4,5 +4,17 @@ type User struct {
 	Id       string `db:"id" json:"id" binding:"required"`
 	Username string `db:"username" json:"username" binding:"required"`
 	IsActive bool   `db:"is_active" json:"is_active"`
-	TeamName string `db:"team_name" json:"team_name"`
+	// TeamName is the primary team; Teams lists every team the user belongs to.
+	TeamName string   `db:"team_name" json:"team_name"`
+	Teams    []string `json:"teams,omitempty"`
+	// Role is the membership role when the user is listed as a team member.
+	Role MembershipRole `json:"role,omitempty"`
+}
+
+// UserUpdate holds the editable attributes of a user; nil fields are left
+// unchanged. TeamName makes the team the user's primary team.
+type UserUpdate struct {
+	Username *string `json:"username"`
+	IsActive *bool   `json:"is_active"`
+	TeamName *string `json:"team_name"`
 }
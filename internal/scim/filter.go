@@ -0,0 +1,340 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Attributes are the filterable attributes of a resource, keyed by lowercase
+// attribute path. Values are strings, bools or string slices for multi-valued
+// attributes.
+type Attributes map[string]any
+
+// Filter is a parsed SCIM filter expression (RFC 7644, section 3.4.2.2).
+type Filter interface {
+	Match(attrs Attributes) bool
+}
+
+// ParseFilter parses expr. Only the attribute paths in allowed may be
+// referenced; complex attribute filters such as emails[type eq "work"] are not
+// supported.
+func ParseFilter(expr string, allowed ...string) (Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, allowed: map[string]bool{}}
+	for _, attr := range allowed {
+		p.allowed[strings.ToLower(attr)] = true
+	}
+
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, InvalidFilter(fmt.Sprintf("unexpected %q", p.tokens[p.pos].text))
+	}
+	return filter, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, token{text: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(expr) && expr[end] != '"' {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, InvalidFilter("unterminated string")
+			}
+			var value string
+			if err := json.Unmarshal([]byte(expr[i:end+1]), &value); err != nil {
+				return nil, InvalidFilter(fmt.Sprintf("invalid string %s", expr[i:end+1]))
+			}
+			tokens = append(tokens, token{text: value, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(expr) && !strings.ContainsRune(" \t()\"", rune(expr[end])) {
+				end++
+			}
+			tokens = append(tokens, token{text: expr[i:end]})
+			i = end
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, InvalidFilter("empty filter")
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens  []token
+	pos     int
+	allowed map[string]bool
+}
+
+func (p *parser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].text, keyword)
+}
+
+func (p *parser) next() (token, error) {
+	if p.pos >= len(p.tokens) {
+		return token{}, InvalidFilter("unexpected end of filter")
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseFactor() (Filter, error) {
+	negate := false
+	if p.peekKeyword("not") {
+		p.pos++
+		negate = true
+		if !p.peekKeyword("(") {
+			return nil, InvalidFilter("not must be followed by a parenthesized filter")
+		}
+	}
+
+	if p.peekKeyword("(") {
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peekKeyword(")") {
+			return nil, InvalidFilter("missing closing parenthesis")
+		}
+		p.pos++
+		if negate {
+			return notFilter{inner}, nil
+		}
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Filter, error) {
+	attrToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if attrToken.quoted {
+		return nil, InvalidFilter(fmt.Sprintf("expected attribute, got %q", attrToken.text))
+	}
+	attr := attributePath(attrToken.text)
+	if !p.allowed[attr] {
+		return nil, InvalidFilter(fmt.Sprintf("unsupported attribute %s", attrToken.text))
+	}
+
+	opToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op := strings.ToLower(opToken.text)
+	if opToken.quoted {
+		return nil, InvalidFilter(fmt.Sprintf("expected operator, got %q", opToken.text))
+	}
+	if op == "pr" {
+		return presentFilter{attr}, nil
+	}
+
+	switch op {
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, InvalidFilter(fmt.Sprintf("unsupported operator %s", opToken.text))
+	}
+
+	valueToken, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	switch {
+	case valueToken.quoted:
+		value = valueToken.text
+	case strings.EqualFold(valueToken.text, "true"):
+		value = true
+	case strings.EqualFold(valueToken.text, "false"):
+		value = false
+	case strings.EqualFold(valueToken.text, "null"):
+		value = nil
+	default:
+		return nil, InvalidFilter(fmt.Sprintf("unsupported value %s", valueToken.text))
+	}
+
+	if _, ok := value.(string); !ok && op != "eq" && op != "ne" {
+		return nil, InvalidFilter(fmt.Sprintf("operator %s needs a string value", op))
+	}
+	return compareFilter{attr: attr, op: op, value: value}, nil
+}
+
+// EqualityValue returns the value of a filter that is a single eq comparison
+// of one of attrs with a string, such as userName eq "alice". Lists use it to
+// look such resources up directly instead of filtering every resource.
+func EqualityValue(filter Filter, attrs ...string) (string, bool) {
+	compare, ok := filter.(compareFilter)
+	if !ok || compare.op != "eq" {
+		return "", false
+	}
+	value, ok := compare.value.(string)
+	if !ok || !slices.ContainsFunc(attrs, func(attr string) bool { return strings.EqualFold(attr, compare.attr) }) {
+		return "", false
+	}
+	return value, true
+}
+
+// attributePath strips the schema URN from a fully qualified attribute path
+// and lowercases it, since attribute names are case-insensitive.
+func attributePath(path string) string {
+	if strings.HasPrefix(strings.ToLower(path), "urn:") {
+		path = path[strings.LastIndex(path, ":")+1:]
+	}
+	return strings.ToLower(path)
+}
+
+type andFilter struct{ left, right Filter }
+
+func (f andFilter) Match(attrs Attributes) bool { return f.left.Match(attrs) && f.right.Match(attrs) }
+
+type orFilter struct{ left, right Filter }
+
+func (f orFilter) Match(attrs Attributes) bool { return f.left.Match(attrs) || f.right.Match(attrs) }
+
+type notFilter struct{ inner Filter }
+
+func (f notFilter) Match(attrs Attributes) bool { return !f.inner.Match(attrs) }
+
+type presentFilter struct{ attr string }
+
+func (f presentFilter) Match(attrs Attributes) bool {
+	switch value := attrs[f.attr].(type) {
+	case nil:
+		return false
+	case string:
+		return value != ""
+	case []string:
+		return len(value) > 0
+	default:
+		return true
+	}
+}
+
+type compareFilter struct {
+	attr  string
+	op    string
+	value any
+}
+
+// Match compares strings case-insensitively. A multi-valued attribute matches
+// when any of its values does.
+func (f compareFilter) Match(attrs Attributes) bool {
+	actual, ok := attrs[f.attr]
+	if f.value == nil {
+		present := presentFilter{f.attr}.Match(attrs)
+		return (f.op == "eq") != present
+	}
+	if !ok {
+		return f.op == "ne"
+	}
+
+	switch actual := actual.(type) {
+	case []string:
+		for _, item := range actual {
+			if f.compare(item) {
+				return true
+			}
+		}
+		return false
+	default:
+		return f.compare(actual)
+	}
+}
+
+func (f compareFilter) compare(actual any) bool {
+	if expected, ok := f.value.(bool); ok {
+		actual, ok := actual.(bool)
+		return ok && (actual == expected) == (f.op == "eq")
+	}
+
+	expected := strings.ToLower(f.value.(string))
+	actualString, ok := actual.(string)
+	if !ok {
+		return false
+	}
+	actualString = strings.ToLower(actualString)
+
+	switch f.op {
+	case "eq":
+		return actualString == expected
+	case "ne":
+		return actualString != expected
+	case "co":
+		return strings.Contains(actualString, expected)
+	case "sw":
+		return strings.HasPrefix(actualString, expected)
+	case "ew":
+		return strings.HasSuffix(actualString, expected)
+	case "gt":
+		return actualString > expected
+	case "ge":
+		return actualString >= expected
+	case "lt":
+		return actualString < expected
+	case "le":
+		return actualString <= expected
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+// Validate checks the operation names. Some identity providers capitalize
+// them, so they are normalized to lowercase.
+func (r *PatchRequest) Validate() error {
+	if len(r.Operations) == 0 {
+		return InvalidValue("Operations must not be empty")
+	}
+	for i := range r.Operations {
+		op := &r.Operations[i]
+		op.Op = strings.ToLower(op.Op)
+		switch op.Op {
+		case PatchAdd, PatchReplace:
+			if len(op.Value) == 0 {
+				return InvalidValue(op.Op + " needs a value")
+			}
+		case PatchRemove:
+			if op.Path == "" {
+				return NoTarget("remove needs a path")
+			}
+		default:
+			return InvalidValue("op must be one of add, remove, replace")
+		}
+	}
+	return nil
+}
+
+// ParsePath splits a PATCH path into its lowercase attribute and an optional
+// value filter, as in members[value eq "user1"]. The filter may reference only
+// the sub-attributes in allowed.
+func ParsePath(path string, allowed ...string) (string, Filter, error) {
+	attr, rest, found := strings.Cut(path, "[")
+	attr = attributePath(strings.TrimSpace(attr))
+	if !found {
+		return attr, nil, nil
+	}
+
+	expr, tail, ok := strings.Cut(rest, "]")
+	if !ok || strings.TrimSpace(tail) != "" {
+		return "", nil, InvalidPath("invalid path " + path)
+	}
+	filter, err := ParseFilter(expr, allowed...)
+	if err != nil {
+		var scimErr *Error
+		if errors.As(err, &scimErr) {
+			return "", nil, InvalidPath("invalid path " + path + ": " + scimErr.Detail)
+		}
+		return "", nil, err
+	}
+	return attr, filter, nil
+}
+
+// ValueObject decodes the value of an operation without a path, which holds
+// attributes by name. Keys are lowercased.
+func (op *PatchOperation) ValueObject() (map[string]json.RawMessage, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(op.Value, &raw); err != nil {
+		return nil, InvalidValue("value must be an object when path is empty")
+	}
+	values := make(map[string]json.RawMessage, len(raw))
+	for key, value := range raw {
+		values[attributePath(key)] = value
+	}
+	return values, nil
+}
+
+// DecodeBool accepts JSON booleans and, since some identity providers send
+// them, the strings "true" and "false" in any case.
+func DecodeBool(raw json.RawMessage) (bool, error) {
+	var value bool
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if value, err := strconv.ParseBool(strings.ToLower(text)); err == nil {
+			return value, nil
+		}
+	}
+	return false, InvalidValue("expected a boolean, got " + string(raw))
+}
+
+func DecodeString(raw json.RawMessage) (string, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", InvalidValue("expected a string, got " + string(raw))
+	}
+	return value, nil
+}
+
+// DecodeReferences accepts a list of references or a single one.
+func DecodeReferences(raw json.RawMessage) ([]Reference, error) {
+	var refs []Reference
+	if err := json.Unmarshal(raw, &refs); err == nil {
+		return refs, nil
+	}
+	var ref Reference
+	if err := json.Unmarshal(raw, &ref); err == nil && ref.Value != "" {
+		return []Reference{ref}, nil
+	}
+	return nil, InvalidValue("expected members, got " + string(raw))
+}
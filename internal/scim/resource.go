@@ -0,0 +1,278 @@
+// Package scim maps users and teams onto SCIM 2.0 resources (RFC 7643) and
+// implements the parts of the protocol (RFC 7644) the provisioning endpoints
+// need: filters, PATCH operations, list responses and errors.
+package scim
+
+import (
+	"avito-autumn-2025/internal/models"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	UserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	// MaxResults caps the page size of list responses.
+	MaxResults = 100
+)
+
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+// Reference points to another resource: a group of a user or a member of a
+// group.
+type Reference struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// User is a user resource. The id is the user id; externalId mirrors it,
+// since identity providers match users by externalId. On creation the id is
+// taken from externalId, falling back to userName.
+type User struct {
+	Schemas    []string    `json:"schemas"`
+	Id         string      `json:"id,omitempty"`
+	ExternalId string      `json:"externalId,omitempty"`
+	UserName   string      `json:"userName"`
+	Active     *bool       `json:"active,omitempty"`
+	Groups     []Reference `json:"groups,omitempty"`
+	Meta       *Meta       `json:"meta,omitempty"`
+}
+
+// Group is a team resource. The id and displayName are the team name.
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	Id          string      `json:"id,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []Reference `json:"members"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// UserAttributes lists the attribute paths users can be filtered by.
+var UserAttributes = []string{"id", "externalId", "userName", "active", "groups.value", "groups.display"}
+
+// GroupAttributes lists the attribute paths groups can be filtered by.
+var GroupAttributes = []string{"id", "externalId", "displayName", "members.value", "members.display"}
+
+func FromUser(user *models.User) *User {
+	active := user.IsActive
+	resource := &User{
+		Schemas:    []string{UserSchema},
+		Id:         user.Id,
+		ExternalId: user.Id,
+		UserName:   user.Username,
+		Active:     &active,
+		Meta:       &Meta{ResourceType: "User"},
+	}
+	for _, team := range user.Teams {
+		resource.Groups = append(resource.Groups, Reference{Value: team, Display: team})
+	}
+	return resource
+}
+
+func FromTeam(team *models.Team) *Group {
+	group := &Group{
+		Schemas:     []string{GroupSchema},
+		Id:          team.Name,
+		DisplayName: team.Name,
+		Members:     []Reference{},
+		Meta:        &Meta{ResourceType: "Group"},
+	}
+	for _, member := range team.Users {
+		group.Members = append(group.Members, Reference{Value: member.Id, Display: member.Username})
+	}
+	return group
+}
+
+func (u *User) Attributes() Attributes {
+	attrs := Attributes{"id": u.Id, "externalid": u.ExternalId, "username": u.UserName}
+	if u.Active != nil {
+		attrs["active"] = *u.Active
+	}
+	values, displays := referenceValues(u.Groups)
+	attrs["groups.value"], attrs["groups.display"] = values, displays
+	return attrs
+}
+
+func (g *Group) Attributes() Attributes {
+	attrs := Attributes{"id": g.Id, "externalid": g.Id, "displayname": g.DisplayName}
+	values, displays := referenceValues(g.Members)
+	attrs["members.value"], attrs["members.display"] = values, displays
+	return attrs
+}
+
+func referenceValues(refs []Reference) ([]string, []string) {
+	values := make([]string, 0, len(refs))
+	displays := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		values = append(values, ref.Value)
+		displays = append(displays, ref.Display)
+	}
+	return values, displays
+}
+
+// ListQuery holds the filter and the 1-based pagination of a list request.
+type ListQuery struct {
+	Filter     string
+	StartIndex int
+	Count      int
+}
+
+// NewListQuery parses the startIndex and count query parameters. A missing
+// startIndex starts at the first resource and a missing count returns up to
+// MaxResults resources.
+func NewListQuery(filter, startIndex, count string) (ListQuery, error) {
+	query := ListQuery{Filter: filter, StartIndex: 1, Count: MaxResults}
+	if startIndex != "" {
+		value, err := strconv.Atoi(startIndex)
+		if err != nil {
+			return query, InvalidValue("startIndex must be an integer")
+		}
+		query.StartIndex = max(value, 1)
+	}
+	if count != "" {
+		value, err := strconv.Atoi(count)
+		if err != nil {
+			return query, InvalidValue("count must be an integer")
+		}
+		query.Count = min(max(value, 0), MaxResults)
+	}
+	return query, nil
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// Page filters resources and returns the page of query.
+func Page[R interface{ Attributes() Attributes }](resources []R, query ListQuery, allowed []string) (*ListResponse, error) {
+	matched := resources
+	if query.Filter != "" {
+		filter, err := ParseFilter(query.Filter, allowed...)
+		if err != nil {
+			return nil, err
+		}
+		matched = nil
+		for _, resource := range resources {
+			if filter.Match(resource.Attributes()) {
+				matched = append(matched, resource)
+			}
+		}
+	}
+
+	start := min(query.StartIndex-1, len(matched))
+	end := min(start+query.Count, len(matched))
+	return NewListResponse(matched[start:end], len(matched), query), nil
+}
+
+// NewListResponse wraps a page selected elsewhere; total counts every resource
+// matching the query.
+func NewListResponse[R any](page []R, total int, query ListQuery) *ListResponse {
+	response := &ListResponse{
+		Schemas:      []string{ListResponseSchema},
+		TotalResults: total,
+		StartIndex:   query.StartIndex,
+		ItemsPerPage: len(page),
+		Resources:    make([]any, 0, len(page)),
+	}
+	for _, resource := range page {
+		response.Resources = append(response.Resources, resource)
+	}
+	return response
+}
+
+// Error is a SCIM error response. Services return it for client errors; the
+// handler renders it with its status.
+type Error struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string {
+	if e.ScimType != "" {
+		return fmt.Sprintf("scim %s: %s", e.ScimType, e.Detail)
+	}
+	return "scim: " + e.Detail
+}
+
+// Body returns the error as a response body, with the status as a string as
+// RFC 7644 requires.
+func (e *Error) Body() map[string]any {
+	body := map[string]any{
+		"schemas": []string{ErrorSchema},
+		"status":  strconv.Itoa(e.Status),
+		"detail":  e.Detail,
+	}
+	if e.ScimType != "" {
+		body["scimType"] = e.ScimType
+	}
+	return body
+}
+
+func NewError(status int, scimType, detail string) *Error {
+	return &Error{Status: status, ScimType: scimType, Detail: detail}
+}
+
+func NotFound(detail string) *Error {
+	return NewError(http.StatusNotFound, "", detail)
+}
+
+func Uniqueness(detail string) *Error {
+	return NewError(http.StatusConflict, "uniqueness", detail)
+}
+
+func InvalidValue(detail string) *Error {
+	return NewError(http.StatusBadRequest, "invalidValue", detail)
+}
+
+func InvalidFilter(detail string) *Error {
+	return NewError(http.StatusBadRequest, "invalidFilter", detail)
+}
+
+func InvalidPath(detail string) *Error {
+	return NewError(http.StatusBadRequest, "invalidPath", detail)
+}
+
+func Mutability(detail string) *Error {
+	return NewError(http.StatusBadRequest, "mutability", detail)
+}
+
+func NoTarget(detail string) *Error {
+	return NewError(http.StatusBadRequest, "noTarget", detail)
+}
+
+func InvalidSyntax(detail string) *Error {
+	return NewError(http.StatusBadRequest, "invalidSyntax", detail)
+}
+
+// ServiceProviderConfig describes the supported protocol features.
+func ServiceProviderConfig() map[string]any {
+	unsupported := map[string]any{"supported": false}
+	return map[string]any{
+		"schemas":        []string{ServiceProviderConfigSchema},
+		"patch":          map[string]any{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": MaxResults},
+		"changePassword": unsupported,
+		"sort":           unsupported,
+		"etag":           unsupported,
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "Token issued by cmd/token for an admin user",
+		}},
+	}
+}
@@ -0,0 +1,81 @@
+package directory
+
+import (
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Source reads a snapshot of the directory. *ldap.Directory implements it.
+type Source interface {
+	Read(ctx context.Context) (*models.Directory, error)
+}
+
+// Worker periodically syncs users and teams with an external directory.
+type Worker struct {
+	source      Source
+	teamStorage storage.Team
+	interval    time.Duration
+	// adoptUsers lets the sync take over users created by hand whose id
+	// appears in the directory.
+	adoptUsers bool
+	log        logger.Logger
+}
+
+func NewWorker(source Source, teamStorage storage.Team, interval time.Duration, adoptUsers bool, log logger.Logger) *Worker {
+	return &Worker{
+		source:      source,
+		teamStorage: teamStorage,
+		interval:    interval,
+		adoptUsers:  adoptUsers,
+		log:         log,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	w.log.Info("Starting directory sync worker", "interval", w.interval.String())
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.SyncOnce(ctx); err != nil {
+			w.log.Error("Directory sync failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("Directory sync worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SyncOnce reads the directory and applies it. An empty directory is refused:
+// it far more likely means a wrong base DN or filter than that everyone left,
+// and applying it would deactivate every synced user.
+func (w *Worker) SyncOnce(ctx context.Context) (*models.ImportResult, error) {
+	dir, err := w.source.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	if len(dir.Users) == 0 {
+		return nil, errors.New("directory has no users")
+	}
+
+	result, err := w.teamStorage.SyncDirectory(ctx, dir, w.adoptUsers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync directory: %w", err)
+	}
+
+	if len(result.Rows) > 0 {
+		w.log.Info("Directory sync finished", "users", len(dir.Users), "groups", len(dir.Groups), "changes", len(result.Rows))
+	}
+
+	return result, nil
+}
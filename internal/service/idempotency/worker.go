@@ -0,0 +1,62 @@
+package idempotency
+
+import (
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/storage"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Worker periodically deletes idempotency keys whose responses are no longer
+// replayed.
+type Worker struct {
+	storage  storage.Idempotency
+	ttl      time.Duration
+	interval time.Duration
+	log      logger.Logger
+}
+
+func NewWorker(storage storage.Idempotency, ttl, interval time.Duration, log logger.Logger) *Worker {
+	return &Worker{
+		storage:  storage,
+		ttl:      ttl,
+		interval: interval,
+		log:      log,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	w.log.Info("Starting idempotency key purge worker", "interval", w.interval.String(), "ttl", w.ttl.String())
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.PurgeOnce(ctx); err != nil {
+			w.log.Error("Idempotency key purge failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("Idempotency key purge worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce deletes the keys older than the TTL and returns how many were
+// deleted.
+func (w *Worker) PurgeOnce(ctx context.Context) (int64, error) {
+	purged, err := w.storage.PurgeIdempotencyKeys(ctx, w.ttl)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+
+	if purged > 0 {
+		w.log.Info("Idempotency key purge finished", "purged", purged)
+	}
+
+	return purged, nil
+}
@@ -7,8 +7,14 @@ import (
 
 type PullRequest interface {
 	CreatePullRequest(ctx context.Context, pr *models.PullRequest) (*models.PullRequest, error)
-	MergePullRequest(ctx context.Context, prID string) error
-	ReassignReviewer(ctx context.Context, prID, oldUserID string) error
-	GetPullRequestsByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequestShort, error)
+	CreateOrGetPullRequest(ctx context.Context, pr *models.PullRequest) (*models.PullRequest, bool, error)
+	GetPullRequest(ctx context.Context, repository, prID string) (*models.PullRequest, error)
+	MergePullRequest(ctx context.Context, repository, prID string, force bool, version int64) (int64, error)
+	ReassignReviewer(ctx context.Context, repository, prID, oldUserID string, version int64) (int64, error)
+	SubmitReview(ctx context.Context, repository, prID, reviewerID string, verdict models.ReviewVerdict, version int64) (int64, error)
+	GetPullRequestsByReviewer(ctx context.Context, reviewerID string, query *models.PullRequestQuery) (*models.PullRequestPage, error)
+	SearchPullRequests(ctx context.Context, query *models.PullRequestQuery) (*models.PullRequestPage, error)
 	GetReviewStatistics(ctx context.Context) (*models.ReviewStatistics, error)
+	GetStalePullRequests(ctx context.Context) ([]*models.PullRequest, error)
+	GetPullRequestEvents(ctx context.Context, repository, prID string) ([]*models.PullRequestEvent, error)
 }
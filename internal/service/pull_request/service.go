@@ -1,19 +1,34 @@
 package pull_request
 
 import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/auth"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"avito-autumn-2025/internal/storage"
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"time"
 )
 
+const (
+	defaultReviewersCount = 2
+	// Pull requests that reach one of the large size thresholds get one
+	// reviewer more than the base count.
+	largeChangedLines = 500
+	largeChangedFiles = 20
+	// urgentMaxOpenReviews is the number of open reviews from which a reviewer
+	// is no longer considered available for urgent pull requests.
+	urgentMaxOpenReviews = 2
+)
+
 type PullRequestService struct {
 	prStorage   storage.PullRequest
 	userStorage storage.User
 	teamStorage storage.Team
+	repoStorage storage.Repository
 	log         logger.Logger
 }
 
@@ -21,18 +36,20 @@ func NewPullRequestService(
 	prStorage storage.PullRequest,
 	userStorage storage.User,
 	teamStorage storage.Team,
+	repoStorage storage.Repository,
 	log logger.Logger,
 ) *PullRequestService {
 	return &PullRequestService{
 		prStorage:   prStorage,
 		userStorage: userStorage,
 		teamStorage: teamStorage,
+		repoStorage: repoStorage,
 		log:         log,
 	}
 }
 
 func (s *PullRequestService) CreatePullRequest(ctx context.Context, pr *models.PullRequest) (*models.PullRequest, error) {
-	s.log.Info("Creating pull request", "pr_id", pr.PullRequestId, "author_id", pr.AuthorId)
+	s.log.Info("Creating pull request", "repository", pr.Repository, "pr_id", pr.PullRequestId, "author_id", pr.AuthorId)
 
 	author, err := s.userStorage.GetUserByID(ctx, pr.AuthorId)
 	if err != nil {
@@ -41,21 +58,44 @@ func (s *PullRequestService) CreatePullRequest(ctx context.Context, pr *models.P
 	}
 	if author == nil {
 		s.log.Warn("Author not found", "author_id", pr.AuthorId)
-		return nil, fmt.Errorf("author %s not found", pr.AuthorId)
+		return nil, apperr.NotFound(apperr.CodeUserNotFound, "author %s not found", pr.AuthorId)
 	}
 
 	if !author.IsActive {
 		s.log.Warn("Author is not active", "author_id", pr.AuthorId)
-		return nil, fmt.Errorf("author %s is not active", pr.AuthorId)
+		return nil, apperr.PreconditionFailed(apperr.CodeAuthorInactive, "author %s is not active", pr.AuthorId)
+	}
+
+	var repo *models.Repository
+	if pr.Repository != "" {
+		repo, err = s.repoStorage.GetRepository(ctx, pr.Repository)
+		if err != nil {
+			s.log.Error("Failed to get repository", "error", err, "repository", pr.Repository)
+			return nil, fmt.Errorf("failed to get repository: %w", err)
+		}
+		if repo == nil {
+			s.log.Warn("Repository not found", "repository", pr.Repository)
+			return nil, apperr.NotFound(apperr.CodeRepositoryNotFound, "repository %s not found", pr.Repository)
+		}
 	}
 
-	teamMembers, err := s.prStorage.GetActiveTeamMembers(ctx, author.TeamName, author.Id)
+	teamName, err := s.pullRequestTeam(ctx, pr, repo, author)
 	if err != nil {
-		s.log.Error("Failed to get team members", "error", err, "team_name", author.TeamName)
-		return nil, fmt.Errorf("failed to get team members: %w", err)
+		return nil, err
 	}
+	pr.TeamName = teamName
 
-	reviewers := s.selectReviewers(teamMembers, 2)
+	candidates, err := s.reviewerPool(ctx, pr, repo, author, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	baseCount := defaultReviewersCount
+	if repo != nil && repo.ReviewersCount > 0 {
+		baseCount = repo.ReviewersCount
+	}
+
+	reviewers := s.selectReviewers(candidates, reviewersCount(pr, baseCount))
 
 	err = s.prStorage.CreatePullRequest(ctx, pr, reviewers)
 	if err != nil {
@@ -69,40 +109,146 @@ func (s *PullRequestService) CreatePullRequest(ctx context.Context, pr *models.P
 	return pr, nil
 }
 
-func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string) error {
-	s.log.Info("Merging pull request", "pr_id", prID)
+// CreateOrGetPullRequest creates pr unless a pull request with its id already
+// exists in the repository, in which case that one is returned unchanged.
+// Webhooks retry deliveries, and a retry must neither fail nor reassign
+// reviewers. It reports whether the pull request was created.
+func (s *PullRequestService) CreateOrGetPullRequest(ctx context.Context, pr *models.PullRequest) (*models.PullRequest, bool, error) {
+	existing, err := s.existingPullRequest(ctx, pr)
+	if err != nil || existing != nil {
+		return existing, false, err
+	}
+
+	created, err := s.CreatePullRequest(ctx, pr)
+	if apperr.Code(err) == apperr.CodePullRequestExists {
+		// Another delivery created it in the meantime
+		existing, err = s.existingPullRequest(ctx, pr)
+		return existing, false, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return created, true, nil
+}
+
+// existingPullRequest returns the pull request with the id of pr, or nil if
+// there is none. A pull request of another author is a conflict rather than a
+// retry.
+func (s *PullRequestService) existingPullRequest(ctx context.Context, pr *models.PullRequest) (*models.PullRequest, error) {
+	existing, err := s.prStorage.GetPullRequest(ctx, pr.Repository, pr.PullRequestId)
+	if errors.Is(err, apperr.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		s.log.Error("Failed to get existing pull request", "error", err, "pr_id", pr.PullRequestId)
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
 
-	err := s.prStorage.MergePullRequest(ctx, prID)
+	if existing.AuthorId != pr.AuthorId {
+		s.log.Warn("Pull request exists with another author", "pr_id", pr.PullRequestId, "author_id", existing.AuthorId)
+		return nil, apperr.Conflict(apperr.CodePullRequestExists, "pull request %s already exists with author %s", pr.PullRequestId, existing.AuthorId)
+	}
+
+	s.log.Info("Returning existing pull request", "repository", pr.Repository, "pr_id", pr.PullRequestId)
+	return existing, nil
+}
+
+func (s *PullRequestService) GetPullRequest(ctx context.Context, repository, prID string) (*models.PullRequest, error) {
+	s.log.Debug("Getting pull request", "repository", repository, "pr_id", prID)
+
+	pr, err := s.prStorage.GetPullRequest(ctx, repository, prID)
+	if err != nil {
+		s.log.Error("Failed to get pull request", "error", err, "pr_id", prID)
+		return nil, fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	s.log.Debug("Successfully retrieved pull request", "pr_id", prID)
+	return pr, nil
+}
+
+func (s *PullRequestService) GetPullRequestEvents(ctx context.Context, repository, prID string) ([]*models.PullRequestEvent, error) {
+	s.log.Debug("Getting pull request events", "repository", repository, "pr_id", prID)
+
+	events, err := s.prStorage.GetPullRequestEvents(ctx, repository, prID)
+	if err != nil {
+		s.log.Error("Failed to get pull request events", "error", err, "pr_id", prID)
+		return nil, fmt.Errorf("failed to get pull request events: %w", err)
+	}
+
+	s.log.Debug("Successfully retrieved pull request events", "pr_id", prID, "count", len(events))
+	return events, nil
+}
+
+// MergePullRequest merges the pull request and returns its new version.
+// Requested changes block the merge unless force is set, which is reserved for
+// leads of the pull request's team. A non-zero version must be the current one.
+func (s *PullRequestService) MergePullRequest(ctx context.Context, repository, prID string, force bool, version int64) (int64, error) {
+	s.log.Info("Merging pull request", "repository", repository, "pr_id", prID, "force", force)
+
+	if force {
+		if err := s.requireTeamLead(ctx, repository, prID); err != nil {
+			return 0, err
+		}
+	}
+
+	newVersion, err := s.prStorage.MergePullRequest(ctx, repository, prID, force, version)
 	if err != nil {
 		s.log.Error("Failed to merge pull request", "error", err, "pr_id", prID)
-		return fmt.Errorf("failed to merge pull request: %w", err)
+		return 0, fmt.Errorf("failed to merge pull request: %w", err)
 	}
 
 	s.log.Info("Successfully merged pull request", "pr_id", prID)
-	return nil
+	return newVersion, nil
 }
 
-func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldUserID string) error {
-	s.log.Info("Reassigning reviewer", "pr_id", prID, "old_reviewer", oldUserID)
+// ReassignReviewer replaces oldUserID on the pull request and returns its new
+// version. A non-zero version must be the current one.
+func (s *PullRequestService) ReassignReviewer(ctx context.Context, repository, prID, oldUserID string, version int64) (int64, error) {
+	s.log.Info("Reassigning reviewer", "repository", repository, "pr_id", prID, "old_reviewer", oldUserID)
+
+	if err := s.requireTeamLead(ctx, repository, prID); err != nil {
+		return 0, err
+	}
 
 	oldReviewer, err := s.userStorage.GetUserByID(ctx, oldUserID)
 	if err != nil {
 		s.log.Error("Failed to get old reviewer", "error", err, "reviewer_id", oldUserID)
-		return fmt.Errorf("failed to get old reviewer: %w", err)
+		return 0, fmt.Errorf("failed to get old reviewer: %w", err)
 	}
 	if oldReviewer == nil {
 		s.log.Warn("Old reviewer not found", "reviewer_id", oldUserID)
-		return fmt.Errorf("reviewer %s not found", oldUserID)
+		return 0, apperr.NotFound(apperr.CodeUserNotFound, "reviewer %s not found", oldUserID)
 	}
 
-	err = s.prStorage.ReassignReviewer(ctx, prID, oldUserID)
+	newVersion, err := s.prStorage.ReassignReviewer(ctx, repository, prID, oldUserID, version)
 	if err != nil {
 		s.log.Error("Failed to reassign reviewer", "error", err, "pr_id", prID)
-		return fmt.Errorf("failed to reassign reviewer: %w", err)
+		return 0, fmt.Errorf("failed to reassign reviewer: %w", err)
 	}
 
 	s.log.Info("Successfully reassigned reviewer", "pr_id", prID, "old_reviewer", oldUserID)
-	return nil
+	return newVersion, nil
+}
+
+// SubmitReview records the verdict and returns the new version of the pull
+// request. A non-zero version must be the current one. Callers submit their
+// own reviews; admins may submit on behalf of any reviewer.
+func (s *PullRequestService) SubmitReview(ctx context.Context, repository, prID, reviewerID string, verdict models.ReviewVerdict, version int64) (int64, error) {
+	s.log.Info("Submitting review", "repository", repository, "pr_id", prID, "reviewer_id", reviewerID, "verdict", verdict)
+
+	if err := auth.RequireSelf(ctx, reviewerID); err != nil {
+		s.log.Warn("Permission denied", "error", err, "pr_id", prID, "reviewer_id", reviewerID)
+		return 0, err
+	}
+
+	newVersion, err := s.prStorage.SubmitReview(ctx, repository, prID, reviewerID, verdict, version)
+	if err != nil {
+		s.log.Error("Failed to submit review", "error", err, "pr_id", prID, "reviewer_id", reviewerID)
+		return 0, fmt.Errorf("failed to submit review: %w", err)
+	}
+
+	s.log.Info("Successfully submitted review", "pr_id", prID, "reviewer_id", reviewerID)
+	return newVersion, nil
 }
 
 func (s *PullRequestService) GetReviewStatistics(ctx context.Context) (*models.ReviewStatistics, error) {
@@ -118,17 +264,152 @@ func (s *PullRequestService) GetReviewStatistics(ctx context.Context) (*models.R
 	return stats, nil
 }
 
-func (s *PullRequestService) GetPullRequestsByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequestShort, error) {
+func (s *PullRequestService) GetStalePullRequests(ctx context.Context) ([]*models.PullRequest, error) {
+	s.log.Debug("Getting stale pull requests")
+
+	prs, err := s.prStorage.GetStalePullRequests(ctx)
+	if err != nil {
+		s.log.Error("Failed to get stale pull requests", "error", err)
+		return nil, fmt.Errorf("failed to get stale pull requests: %w", err)
+	}
+
+	s.log.Debug("Successfully retrieved stale pull requests", "count", len(prs))
+	return prs, nil
+}
+
+// GetPullRequestsByReviewer returns one page of the pull requests reviewerID is
+// assigned to that match query.
+func (s *PullRequestService) GetPullRequestsByReviewer(ctx context.Context, reviewerID string, query *models.PullRequestQuery) (*models.PullRequestPage, error) {
 	s.log.Debug("Getting pull requests by reviewer", "reviewer_id", reviewerID)
 
-	prs, err := s.prStorage.GetPullRequestsByReviewer(ctx, reviewerID)
+	query.ReviewerId = reviewerID
+	page, err := s.prStorage.SearchPullRequests(ctx, query)
 	if err != nil {
 		s.log.Error("Failed to get pull requests by reviewer", "error", err, "reviewer_id", reviewerID)
 		return nil, fmt.Errorf("failed to get pull requests by reviewer: %w", err)
 	}
 
-	s.log.Debug("Successfully retrieved pull requests by reviewer", "reviewer_id", reviewerID, "count", len(prs))
-	return prs, nil
+	s.log.Debug("Successfully retrieved pull requests by reviewer", "reviewer_id", reviewerID, "count", len(page.PullRequests))
+	return page, nil
+}
+
+func (s *PullRequestService) SearchPullRequests(ctx context.Context, query *models.PullRequestQuery) (*models.PullRequestPage, error) {
+	s.log.Debug("Searching pull requests")
+
+	page, err := s.prStorage.SearchPullRequests(ctx, query)
+	if err != nil {
+		s.log.Error("Failed to search pull requests", "error", err)
+		return nil, fmt.Errorf("failed to search pull requests: %w", err)
+	}
+
+	s.log.Debug("Successfully searched pull requests", "count", len(page.PullRequests))
+	return page, nil
+}
+
+// reviewersCount decides how many reviewers a pull request needs based on its size.
+func reviewersCount(pr *models.PullRequest, baseCount int) int {
+	if pr.ChangedLines() >= largeChangedLines || pr.ChangedFiles >= largeChangedFiles {
+		return baseCount + 1
+	}
+	return baseCount
+}
+
+// pullRequestTeam decides which team the pull request belongs to: the team given
+// on the request, the repository's team, or the author's primary team.
+func (s *PullRequestService) pullRequestTeam(ctx context.Context, pr *models.PullRequest, repo *models.Repository, author *models.User) (string, error) {
+	if pr.TeamName != "" {
+		if _, err := s.teamStorage.GetTeamWithMembers(ctx, pr.TeamName); err != nil {
+			s.log.Error("Failed to get pull request team", "error", err, "team_name", pr.TeamName)
+			return "", err
+		}
+		return pr.TeamName, nil
+	}
+	if repo != nil && repo.TeamName != "" {
+		return repo.TeamName, nil
+	}
+	return author.TeamName, nil
+}
+
+// reviewerPool returns the users the reviewers are picked from: the available
+// members of the repository reviewer pool when one is configured, otherwise of
+// the pull request's team. An exhausted pool falls back to that team if the
+// repository allows it, and an exhausted team to its ancestors, nearest first.
+func (s *PullRequestService) reviewerPool(ctx context.Context, pr *models.PullRequest, repo *models.Repository, author *models.User, teamName string) ([]*models.User, error) {
+	if repo != nil && len(repo.Reviewers) > 0 {
+		members, err := s.repoStorage.GetActivePoolMembers(ctx, repo.Name, author.Id)
+		if err != nil {
+			s.log.Error("Failed to get repository pool members", "error", err, "repository", repo.Name)
+			return nil, fmt.Errorf("failed to get repository pool members: %w", err)
+		}
+		candidates, err := s.reviewerCandidates(ctx, pr, members)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) > 0 || !repo.FallbackToAuthorTeam {
+			return candidates, nil
+		}
+		s.log.Info("Repository pool has no available reviewers, falling back to team", "repository", repo.Name, "team_name", teamName)
+	}
+
+	if teamName == "" {
+		return []*models.User{}, nil
+	}
+
+	ancestors, err := s.teamStorage.GetTeamAncestors(ctx, teamName)
+	if err != nil {
+		s.log.Error("Failed to get team ancestors", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get team ancestors: %w", err)
+	}
+
+	var candidates []*models.User
+	for _, team := range append([]string{teamName}, ancestors...) {
+		members, err := s.prStorage.GetActiveTeamMembers(ctx, team, author.Id)
+		if err != nil {
+			s.log.Error("Failed to get team members", "error", err, "team_name", team)
+			return nil, fmt.Errorf("failed to get team members: %w", err)
+		}
+		candidates, err = s.reviewerCandidates(ctx, pr, members)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) > 0 {
+			if team != teamName {
+				s.log.Info("Team has no available reviewers, falling back to parent team", "team_name", teamName, "fallback_team", team)
+			}
+			break
+		}
+	}
+	return candidates, nil
+}
+
+// reviewerCandidates narrows the team down to the members that may review the
+// pull request. Urgent pull requests only go to members whose open review load
+// is below urgentMaxOpenReviews.
+func (s *PullRequestService) reviewerCandidates(ctx context.Context, pr *models.PullRequest, members []*models.User) ([]*models.User, error) {
+	if pr.Priority != models.URGENT || len(members) == 0 {
+		return members, nil
+	}
+
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member.Id
+	}
+
+	counts, err := s.prStorage.GetOpenReviewCounts(ctx, ids)
+	if err != nil {
+		s.log.Error("Failed to get open review counts", "error", err, "pr_id", pr.PullRequestId)
+		return nil, fmt.Errorf("failed to get open review counts: %w", err)
+	}
+
+	available := make([]*models.User, 0, len(members))
+	for _, member := range members {
+		if counts[member.Id] < urgentMaxOpenReviews {
+			available = append(available, member)
+		}
+	}
+
+	s.log.Debug("Filtered available reviewers for urgent pull request", "pr_id", pr.PullRequestId, "members", len(members), "available", len(available))
+	return available, nil
 }
 
 func (s *PullRequestService) selectReviewers(members []*models.User, maxCount int) []string {
@@ -154,3 +435,23 @@ func (s *PullRequestService) selectReviewers(members []*models.User, maxCount in
 
 	return reviewers
 }
+
+// requireTeamLead checks that the authenticated caller, if any, leads the team
+// of the pull request.
+func (s *PullRequestService) requireTeamLead(ctx context.Context, repository, prID string) error {
+	if _, ok := auth.FromContext(ctx); !ok {
+		return nil
+	}
+
+	pr, err := s.prStorage.GetPullRequest(ctx, repository, prID)
+	if err != nil {
+		s.log.Error("Failed to get pull request", "error", err, "pr_id", prID)
+		return fmt.Errorf("failed to get pull request: %w", err)
+	}
+
+	if err := auth.RequireTeamLead(ctx, s.teamStorage, pr.TeamName); err != nil {
+		s.log.Warn("Permission denied", "error", err, "pr_id", prID, "team_name", pr.TeamName)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package service
+
+import (
+	"avito-autumn-2025/internal/models"
+	"context"
+)
+
+type Repository interface {
+	SetRepository(ctx context.Context, repo *models.Repository) (*models.Repository, error)
+	GetRepository(ctx context.Context, name string) (*models.Repository, error)
+}
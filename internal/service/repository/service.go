@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"avito-autumn-2025/internal/auth"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage"
+	"context"
+)
+
+type Service struct {
+	storage storage.Repository
+	teams   storage.Team
+	log     logger.Logger
+}
+
+func NewRepositoryService(r storage.Repository, teams storage.Team, log logger.Logger) Service {
+	return Service{storage: r, teams: teams, log: log}
+}
+
+// SetRepository creates or replaces the repository settings. The caller must
+// lead the repository's current team and the team it is moved to; repositories
+// without a team are configured by admins only.
+func (s *Service) SetRepository(ctx context.Context, repo *models.Repository) (*models.Repository, error) {
+	s.log.Info("Setting repository in service", "repository", repo.Name, "reviewers_count", len(repo.Reviewers))
+
+	current, err := s.storage.GetRepository(ctx, repo.Name)
+	if err != nil {
+		s.log.Error("Failed to get repository in service", "error", err, "repository", repo.Name)
+		return nil, err
+	}
+	if current != nil && current.TeamName != repo.TeamName {
+		if err := s.requireLead(ctx, current.TeamName); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.requireLead(ctx, repo.TeamName); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.storage.UpsertRepository(ctx, repo)
+	if err != nil {
+		s.log.Error("Failed to set repository in service", "error", err, "repository", repo.Name)
+		return nil, err
+	}
+
+	s.log.Info("Successfully set repository in service", "repository", updated.Name)
+	return updated, nil
+}
+
+func (s *Service) GetRepository(ctx context.Context, name string) (*models.Repository, error) {
+	s.log.Debug("Getting repository in service", "repository", name)
+
+	repo, err := s.storage.GetRepository(ctx, name)
+	if err != nil {
+		s.log.Error("Failed to get repository in service", "error", err, "repository", name)
+		return nil, err
+	}
+
+	s.log.Debug("Successfully retrieved repository in service", "repository", name, "found", repo != nil)
+	return repo, nil
+}
+
+// requireLead checks that the authenticated caller, if any, leads the team or
+// one of its ancestors. An empty team name requires an admin.
+func (s *Service) requireLead(ctx context.Context, teamName string) error {
+	if err := auth.RequireTeamLead(ctx, s.teams, teamName); err != nil {
+		s.log.Warn("Permission denied in service", "error", err, "team_name", teamName)
+		return err
+	}
+	return nil
+}
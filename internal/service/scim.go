@@ -0,0 +1,21 @@
+package service
+
+import (
+	"avito-autumn-2025/internal/scim"
+	"context"
+)
+
+type SCIM interface {
+	ListUsers(ctx context.Context, query scim.ListQuery) (*scim.ListResponse, error)
+	GetUser(ctx context.Context, id string) (*scim.User, error)
+	CreateUser(ctx context.Context, user *scim.User) (*scim.User, error)
+	ReplaceUser(ctx context.Context, id string, user *scim.User) (*scim.User, error)
+	PatchUser(ctx context.Context, id string, patch *scim.PatchRequest) (*scim.User, error)
+	DeleteUser(ctx context.Context, id string) error
+	ListGroups(ctx context.Context, query scim.ListQuery) (*scim.ListResponse, error)
+	GetGroup(ctx context.Context, id string) (*scim.Group, error)
+	CreateGroup(ctx context.Context, group *scim.Group) (*scim.Group, error)
+	ReplaceGroup(ctx context.Context, id string, group *scim.Group) (*scim.Group, error)
+	PatchGroup(ctx context.Context, id string, patch *scim.PatchRequest) (*scim.Group, error)
+	DeleteGroup(ctx context.Context, id string) error
+}
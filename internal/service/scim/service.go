@@ -0,0 +1,637 @@
+package scim
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/auth"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/scim"
+	"avito-autumn-2025/internal/storage"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+const (
+	maxIdLength       = 50
+	maxUsernameLength = 100
+)
+
+// Service provisions users and teams for an identity provider speaking SCIM.
+// Every operation requires an admin.
+type Service struct {
+	users storage.User
+	teams storage.Team
+	log   logger.Logger
+}
+
+func NewSCIMService(users storage.User, teams storage.Team, log logger.Logger) Service {
+	return Service{users: users, teams: teams, log: log}
+}
+
+// ListUsers pages through users in the database when there is no filter or
+// the filter is userName eq "...", and filters every user otherwise.
+func (s *Service) ListUsers(ctx context.Context, query scim.ListQuery) (*scim.ListResponse, error) {
+	s.log.Debug("Listing SCIM users in service", "filter", query.Filter, "start_index", query.StartIndex, "count", query.Count)
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	listQuery, ok, err := storageQuery(query, scim.UserAttributes, "userName")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		users, total, err := s.users.SearchUsers(ctx, listQuery)
+		if err != nil {
+			s.log.Error("Failed to search users in service", "error", err)
+			return nil, err
+		}
+		resources := make([]*scim.User, 0, len(users))
+		for _, user := range users {
+			resources = append(resources, scim.FromUser(user))
+		}
+		return scim.NewListResponse(resources, total, query), nil
+	}
+
+	users, err := s.users.ListUsers(ctx)
+	if err != nil {
+		s.log.Error("Failed to list users in service", "error", err)
+		return nil, err
+	}
+
+	resources := make([]*scim.User, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, scim.FromUser(user))
+	}
+	return scim.Page(resources, query, scim.UserAttributes)
+}
+
+func (s *Service) GetUser(ctx context.Context, id string) (*scim.User, error) {
+	s.log.Debug("Getting SCIM user in service", "user_id", id)
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.user(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return scim.FromUser(user), nil
+}
+
+// CreateUser creates a user with the id taken from externalId, or from
+// userName when there is none. Users are active unless active is false.
+func (s *Service) CreateUser(ctx context.Context, resource *scim.User) (*scim.User, error) {
+	s.log.Info("Creating SCIM user in service", "user_name", resource.UserName, "external_id", resource.ExternalId)
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	id := resource.ExternalId
+	if id == "" {
+		id = resource.UserName
+	}
+	if err := validateUser(id, resource.UserName); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.users.GetUserByID(ctx, id)
+	if err != nil {
+		s.log.Error("Failed to get user in service", "error", err, "user_id", id)
+		return nil, err
+	}
+	if existing != nil {
+		s.log.Warn("SCIM user already exists", "user_id", id)
+		return nil, scim.Uniqueness(fmt.Sprintf("user %s already exists", id))
+	}
+
+	user := &models.User{Id: id, Username: resource.UserName, IsActive: resource.Active == nil || *resource.Active}
+	if _, err := s.users.CreateUser(ctx, user); err != nil {
+		s.log.Error("Failed to create user in service", "error", err, "user_id", id)
+		return nil, err
+	}
+
+	s.log.Info("Successfully created SCIM user in service", "user_id", id)
+	return s.GetUser(ctx, id)
+}
+
+// ReplaceUser sets userName and active. A missing active means active.
+func (s *Service) ReplaceUser(ctx context.Context, id string, resource *scim.User) (*scim.User, error) {
+	s.log.Info("Replacing SCIM user in service", "user_id", id)
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.user(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateUser(id, resource.UserName); err != nil {
+		return nil, err
+	}
+
+	active := resource.Active == nil || *resource.Active
+	if err := s.applyUser(ctx, user, resource.UserName, active); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, id)
+}
+
+// PatchUser applies PATCH operations to userName and active. Attributes the
+// service does not store are ignored, so that identity providers can send
+// their full attribute mapping.
+func (s *Service) PatchUser(ctx context.Context, id string, patch *scim.PatchRequest) (*scim.User, error) {
+	s.log.Info("Patching SCIM user in service", "user_id", id, "operations_count", len(patch.Operations))
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := patch.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.user(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	username, active := user.Username, user.IsActive
+	set := func(attr string, raw json.RawMessage) error {
+		var err error
+		switch attr {
+		case "username":
+			username, err = scim.DecodeString(raw)
+		case "active":
+			active, err = scim.DecodeBool(raw)
+		}
+		return err
+	}
+
+	for _, op := range patch.Operations {
+		if op.Op == scim.PatchRemove {
+			attr, _, err := scim.ParsePath(op.Path)
+			if err != nil {
+				return nil, err
+			}
+			if attr == "username" || attr == "active" {
+				return nil, scim.Mutability(fmt.Sprintf("%s cannot be removed", op.Path))
+			}
+			continue
+		}
+
+		if op.Path == "" {
+			values, err := op.ValueObject()
+			if err != nil {
+				return nil, err
+			}
+			for attr, raw := range values {
+				if err := set(attr, raw); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		attr, _, err := scim.ParsePath(op.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := set(attr, op.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateUser(id, username); err != nil {
+		return nil, err
+	}
+	if err := s.applyUser(ctx, user, username, active); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, id)
+}
+
+// DeleteUser deprovisions the user: they are soft-deleted, which deactivates
+// them and reassigns their open reviews.
+func (s *Service) DeleteUser(ctx context.Context, id string) error {
+	s.log.Info("Deleting SCIM user in service", "user_id", id)
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.user(ctx, id); err != nil {
+		return err
+	}
+
+	result, err := s.users.DeleteUser(ctx, id)
+	if err != nil {
+		s.log.Error("Failed to delete user in service", "error", err, "user_id", id)
+		return err
+	}
+
+	s.log.Info("Successfully deleted SCIM user in service", "user_id", id,
+		"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	return nil
+}
+
+// applyUser updates the username and activity. Deactivation reassigns the
+// user's open reviews.
+func (s *Service) applyUser(ctx context.Context, user *models.User, username string, active bool) error {
+	update := &models.UserUpdate{}
+	if username != user.Username {
+		update.Username = &username
+	}
+	if active && !user.IsActive {
+		update.IsActive = &active
+	}
+	if update.Username != nil || update.IsActive != nil {
+		if _, err := s.users.UpdateUser(ctx, user.Id, update); err != nil {
+			s.log.Error("Failed to update user in service", "error", err, "user_id", user.Id)
+			return err
+		}
+	}
+
+	if !active && user.IsActive {
+		result, err := s.users.DeactivateUser(ctx, user.Id)
+		if err != nil {
+			s.log.Error("Failed to deactivate user in service", "error", err, "user_id", user.Id)
+			return err
+		}
+		s.log.Info("Deactivated SCIM user in service", "user_id", user.Id,
+			"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	}
+	return nil
+}
+
+func (s *Service) user(ctx context.Context, id string) (*models.User, error) {
+	user, err := s.users.GetUserByID(ctx, id)
+	if err != nil {
+		s.log.Error("Failed to get user in service", "error", err, "user_id", id)
+		return nil, err
+	}
+	if user == nil {
+		return nil, scim.NotFound(fmt.Sprintf("user %s not found", id))
+	}
+	return user, nil
+}
+
+// ListGroups pages through teams in the database when there is no filter or
+// the filter is displayName eq "..." or id eq "...", and filters every team
+// otherwise.
+func (s *Service) ListGroups(ctx context.Context, query scim.ListQuery) (*scim.ListResponse, error) {
+	s.log.Debug("Listing SCIM groups in service", "filter", query.Filter, "start_index", query.StartIndex, "count", query.Count)
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	listQuery, ok, err := storageQuery(query, scim.GroupAttributes, "displayName", "id")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		teams, total, err := s.teams.SearchTeams(ctx, listQuery)
+		if err != nil {
+			s.log.Error("Failed to search teams in service", "error", err)
+			return nil, err
+		}
+		resources := make([]*scim.Group, 0, len(teams))
+		for _, team := range teams {
+			resources = append(resources, scim.FromTeam(team))
+		}
+		return scim.NewListResponse(resources, total, query), nil
+	}
+
+	teams, err := s.teams.ListTeams(ctx)
+	if err != nil {
+		s.log.Error("Failed to list teams in service", "error", err)
+		return nil, err
+	}
+
+	resources := make([]*scim.Group, 0, len(teams))
+	for _, team := range teams {
+		resources = append(resources, scim.FromTeam(team))
+	}
+	return scim.Page(resources, query, scim.GroupAttributes)
+}
+
+func (s *Service) GetGroup(ctx context.Context, id string) (*scim.Group, error) {
+	s.log.Debug("Getting SCIM group in service", "team_name", id)
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	team, err := s.team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return scim.FromTeam(team), nil
+}
+
+// CreateGroup creates a top-level team. Members must already exist.
+func (s *Service) CreateGroup(ctx context.Context, resource *scim.Group) (*scim.Group, error) {
+	s.log.Info("Creating SCIM group in service", "display_name", resource.DisplayName, "members_count", len(resource.Members))
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateGroupName(resource.DisplayName); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.teams.GetTeamsByNames(ctx, []string{resource.DisplayName})
+	if err != nil {
+		s.log.Error("Failed to get team in service", "error", err, "team_name", resource.DisplayName)
+		return nil, err
+	}
+	if len(existing) > 0 {
+		s.log.Warn("SCIM group already exists", "team_name", resource.DisplayName)
+		return nil, scim.Uniqueness(fmt.Sprintf("group %s already exists", resource.DisplayName))
+	}
+
+	members, err := s.members(ctx, resource.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.teams.CreateTeam(ctx, &models.Team{Name: resource.DisplayName, Users: members}); err != nil {
+		s.log.Error("Failed to create team in service", "error", err, "team_name", resource.DisplayName)
+		return nil, err
+	}
+
+	s.log.Info("Successfully created SCIM group in service", "team_name", resource.DisplayName)
+	return s.GetGroup(ctx, resource.DisplayName)
+}
+
+// ReplaceGroup renames the team to displayName and sets its members.
+func (s *Service) ReplaceGroup(ctx context.Context, id string, resource *scim.Group) (*scim.Group, error) {
+	s.log.Info("Replacing SCIM group in service", "team_name", id, "members_count", len(resource.Members))
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	team, err := s.team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGroupName(resource.DisplayName); err != nil {
+		return nil, err
+	}
+
+	desired := make([]string, 0, len(resource.Members))
+	for _, member := range resource.Members {
+		desired = append(desired, member.Value)
+	}
+	return s.applyGroup(ctx, team, resource.DisplayName, desired)
+}
+
+// PatchGroup applies PATCH operations to displayName and members. Members are
+// added with the member role; removing one reassigns their open reviews on the
+// team's pull requests, or unassigns those nobody else can take over.
+func (s *Service) PatchGroup(ctx context.Context, id string, patch *scim.PatchRequest) (*scim.Group, error) {
+	s.log.Info("Patching SCIM group in service", "team_name", id, "operations_count", len(patch.Operations))
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := patch.Validate(); err != nil {
+		return nil, err
+	}
+
+	team, err := s.team(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := team.Name
+	var members []string
+	for _, member := range team.Users {
+		members = append(members, member.Id)
+	}
+
+	apply := func(op, attr string, raw json.RawMessage) error {
+		switch attr {
+		case "displayname":
+			if op == scim.PatchRemove {
+				return scim.Mutability("displayName cannot be removed")
+			}
+			value, err := scim.DecodeString(raw)
+			name = value
+			return err
+		case "members":
+			var refs []scim.Reference
+			if len(raw) > 0 {
+				var err error
+				if refs, err = scim.DecodeReferences(raw); err != nil {
+					return err
+				}
+			}
+			switch op {
+			case scim.PatchAdd:
+				for _, ref := range refs {
+					members = appendMissing(members, ref.Value)
+				}
+			case scim.PatchReplace:
+				members = nil
+				for _, ref := range refs {
+					members = appendMissing(members, ref.Value)
+				}
+			case scim.PatchRemove:
+				if len(refs) == 0 {
+					members = nil
+				}
+				members = slices.DeleteFunc(members, func(member string) bool {
+					return slices.ContainsFunc(refs, func(ref scim.Reference) bool { return ref.Value == member })
+				})
+			}
+		}
+		return nil
+	}
+
+	for _, op := range patch.Operations {
+		if op.Path == "" {
+			values, err := op.ValueObject()
+			if err != nil {
+				return nil, err
+			}
+			for attr, raw := range values {
+				if err := apply(op.Op, attr, raw); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		attr, filter, err := scim.ParsePath(op.Path, "value")
+		if err != nil {
+			return nil, err
+		}
+		if filter != nil {
+			if attr != "members" || op.Op != scim.PatchRemove {
+				return nil, scim.InvalidPath(fmt.Sprintf("unsupported path %s", op.Path))
+			}
+			members = slices.DeleteFunc(members, func(member string) bool {
+				return filter.Match(scim.Attributes{"value": member})
+			})
+			continue
+		}
+		if err := apply(op.Op, attr, op.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateGroupName(name); err != nil {
+		return nil, err
+	}
+	return s.applyGroup(ctx, team, name, members)
+}
+
+// DeleteGroup deletes the team. Open reviews held by its members are moved to
+// reviewers outside the team.
+func (s *Service) DeleteGroup(ctx context.Context, id string) error {
+	s.log.Info("Deleting SCIM group in service", "team_name", id)
+
+	if err := s.requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.team(ctx, id); err != nil {
+		return err
+	}
+
+	reassigned, err := s.teams.DeleteTeam(ctx, id, models.OpenReviewsReassign, 0)
+	if err != nil {
+		s.log.Error("Failed to delete team in service", "error", err, "team_name", id)
+		return err
+	}
+
+	s.log.Info("Successfully deleted SCIM group in service", "team_name", id, "reassigned_reviews", reassigned)
+	return nil
+}
+
+// applyGroup renames the team and makes memberIDs its members in one
+// transaction, so a failing change leaves the group as it was.
+func (s *Service) applyGroup(ctx context.Context, team *models.Team, name string, memberIDs []string) (*scim.Group, error) {
+	current := map[string]*models.User{}
+	for _, member := range team.Users {
+		current[member.Id] = member
+	}
+	var members []*models.User
+	var added []scim.Reference
+	for _, id := range memberIDs {
+		if member, ok := current[id]; ok {
+			members = append(members, member)
+		} else {
+			added = append(added, scim.Reference{Value: id})
+		}
+	}
+	newMembers, err := s.members(ctx, added)
+	if err != nil {
+		return nil, err
+	}
+	members = append(members, newMembers...)
+
+	updated, err := s.teams.ReplaceTeam(ctx, team.Name, name, members, 0)
+	if err != nil {
+		s.log.Error("Failed to replace team in service", "error", err, "team_name", team.Name)
+		return nil, err
+	}
+
+	s.log.Info("Successfully updated SCIM group in service", "team_name", name, "added", len(newMembers))
+	return scim.FromTeam(updated), nil
+}
+
+// members loads the referenced users, which must exist.
+func (s *Service) members(ctx context.Context, refs []scim.Reference) ([]*models.User, error) {
+	var members []*models.User
+	for _, ref := range refs {
+		user, err := s.users.GetUserByID(ctx, ref.Value)
+		if err != nil {
+			s.log.Error("Failed to get user in service", "error", err, "user_id", ref.Value)
+			return nil, err
+		}
+		if user == nil {
+			return nil, scim.InvalidValue(fmt.Sprintf("member %s not found", ref.Value))
+		}
+		members = append(members, user)
+	}
+	return members, nil
+}
+
+func (s *Service) team(ctx context.Context, id string) (*models.Team, error) {
+	team, err := s.teams.GetTeamWithMembers(ctx, id)
+	if apperr.Code(err) == apperr.CodeTeamNotFound {
+		return nil, scim.NotFound(fmt.Sprintf("group %s not found", id))
+	}
+	if err != nil {
+		s.log.Error("Failed to get team in service", "error", err, "team_name", id)
+		return nil, err
+	}
+	return team, nil
+}
+
+// storageQuery translates a list request the storage can answer on its own:
+// one without a filter or with an eq comparison of one of nameAttrs. It
+// reports false for any other filter.
+func storageQuery(query scim.ListQuery, allowed []string, nameAttrs ...string) (*models.ListQuery, bool, error) {
+	listQuery := &models.ListQuery{Offset: query.StartIndex - 1, Limit: query.Count}
+	if query.Filter == "" {
+		return listQuery, true, nil
+	}
+
+	filter, err := scim.ParseFilter(query.Filter, allowed...)
+	if err != nil {
+		return nil, false, err
+	}
+	name, ok := scim.EqualityValue(filter, nameAttrs...)
+	if !ok || name == "" {
+		return nil, false, nil
+	}
+	listQuery.Name = name
+	return listQuery, true, nil
+}
+
+func (s *Service) requireAdmin(ctx context.Context) error {
+	if err := auth.RequireAdmin(ctx); err != nil {
+		s.log.Warn("Permission denied in service", "error", err)
+		return err
+	}
+	return nil
+}
+
+func validateUser(id, username string) error {
+	switch {
+	case username == "":
+		return scim.InvalidValue("userName is required")
+	case len(username) > maxUsernameLength:
+		return scim.InvalidValue(fmt.Sprintf("userName must be at most %d characters", maxUsernameLength))
+	case len(id) > maxIdLength:
+		return scim.InvalidValue(fmt.Sprintf("id must be at most %d characters", maxIdLength))
+	}
+	return nil
+}
+
+func validateGroupName(name string) error {
+	switch {
+	case name == "":
+		return scim.InvalidValue("displayName is required")
+	case len(name) > maxIdLength:
+		return scim.InvalidValue(fmt.Sprintf("displayName must be at most %d characters", maxIdLength))
+	}
+	return nil
+}
+
+func appendMissing(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
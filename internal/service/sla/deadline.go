@@ -0,0 +1,53 @@
+package sla
+
+import "time"
+
+// BusinessHours is the part of Monday to Friday that counts towards an SLA:
+// from Start to End o'clock in Location.
+type BusinessHours struct {
+	Start    int
+	End      int
+	Location *time.Location
+}
+
+// Deadline returns the moment a review assigned at assignedAt breaches an SLA of
+// the given number of hours. With businessHours only the working hours of
+// weekdays count towards the SLA; nil counts every hour.
+func Deadline(assignedAt time.Time, hours int, businessHours *BusinessHours) time.Time {
+	remaining := time.Duration(hours) * time.Hour
+	if businessHours == nil {
+		return assignedAt.Add(remaining)
+	}
+
+	current := assignedAt.In(businessHours.Location)
+	for {
+		year, month, day := current.Date()
+		nextDay := time.Date(year, month, day+1, 0, 0, 0, 0, current.Location())
+		if isWeekend(current) {
+			current = nextDay
+			continue
+		}
+
+		opens := time.Date(year, month, day, businessHours.Start, 0, 0, 0, current.Location())
+		closes := time.Date(year, month, day, businessHours.End, 0, 0, 0, current.Location())
+		if current.Before(opens) {
+			current = opens
+		}
+		if !current.Before(closes) {
+			current = nextDay
+			continue
+		}
+
+		available := closes.Sub(current)
+		if remaining <= available {
+			return current.Add(remaining).In(assignedAt.Location())
+		}
+
+		remaining -= available
+		current = nextDay
+	}
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
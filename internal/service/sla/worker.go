@@ -0,0 +1,101 @@
+package sla
+
+import (
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Worker periodically looks for reviews on open pull requests that missed the
+// first-response SLA of the author's team and reassigns or escalates them
+// according to the team policy.
+type Worker struct {
+	prStorage storage.PullRequest
+	interval  time.Duration
+	log       logger.Logger
+}
+
+func NewWorker(prStorage storage.PullRequest, interval time.Duration, log logger.Logger) *Worker {
+	return &Worker{
+		prStorage: prStorage,
+		interval:  interval,
+		log:       log,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	w.log.Info("Starting SLA worker", "interval", w.interval.String())
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.CheckOnce(ctx); err != nil {
+			w.log.Error("SLA check failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("SLA worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce runs a single SLA pass and returns the actions that were applied.
+func (w *Worker) CheckOnce(ctx context.Context) ([]*models.SLAAction, error) {
+	w.log.Debug("Checking review SLAs")
+
+	pending, err := w.prStorage.GetPendingReviews(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending reviews: %w", err)
+	}
+
+	now := time.Now()
+	var applied []*models.SLAAction
+	for _, review := range pending {
+		deadline := Deadline(review.AssignedAt, review.Policy.FirstResponseHours, w.businessHours(&review.Policy))
+		if now.Before(deadline) {
+			continue
+		}
+
+		action := &models.SLAAction{
+			Repository:    review.Repository,
+			PullRequestId: review.PullRequestId,
+			ReviewerId:    review.ReviewerId,
+			Action:        review.Policy.SLAAction,
+			Deadline:      deadline,
+		}
+		if action.Action == models.SLAActionEscalate {
+			action.NewReviewerId = review.Policy.EscalationReviewerId
+		}
+
+		if err := w.prStorage.ApplySLAAction(ctx, action); err != nil {
+			w.log.Warn("Failed to apply SLA action", "error", err, "pr_id", review.PullRequestId, "reviewer_id", review.ReviewerId, "action", action.Action)
+			continue
+		}
+		applied = append(applied, action)
+	}
+
+	w.log.Debug("Review SLA check finished", "pending", len(pending), "applied", len(applied))
+	return applied, nil
+}
+
+// businessHours returns the working hours of the policy, or nil when every
+// hour counts. Time zones are validated when the policy is saved; an unknown
+// one falls back to UTC.
+func (w *Worker) businessHours(policy *models.TeamPolicy) *BusinessHours {
+	if !policy.BusinessHoursOnly {
+		return nil
+	}
+	location, err := time.LoadLocation(policy.TimeZone)
+	if err != nil {
+		w.log.Warn("Unknown time zone of team policy, using UTC", "error", err, "team_name", policy.TeamName, "time_zone", policy.TimeZone)
+		location = time.UTC
+	}
+	return &BusinessHours{Start: policy.WorkdayStart, End: policy.WorkdayEnd, Location: location}
+}
@@ -0,0 +1,72 @@
+package stale
+
+import (
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage"
+	"context"
+	"fmt"
+	"time"
+)
+
+// Worker periodically marks open pull requests without recent activity as stale
+// and closes the ones that stayed stale past the grace period of the author's team.
+type Worker struct {
+	prStorage storage.PullRequest
+	interval  time.Duration
+	log       logger.Logger
+}
+
+type Result struct {
+	MarkedStale []*models.PullRequestShort
+	Closed      []*models.PullRequestShort
+}
+
+func NewWorker(prStorage storage.PullRequest, interval time.Duration, log logger.Logger) *Worker {
+	return &Worker{
+		prStorage: prStorage,
+		interval:  interval,
+		log:       log,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	w.log.Info("Starting stale pull request worker", "interval", w.interval.String())
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.CheckOnce(ctx); err != nil {
+			w.log.Error("Stale pull request check failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("Stale pull request worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce closes pull requests whose grace period expired and then marks
+// newly inactive ones as stale. Closing runs first so that a pull request is
+// never marked and closed in the same pass.
+func (w *Worker) CheckOnce(ctx context.Context) (*Result, error) {
+	closed, err := w.prStorage.CloseStalePullRequests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close stale pull requests: %w", err)
+	}
+
+	marked, err := w.prStorage.MarkStalePullRequests(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark stale pull requests: %w", err)
+	}
+
+	if len(closed) > 0 || len(marked) > 0 {
+		w.log.Info("Stale pull request check finished", "marked_stale", len(marked), "closed", len(closed))
+	}
+
+	return &Result{MarkedStale: marked, Closed: closed}, nil
+}
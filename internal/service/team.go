@@ -8,4 +8,15 @@ import (
 type Team interface {
 	CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error)
 	GetTeamWithMembers(ctx context.Context, teamName string) (*models.Team, error)
+	ListTeams(ctx context.Context) ([]*models.Team, error)
+	GetTeamWithDescendants(ctx context.Context, teamName string) (*models.Team, error)
+	GetMembershipHistory(ctx context.Context, teamName string) ([]*models.TeamMembershipInterval, error)
+	GetTeamPolicy(ctx context.Context, teamName string) (*models.TeamPolicy, error)
+	SetTeamPolicy(ctx context.Context, policy *models.TeamPolicy) (*models.TeamPolicy, error)
+	UpdateTeam(ctx context.Context, teamName string, update *models.TeamUpdate, version int64) (*models.Team, error)
+	DeleteTeam(ctx context.Context, teamName string, onOpenReviews models.OpenReviewsAction, version int64) (int, error)
+	AddTeamMember(ctx context.Context, teamName string, member *models.User, primary bool, version int64) (*models.Team, error)
+	RemoveTeamMember(ctx context.Context, teamName, userID string, version int64) (int, error)
+	ImportTeams(ctx context.Context, imp *models.Import, dryRun bool) (*models.ImportResult, error)
+	ReconcileTeams(ctx context.Context, imp *models.Import, dryRun bool) (*models.ImportResult, error)
 }
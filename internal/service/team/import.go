@@ -0,0 +1,260 @@
+package team
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/auth"
+	"avito-autumn-2025/internal/models"
+	"context"
+	"fmt"
+)
+
+const (
+	maxNameLength     = 50
+	maxUsernameLength = 100
+)
+
+// ImportTeams validates the whole import up front and applies it in one
+// transaction. An invalid import is reported row by row without touching the
+// database; a dry run reports what would change.
+func (s *Service) ImportTeams(ctx context.Context, imp *models.Import, dryRun bool) (*models.ImportResult, error) {
+	s.log.Info("Importing teams in service", "teams_count", len(imp.Teams), "dry_run", dryRun)
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		s.log.Warn("Permission denied in service", "error", err)
+		return nil, err
+	}
+
+	ordered, rows := validateImport(imp, false)
+	if rows != nil {
+		s.log.Warn("Invalid team import in service", "rows_count", len(rows))
+		return &models.ImportResult{DryRun: dryRun, Rows: rows}, nil
+	}
+
+	result, err := s.storage.ImportTeams(ctx, ordered, dryRun)
+	if err != nil {
+		s.log.Error("Failed to import teams in service", "error", err)
+		return nil, err
+	}
+
+	s.log.Info("Successfully processed team import in service", "applied", result.Applied, "rows_count", len(result.Rows))
+	return result, nil
+}
+
+// ReconcileTeams treats imp as the full desired state of the organization and
+// makes the database match it: entries are applied like an import, and users,
+// memberships, teams and policies missing from it are deactivated or removed.
+func (s *Service) ReconcileTeams(ctx context.Context, imp *models.Import, dryRun bool) (*models.ImportResult, error) {
+	s.log.Info("Reconciling teams in service", "teams_count", len(imp.Teams), "dry_run", dryRun)
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		s.log.Warn("Permission denied in service", "error", err)
+		return nil, err
+	}
+
+	// An empty document would deactivate every user and delete every team.
+	if len(imp.Teams) == 0 {
+		s.log.Warn("Empty reconcile document in service")
+		return nil, apperr.Invalid(apperr.CodeInvalidRequest, "reconcile document lists no teams")
+	}
+
+	ordered, rows := validateImport(imp, true)
+	if rows != nil {
+		s.log.Warn("Invalid reconcile document in service", "rows_count", len(rows))
+		return &models.ImportResult{DryRun: dryRun, Rows: rows}, nil
+	}
+
+	result, err := s.storage.ReconcileTeams(ctx, ordered, dryRun)
+	if err != nil {
+		s.log.Error("Failed to reconcile teams in service", "error", err)
+		return nil, err
+	}
+
+	s.log.Info("Successfully processed team reconciliation in service", "applied", result.Applied, "rows_count", len(result.Rows))
+	return result, nil
+}
+
+// validateImport checks every entry and orders teams after their parents. A
+// full document, when reconcile is set, must also contain every parent team.
+// When anything is invalid it returns nil and the per-row results instead.
+func validateImport(imp *models.Import, reconcile bool) (*models.Import, []*models.ImportRowResult) {
+	var rows []*models.ImportRowResult
+	invalid := false
+	check := func(row *models.ImportRowResult, problems []string) {
+		row.Action = models.ImportSkipped
+		if len(problems) > 0 {
+			row.Action = models.ImportInvalid
+			row.Error = problems[0]
+			for _, problem := range problems[1:] {
+				row.Error += "; " + problem
+			}
+			invalid = true
+		}
+		rows = append(rows, row)
+	}
+
+	teams := map[string]*models.ImportTeam{}
+	primaryTeams := map[string]string{}
+	users := map[string]*models.ImportMember{}
+	for _, team := range imp.Teams {
+		var problems []string
+		switch {
+		case team.Name == "":
+			problems = append(problems, "team_name is required")
+		case len(team.Name) > maxNameLength:
+			problems = append(problems, fmt.Sprintf("team_name must be at most %d characters", maxNameLength))
+		case teams[team.Name] != nil:
+			problems = append(problems, fmt.Sprintf("team %s is listed more than once", team.Name))
+		}
+		if team.ParentTeam != "" && team.ParentTeam == team.Name {
+			problems = append(problems, "parent_team must differ from team_name")
+		}
+		if _, ok := teams[team.Name]; !ok && team.Name != "" {
+			teams[team.Name] = team
+		}
+		check(&models.ImportRowResult{Row: team.Row, Kind: models.ImportRowTeam, TeamName: team.Name}, problems)
+
+		if team.Policy != nil {
+			withDefaultBusinessHours(team.Policy)
+			check(&models.ImportRowResult{Row: team.Row, Kind: models.ImportRowPolicy, TeamName: team.Name}, validatePolicy(team.Policy))
+		}
+
+		members := map[string]bool{}
+		for _, member := range team.Members {
+			var problems []string
+			switch {
+			case member.Id == "":
+				problems = append(problems, "id is required")
+			case len(member.Id) > maxNameLength:
+				problems = append(problems, fmt.Sprintf("id must be at most %d characters", maxNameLength))
+			case members[member.Id]:
+				problems = append(problems, fmt.Sprintf("user %s is listed more than once in team %s", member.Id, team.Name))
+			}
+			if member.Username == "" {
+				problems = append(problems, "username is required")
+			} else if len(member.Username) > maxUsernameLength {
+				problems = append(problems, fmt.Sprintf("username must be at most %d characters", maxUsernameLength))
+			}
+			switch member.Role {
+			case "", models.RoleLead, models.RoleMember, models.RoleObserver:
+			default:
+				problems = append(problems, "role must be one of lead, member, observer")
+			}
+			if other, ok := users[member.Id]; ok && (other.Username != member.Username || conflictingActivity(other.IsActive, member.IsActive)) {
+				problems = append(problems, fmt.Sprintf("user %s is listed with a different username or is_active in another team", member.Id))
+			} else if (!ok || other.IsActive == nil) && member.Id != "" {
+				users[member.Id] = member
+			}
+			if member.IsPrimary && member.Id != "" {
+				if other, ok := primaryTeams[member.Id]; ok && other != team.Name {
+					problems = append(problems, fmt.Sprintf("user %s is already primary in team %s", member.Id, other))
+				}
+				primaryTeams[member.Id] = team.Name
+			}
+			members[member.Id] = true
+			check(&models.ImportRowResult{Row: member.Row, Kind: models.ImportRowMember, TeamName: team.Name, UserId: member.Id}, problems)
+		}
+	}
+
+	if reconcile {
+		for _, row := range rows {
+			if row.Kind != models.ImportRowTeam || row.Error != "" {
+				continue
+			}
+			parent := teams[row.TeamName].ParentTeam
+			if _, ok := teams[parent]; parent != "" && !ok {
+				row.Action = models.ImportInvalid
+				row.Error = fmt.Sprintf("parent_team %s is not in the document", parent)
+				invalid = true
+			}
+		}
+	}
+
+	ordered, cycle := orderTeams(imp.Teams, teams)
+	if cycle != nil {
+		for _, row := range rows {
+			if row.Kind == models.ImportRowTeam && row.TeamName == cycle.Name && row.Error == "" {
+				row.Action = models.ImportInvalid
+				row.Error = fmt.Sprintf("team %s is its own ancestor", cycle.Name)
+			}
+		}
+		invalid = true
+	}
+
+	if invalid {
+		return nil, rows
+	}
+	return &models.Import{Teams: ordered}, nil
+}
+
+// withDefaultBusinessHours fills in the working hours a policy leaves out.
+func withDefaultBusinessHours(policy *models.TeamPolicy) {
+	if policy.WorkdayStart == 0 && policy.WorkdayEnd == 0 {
+		policy.WorkdayStart, policy.WorkdayEnd = models.DefaultWorkdayStart, models.DefaultWorkdayEnd
+	}
+	if policy.TimeZone == "" {
+		policy.TimeZone = models.DefaultTimeZone
+	}
+}
+
+func validatePolicy(policy *models.TeamPolicy) []string {
+	var problems []string
+	if policy.FirstResponseHours <= 0 {
+		problems = append(problems, "first_response_hours must be positive")
+	}
+	if err := policy.ValidateBusinessHours(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if policy.SLAAction != models.SLAActionReassign && policy.SLAAction != models.SLAActionEscalate {
+		problems = append(problems, "sla_action must be one of REASSIGN, ESCALATE")
+	}
+	if policy.StaleAfterDays < 0 || policy.CloseAfterDays < 0 {
+		problems = append(problems, "stale_after_days and close_after_days must not be negative")
+	}
+	return problems
+}
+
+// orderTeams sorts teams so that parents listed in the import come first. It
+// returns a team of a parent cycle, if there is one.
+func orderTeams(teams []*models.ImportTeam, byName map[string]*models.ImportTeam) ([]*models.ImportTeam, *models.ImportTeam) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	ordered := make([]*models.ImportTeam, 0, len(teams))
+
+	var visit func(team *models.ImportTeam) *models.ImportTeam
+	visit = func(team *models.ImportTeam) *models.ImportTeam {
+		switch state[team.Name] {
+		case visiting:
+			return team
+		case done:
+			return nil
+		}
+		state[team.Name] = visiting
+		if parent, ok := byName[team.ParentTeam]; ok && parent != team {
+			if cycle := visit(parent); cycle != nil {
+				return cycle
+			}
+		}
+		state[team.Name] = done
+		ordered = append(ordered, team)
+		return nil
+	}
+
+	for _, team := range teams {
+		if byName[team.Name] != team {
+			continue
+		}
+		if cycle := visit(team); cycle != nil {
+			return nil, cycle
+		}
+	}
+	return ordered, nil
+}
+
+// conflictingActivity reports whether two entries of a user set is_active to
+// different values. An omitted is_active agrees with anything.
+func conflictingActivity(a, b *bool) bool {
+	return a != nil && b != nil && *a != *b
+}
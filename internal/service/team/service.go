@@ -1,6 +1,7 @@
 package team
 
 import (
+	"avito-autumn-2025/internal/auth"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"avito-autumn-2025/internal/storage"
@@ -19,6 +20,16 @@ func NewTeamService(r storage.Team, log logger.Logger) Service {
 func (s *Service) CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error) {
 	s.log.Info("Creating team in service", "team_name", team.Name, "members_count", len(team.Users))
 
+	// Top-level teams are created by admins, subteams by leads of the parent.
+	if team.ParentTeam == "" {
+		if err := auth.RequireAdmin(ctx); err != nil {
+			s.log.Warn("Permission denied in service", "error", err, "team_name", team.Name)
+			return nil, err
+		}
+	} else if err := s.requireLead(ctx, team.ParentTeam); err != nil {
+		return nil, err
+	}
+
 	createdTeam, err := s.storage.CreateTeam(ctx, team)
 	if err != nil {
 		s.log.Error("Failed to create team in service", "error", err, "team_name", team.Name)
@@ -41,3 +52,166 @@ func (s *Service) GetTeamWithMembers(ctx context.Context, teamName string) (*mod
 	s.log.Debug("Successfully retrieved team with members in service", "team_name", teamName, "members_count", len(team.Users))
 	return team, nil
 }
+
+func (s *Service) ListTeams(ctx context.Context) ([]*models.Team, error) {
+	s.log.Debug("Listing teams in service")
+
+	teams, err := s.storage.ListTeams(ctx)
+	if err != nil {
+		s.log.Error("Failed to list teams in service", "error", err)
+		return nil, err
+	}
+
+	s.log.Debug("Successfully listed teams in service", "count", len(teams))
+	return teams, nil
+}
+
+func (s *Service) GetTeamWithDescendants(ctx context.Context, teamName string) (*models.Team, error) {
+	s.log.Debug("Getting team with descendants in service", "team_name", teamName)
+
+	team, err := s.storage.GetTeamWithDescendants(ctx, teamName)
+	if err != nil {
+		s.log.Error("Failed to get team with descendants in service", "error", err, "team_name", teamName)
+		return nil, err
+	}
+
+	s.log.Debug("Successfully retrieved team with descendants in service", "team_name", teamName, "subteams_count", len(team.Subteams))
+	return team, nil
+}
+
+func (s *Service) GetMembershipHistory(ctx context.Context, teamName string) ([]*models.TeamMembershipInterval, error) {
+	s.log.Debug("Getting team membership history in service", "team_name", teamName)
+
+	history, err := s.storage.GetMembershipHistory(ctx, teamName)
+	if err != nil {
+		s.log.Error("Failed to get team membership history in service", "error", err, "team_name", teamName)
+		return nil, err
+	}
+
+	s.log.Debug("Successfully retrieved team membership history in service", "team_name", teamName, "count", len(history))
+	return history, nil
+}
+
+func (s *Service) GetTeamPolicy(ctx context.Context, teamName string) (*models.TeamPolicy, error) {
+	s.log.Debug("Getting team policy in service", "team_name", teamName)
+
+	policy, err := s.storage.GetTeamPolicy(ctx, teamName)
+	if err != nil {
+		s.log.Error("Failed to get team policy in service", "error", err, "team_name", teamName)
+		return nil, err
+	}
+
+	s.log.Debug("Successfully retrieved team policy in service", "team_name", teamName, "found", policy != nil)
+	return policy, nil
+}
+
+func (s *Service) SetTeamPolicy(ctx context.Context, policy *models.TeamPolicy) (*models.TeamPolicy, error) {
+	s.log.Info("Setting team policy in service", "team_name", policy.TeamName)
+
+	if err := s.requireLead(ctx, policy.TeamName); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.storage.GetTeamWithMembers(ctx, policy.TeamName); err != nil {
+		s.log.Error("Failed to get team for policy in service", "error", err, "team_name", policy.TeamName)
+		return nil, err
+	}
+
+	updated, err := s.storage.UpsertTeamPolicy(ctx, policy)
+	if err != nil {
+		s.log.Error("Failed to set team policy in service", "error", err, "team_name", policy.TeamName)
+		return nil, err
+	}
+
+	s.log.Info("Successfully set team policy in service", "team_name", policy.TeamName)
+	return updated, nil
+}
+
+func (s *Service) UpdateTeam(ctx context.Context, teamName string, update *models.TeamUpdate, version int64) (*models.Team, error) {
+	s.log.Info("Updating team in service", "team_name", teamName, "new_team_name", update.Name)
+
+	if err := s.requireLead(ctx, teamName); err != nil {
+		return nil, err
+	}
+	// Moving a team also needs the right to manage its new place in the hierarchy.
+	if update.ParentTeam != nil {
+		if *update.ParentTeam == "" {
+			if err := auth.RequireAdmin(ctx); err != nil {
+				s.log.Warn("Permission denied in service", "error", err, "team_name", teamName)
+				return nil, err
+			}
+		} else if err := s.requireLead(ctx, *update.ParentTeam); err != nil {
+			return nil, err
+		}
+	}
+
+	team, err := s.storage.UpdateTeam(ctx, teamName, update, version)
+	if err != nil {
+		s.log.Error("Failed to update team in service", "error", err, "team_name", teamName)
+		return nil, err
+	}
+
+	s.log.Info("Successfully updated team in service", "team_name", team.Name)
+	return team, nil
+}
+
+func (s *Service) DeleteTeam(ctx context.Context, teamName string, onOpenReviews models.OpenReviewsAction, version int64) (int, error) {
+	s.log.Info("Deleting team in service", "team_name", teamName, "on_open_reviews", onOpenReviews)
+
+	if err := s.requireLead(ctx, teamName); err != nil {
+		return 0, err
+	}
+
+	reassigned, err := s.storage.DeleteTeam(ctx, teamName, onOpenReviews, version)
+	if err != nil {
+		s.log.Error("Failed to delete team in service", "error", err, "team_name", teamName)
+		return 0, err
+	}
+
+	s.log.Info("Successfully deleted team in service", "team_name", teamName, "reassigned_reviews", reassigned)
+	return reassigned, nil
+}
+
+func (s *Service) AddTeamMember(ctx context.Context, teamName string, member *models.User, primary bool, version int64) (*models.Team, error) {
+	s.log.Info("Adding team member in service", "team_name", teamName, "user_id", member.Id, "is_primary", primary)
+
+	if err := s.requireLead(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	team, err := s.storage.AddTeamMember(ctx, teamName, member, primary, version)
+	if err != nil {
+		s.log.Error("Failed to add team member in service", "error", err, "team_name", teamName, "user_id", member.Id)
+		return nil, err
+	}
+
+	s.log.Info("Successfully added team member in service", "team_name", teamName, "user_id", member.Id)
+	return team, nil
+}
+
+func (s *Service) RemoveTeamMember(ctx context.Context, teamName, userID string, version int64) (int, error) {
+	s.log.Info("Removing team member in service", "team_name", teamName, "user_id", userID)
+
+	if err := s.requireLead(ctx, teamName); err != nil {
+		return 0, err
+	}
+
+	reassigned, err := s.storage.RemoveTeamMember(ctx, teamName, userID, version)
+	if err != nil {
+		s.log.Error("Failed to remove team member in service", "error", err, "team_name", teamName, "user_id", userID)
+		return 0, err
+	}
+
+	s.log.Info("Successfully removed team member in service", "team_name", teamName, "user_id", userID, "reassigned_reviews", reassigned)
+	return reassigned, nil
+}
+
+// requireLead checks that the authenticated caller, if any, leads the team or
+// one of its ancestors.
+func (s *Service) requireLead(ctx context.Context, teamName string) error {
+	if err := auth.RequireTeamLead(ctx, s.storage, teamName); err != nil {
+		s.log.Warn("Permission denied in service", "error", err, "team_name", teamName)
+		return err
+	}
+	return nil
+}
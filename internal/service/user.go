@@ -8,4 +8,7 @@ import (
 type User interface {
 	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
 	GetUserByID(ctx context.Context, id string) (*models.User, error)
+	ListUsers(ctx context.Context) ([]*models.User, error)
+	UpdateUser(ctx context.Context, id string, update *models.UserUpdate) (*models.User, error)
+	DeleteUser(ctx context.Context, id string) (*models.ReassignmentResult, error)
 }
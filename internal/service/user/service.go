@@ -1,6 +1,7 @@
 package user
 
 import (
+	"avito-autumn-2025/internal/auth"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"avito-autumn-2025/internal/storage"
@@ -41,3 +42,57 @@ func (s *Service) GetUserByID(ctx context.Context, id string) (*models.User, err
 	s.log.Debug("Successfully retrieved user in service", "user_id", id, "found", user != nil)
 	return user, nil
 }
+
+func (s *Service) ListUsers(ctx context.Context) ([]*models.User, error) {
+	s.log.Debug("Listing users in service")
+
+	users, err := s.storage.ListUsers(ctx)
+	if err != nil {
+		s.log.Error("Failed to list users in service", "error", err)
+		return nil, err
+	}
+
+	s.log.Debug("Successfully listed users in service", "count", len(users))
+	return users, nil
+}
+
+// UpdateUser edits the user. Callers may change their own username and
+// activity; other users and primary teams are managed by admins.
+func (s *Service) UpdateUser(ctx context.Context, id string, update *models.UserUpdate) (*models.User, error) {
+	s.log.Info("Updating user in service", "user_id", id)
+
+	if p, ok := auth.FromContext(ctx); ok && (p.UserID != id || update.TeamName != nil) {
+		if err := auth.RequireAdmin(ctx); err != nil {
+			s.log.Warn("Permission denied in service", "error", err, "user_id", id)
+			return nil, err
+		}
+	}
+
+	user, err := s.storage.UpdateUser(ctx, id, update)
+	if err != nil {
+		s.log.Error("Failed to update user in service", "error", err, "user_id", id)
+		return nil, err
+	}
+
+	s.log.Info("Successfully updated user in service", "user_id", id)
+	return user, nil
+}
+
+func (s *Service) DeleteUser(ctx context.Context, id string) (*models.ReassignmentResult, error) {
+	s.log.Info("Deleting user in service", "user_id", id)
+
+	if err := auth.RequireAdmin(ctx); err != nil {
+		s.log.Warn("Permission denied in service", "error", err, "user_id", id)
+		return nil, err
+	}
+
+	result, err := s.storage.DeleteUser(ctx, id)
+	if err != nil {
+		s.log.Error("Failed to delete user in service", "error", err, "user_id", id)
+		return nil, err
+	}
+
+	s.log.Info("Successfully deleted user in service", "user_id", id,
+		"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	return result, nil
+}
@@ -3,26 +3,76 @@ package storage
 import (
 	"avito-autumn-2025/internal/models"
 	"context"
+	"time"
 )
 
 type User interface {
 	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
 	GetUserByID(ctx context.Context, id string) (*models.User, error)
+	ListUsers(ctx context.Context) ([]*models.User, error)
+	SearchUsers(ctx context.Context, query *models.ListQuery) ([]*models.User, int, error)
+	GetUsersByIDs(ctx context.Context, ids []string) ([]*models.User, error)
 	GetUsersByTeam(ctx context.Context, teamName string) ([]*models.User, error)
 	SetUserActive(ctx context.Context, userID string, isActive bool) error
+	UpdateUser(ctx context.Context, userID string, update *models.UserUpdate) (*models.User, error)
+	DeactivateUser(ctx context.Context, userID string) (*models.ReassignmentResult, error)
+	DeleteUser(ctx context.Context, userID string) (*models.ReassignmentResult, error)
 }
 
 type Team interface {
 	CreateTeam(ctx context.Context, team *models.Team) (*models.Team, error)
 	GetTeamWithMembers(ctx context.Context, teamName string) (*models.Team, error)
+	ListTeams(ctx context.Context) ([]*models.Team, error)
+	SearchTeams(ctx context.Context, query *models.ListQuery) ([]*models.Team, int, error)
+	GetTeamsByNames(ctx context.Context, names []string) ([]*models.Team, error)
+	GetSubteams(ctx context.Context, parentNames []string) ([]*models.Team, error)
+	GetTeamMembers(ctx context.Context, teamNames []string) (map[string][]*models.User, error)
+	GetTeamWithDescendants(ctx context.Context, teamName string) (*models.Team, error)
+	GetTeamAncestors(ctx context.Context, teamName string) ([]string, error)
+	IsTeamLead(ctx context.Context, teamName, userID string) (bool, error)
+	GetMembershipHistory(ctx context.Context, teamName string) ([]*models.TeamMembershipInterval, error)
+	ImportTeams(ctx context.Context, imp *models.Import, dryRun bool) (*models.ImportResult, error)
+	ReconcileTeams(ctx context.Context, imp *models.Import, dryRun bool) (*models.ImportResult, error)
+	SyncDirectory(ctx context.Context, dir *models.Directory, adoptUsers bool) (*models.ImportResult, error)
+	GetTeamPolicy(ctx context.Context, teamName string) (*models.TeamPolicy, error)
+	UpsertTeamPolicy(ctx context.Context, policy *models.TeamPolicy) (*models.TeamPolicy, error)
+	UpdateTeam(ctx context.Context, teamName string, update *models.TeamUpdate, version int64) (*models.Team, error)
+	DeleteTeam(ctx context.Context, teamName string, onOpenReviews models.OpenReviewsAction, version int64) (int, error)
+	AddTeamMember(ctx context.Context, teamName string, member *models.User, primary bool, version int64) (*models.Team, error)
+	RemoveTeamMember(ctx context.Context, teamName, userID string, version int64) (int, error)
+	ReplaceTeam(ctx context.Context, teamName, name string, members []*models.User, version int64) (*models.Team, error)
 }
 
 type PullRequest interface {
 	CreatePullRequest(ctx context.Context, pr *models.PullRequest, reviewers []string) error
-	GetPullRequest(ctx context.Context, prID string) (*models.PullRequest, error)
-	GetPullRequestsByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequestShort, error)
-	MergePullRequest(ctx context.Context, prID string) error
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID string) error
+	GetPullRequest(ctx context.Context, repository, prID string) (*models.PullRequest, error)
+	GetPullRequestsByKeys(ctx context.Context, keys []models.PullRequestKey) ([]*models.PullRequest, error)
+	GetReviewAssignmentsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.ReviewAssignment, error)
+	GetReviewAssignmentsByPullRequests(ctx context.Context, keys []models.PullRequestKey) ([]*models.ReviewAssignment, error)
+	SearchPullRequests(ctx context.Context, query *models.PullRequestQuery) (*models.PullRequestPage, error)
+	MergePullRequest(ctx context.Context, repository, prID string, force bool, version int64) (int64, error)
+	ReassignReviewer(ctx context.Context, repository, prID, oldReviewerID string, version int64) (int64, error)
 	GetActiveTeamMembers(ctx context.Context, teamName string, excludeUser string) ([]*models.User, error)
+	GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error)
 	GetReviewStatistics(ctx context.Context) (*models.ReviewStatistics, error)
+	SubmitReview(ctx context.Context, repository, prID, reviewerID string, verdict models.ReviewVerdict, version int64) (int64, error)
+	GetPendingReviews(ctx context.Context) ([]*models.PendingReview, error)
+	ApplySLAAction(ctx context.Context, action *models.SLAAction) error
+	MarkStalePullRequests(ctx context.Context) ([]*models.PullRequestShort, error)
+	CloseStalePullRequests(ctx context.Context) ([]*models.PullRequestShort, error)
+	GetStalePullRequests(ctx context.Context) ([]*models.PullRequest, error)
+	GetPullRequestEvents(ctx context.Context, repository, prID string) ([]*models.PullRequestEvent, error)
+}
+
+type Repository interface {
+	UpsertRepository(ctx context.Context, repo *models.Repository) (*models.Repository, error)
+	GetRepository(ctx context.Context, name string) (*models.Repository, error)
+	GetActivePoolMembers(ctx context.Context, name string, excludeUser string) ([]*models.User, error)
+}
+
+type Idempotency interface {
+	ReserveIdempotencyKey(ctx context.Context, actorID, key, fingerprint string, ttl time.Duration) (*models.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) error
+	ReleaseIdempotencyKey(ctx context.Context, actorID, key string) error
+	PurgeIdempotencyKeys(ctx context.Context, ttl time.Duration) (int64, error)
 }
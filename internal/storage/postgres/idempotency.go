@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// abandonedReservationAge is how long a key may stay reserved without a
+// response before it is considered abandoned, as when the server stopped
+// while handling the request, and can be reserved again.
+const abandonedReservationAge = time.Minute
+
+type IdempotencyStorage struct {
+	db  *pgxpool.Pool
+	log logger.Logger
+}
+
+func NewIdempotencyStorage(db *pgxpool.Pool, log logger.Logger) IdempotencyStorage {
+	return IdempotencyStorage{db: db, log: log}
+}
+
+// ReserveIdempotencyKey reserves the key of the actor for a request with the
+// given fingerprint. It returns nil when the key was reserved and the record
+// of the earlier request with this key otherwise. Records older than ttl are
+// forgotten.
+func (s *IdempotencyStorage) ReserveIdempotencyKey(ctx context.Context, actorID, key, fingerprint string, ttl time.Duration) (*models.IdempotencyRecord, error) {
+	now := time.Now()
+
+	_, err := s.db.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE actor_id = $1 AND key = $2
+		  AND (created_at <= $3 OR (completed_at IS NULL AND created_at <= $4))
+	`, actorID, key, now.Add(-ttl), now.Add(-abandonedReservationAge))
+	if err != nil {
+		s.log.Error("Failed to delete expired idempotency key", "error", err, "actor_id", actorID)
+		return nil, fmt.Errorf("failed to delete expired idempotency key: %w", err)
+	}
+
+	result, err := s.db.Exec(ctx, `
+		INSERT INTO idempotency_keys (actor_id, key, fingerprint, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (actor_id, key) DO NOTHING
+	`, actorID, key, fingerprint, now)
+	if err != nil {
+		s.log.Error("Failed to reserve idempotency key", "error", err, "actor_id", actorID)
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if result.RowsAffected() == 1 {
+		return nil, nil
+	}
+
+	record := &models.IdempotencyRecord{ActorId: actorID, Key: key}
+	err = s.db.QueryRow(ctx, `
+		SELECT fingerprint, COALESCE(status_code, 0), COALESCE(content_type, ''),
+		       COALESCE(response_body, ''::bytea), completed_at IS NOT NULL, created_at
+		FROM idempotency_keys
+		WHERE actor_id = $1 AND key = $2
+	`, actorID, key).Scan(&record.Fingerprint, &record.StatusCode, &record.ContentType,
+		&record.Body, &record.Completed, &record.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The request holding the key failed and released it in the meantime
+		return nil, apperr.Conflict(apperr.CodeIdempotencyKeyInUse, "request with idempotency key %s is in progress", key)
+	}
+	if err != nil {
+		s.log.Error("Failed to get idempotency key", "error", err, "actor_id", actorID)
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return record, nil
+}
+
+// CompleteIdempotencyKey stores the response to the request that reserved the
+// key, to be replayed for its retries.
+func (s *IdempotencyStorage) CompleteIdempotencyKey(ctx context.Context, record *models.IdempotencyRecord) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE idempotency_keys
+		SET status_code = $3, content_type = $4, response_body = $5, completed_at = $6
+		WHERE actor_id = $1 AND key = $2
+	`, record.ActorId, record.Key, record.StatusCode, record.ContentType, record.Body, time.Now())
+	if err != nil {
+		s.log.Error("Failed to store idempotent response", "error", err, "actor_id", record.ActorId)
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey forgets a reservation without a response so that the
+// request can be retried.
+func (s *IdempotencyStorage) ReleaseIdempotencyKey(ctx context.Context, actorID, key string) error {
+	_, err := s.db.Exec(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE actor_id = $1 AND key = $2 AND completed_at IS NULL
+	`, actorID, key)
+	if err != nil {
+		s.log.Error("Failed to release idempotency key", "error", err, "actor_id", actorID)
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys deletes the records older than ttl and returns how many
+// were deleted.
+func (s *IdempotencyStorage) PurgeIdempotencyKeys(ctx context.Context, ttl time.Duration) (int64, error) {
+	result, err := s.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE created_at <= $1`, time.Now().Add(-ttl))
+	if err != nil {
+		s.log.Error("Failed to purge idempotency keys", "error", err)
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
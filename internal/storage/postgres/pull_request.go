@@ -1,12 +1,14 @@
 package postgres
 
 import (
+	"avito-autumn-2025/internal/apperr"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -23,7 +25,7 @@ func NewPullRequestStorage(db *pgxpool.Pool, log logger.Logger) PullRequestStora
 }
 
 func (p *PullRequestStorage) CreatePullRequest(ctx context.Context, pr *models.PullRequest, reviewers []string) error {
-	p.log.Info("Creating pull request", "pr_id", pr.PullRequestId, "author_id", pr.AuthorId, "reviewers", reviewers)
+	p.log.Info("Creating pull request", "repository", pr.Repository, "pr_id", pr.PullRequestId, "author_id", pr.AuthorId, "reviewers", reviewers)
 
 	tx, err := p.db.Begin(ctx)
 	if err != nil {
@@ -33,23 +35,55 @@ func (p *PullRequestStorage) CreatePullRequest(ctx context.Context, pr *models.P
 	defer tx.Rollback(ctx)
 
 	query := `
-		INSERT INTO pull_requests (id, pull_request_name, author_id, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO pull_requests (id, pull_request_name, author_id, status, created_at, updated_at,
+			repository, labels, priority, url, additions, deletions, changed_files, team_name)
+		VALUES ($1, $2, $3, $4, $5, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''))
+		RETURNING version
 	`
 	now := time.Now()
-	_, err = tx.Exec(ctx, query, pr.PullRequestId, pr.PullRequestName, pr.AuthorId, pr.Status, now)
+	labels := pr.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	priority := pr.Priority
+	if priority == "" {
+		priority = models.NORMAL
+	}
+	err = tx.QueryRow(ctx, query, pr.PullRequestId, pr.PullRequestName, pr.AuthorId, pr.Status, now,
+		pr.Repository, labels, priority, pr.URL, pr.Additions, pr.Deletions, pr.ChangedFiles, pr.TeamName).Scan(&pr.Version)
 	if err != nil {
+		if isUniqueViolation(err) {
+			p.log.Warn("Pull request already exists", "repository", pr.Repository, "pr_id", pr.PullRequestId)
+			return apperr.Conflict(apperr.CodePullRequestExists, "pull request %s already exists", pr.PullRequestId)
+		}
 		p.log.Error("Failed to insert pull request", "error", err, "pr_id", pr.PullRequestId)
 		return fmt.Errorf("failed to insert pull request: %w", err)
 	}
 
+	created := map[string]any{
+		"pull_request_name": pr.PullRequestName,
+		"author_id":         pr.AuthorId,
+		"team_name":         pr.TeamName,
+		"status":            pr.Status,
+		"priority":          priority,
+		"labels":            labels,
+	}
+	if err = p.insertEvent(ctx, tx, pr.Repository, pr.PullRequestId, models.EventCreated, nil, created); err != nil {
+		return err
+	}
+
 	for _, reviewerID := range reviewers {
-		query = `INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)`
-		_, err = tx.Exec(ctx, query, pr.PullRequestId, reviewerID)
+		query = `INSERT INTO pull_request_reviewers (repository, pr_id, user_id) VALUES ($1, $2, $3)`
+		_, err = tx.Exec(ctx, query, pr.Repository, pr.PullRequestId, reviewerID)
 		if err != nil {
 			p.log.Error("Failed to insert reviewer", "error", err, "pr_id", pr.PullRequestId, "reviewer_id", reviewerID)
 			return fmt.Errorf("failed to insert reviewer %s: %w", reviewerID, err)
 		}
+
+		assigned := map[string]any{"reviewer_id": reviewerID}
+		if err = p.insertEvent(ctx, tx, pr.Repository, pr.PullRequestId, models.EventReviewerAssigned, nil, assigned); err != nil {
+			return err
+		}
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -61,30 +95,43 @@ func (p *PullRequestStorage) CreatePullRequest(ctx context.Context, pr *models.P
 	return nil
 }
 
-func (p *PullRequestStorage) GetPullRequest(ctx context.Context, prID string) (*models.PullRequest, error) {
-	p.log.Debug("Getting pull request", "pr_id", prID)
+func (p *PullRequestStorage) GetPullRequest(ctx context.Context, repository, prID string) (*models.PullRequest, error) {
+	p.log.Debug("Getting pull request", "repository", repository, "pr_id", prID)
 
 	query := `
-		SELECT id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT id, pull_request_name, author_id, status, created_at, merged_at, updated_at, stale_at, closed_at,
+			repository, labels, priority, url, additions, deletions, changed_files, COALESCE(team_name, ''), version
 		FROM pull_requests
-		WHERE id = $1
+		WHERE repository = $1 AND id = $2
 	`
 
 	pr := &models.PullRequest{}
 	var mergedAt sql.NullTime
-	err := p.db.QueryRow(ctx, query, prID).Scan(
+	err := p.db.QueryRow(ctx, query, repository, prID).Scan(
 		&pr.PullRequestId,
 		&pr.PullRequestName,
 		&pr.AuthorId,
 		&pr.Status,
 		&pr.CreatedAt,
 		&mergedAt,
+		&pr.UpdatedAt,
+		&pr.StaleAt,
+		&pr.ClosedAt,
+		&pr.Repository,
+		&pr.Labels,
+		&pr.Priority,
+		&pr.URL,
+		&pr.Additions,
+		&pr.Deletions,
+		&pr.ChangedFiles,
+		&pr.TeamName,
+		&pr.Version,
 	)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			p.log.Warn("Pull request not found", "pr_id", prID)
-			return nil, fmt.Errorf("pull request %s not found", prID)
+			return nil, apperr.NotFound(apperr.CodePullRequestNotFound, "pull request %s not found", prID)
 		}
 		p.log.Error("Failed to get pull request", "error", err, "pr_id", prID)
 		return nil, fmt.Errorf("failed to get pull request: %w", err)
@@ -94,8 +141,8 @@ func (p *PullRequestStorage) GetPullRequest(ctx context.Context, prID string) (*
 		pr.MergedAt = &mergedAt.Time
 	}
 
-	reviewersQuery := `SELECT user_id FROM pull_request_reviewers WHERE pr_id = $1`
-	rows, err := p.db.Query(ctx, reviewersQuery, prID)
+	reviewersQuery := `SELECT user_id FROM pull_request_reviewers WHERE repository = $1 AND pr_id = $2`
+	rows, err := p.db.Query(ctx, reviewersQuery, repository, prID)
 	if err != nil {
 		p.log.Error("Failed to get reviewers", "error", err, "pr_id", prID)
 		return nil, fmt.Errorf("failed to get reviewers: %w", err)
@@ -113,169 +160,839 @@ func (p *PullRequestStorage) GetPullRequest(ctx context.Context, prID string) (*
 	}
 
 	pr.AssignedReviewers = reviewers
+
+	slaActions, err := p.getSLAActions(ctx, repository, prID)
+	if err != nil {
+		return nil, err
+	}
+	pr.SLAActions = slaActions
+
 	p.log.Debug("Successfully retrieved pull request", "pr_id", prID, "reviewers_count", len(reviewers))
 
 	return pr, nil
 }
 
-func (p *PullRequestStorage) GetPullRequestsByReviewer(ctx context.Context, reviewerID string) ([]*models.PullRequestShort, error) {
-	p.log.Debug("Getting pull requests by reviewer", "reviewer_id", reviewerID)
-
+func (p *PullRequestStorage) getSLAActions(ctx context.Context, repository, prID string) ([]*models.SLAAction, error) {
 	query := `
-		SELECT pr.id, pr.pull_request_name, pr.author_id, pr.status
-		FROM pull_requests pr
-		INNER JOIN pull_request_reviewers prr ON pr.id = prr.pr_id
-		WHERE prr.user_id = $1
-		ORDER BY pr.created_at DESC
+		SELECT id, repository, pr_id, reviewer_id, action, new_reviewer_id, deadline, created_at
+		FROM pull_request_sla_actions
+		WHERE repository = $1 AND pr_id = $2
+		ORDER BY created_at
 	`
+	rows, err := p.db.Query(ctx, query, repository, prID)
+	if err != nil {
+		p.log.Error("Failed to get SLA actions", "error", err, "pr_id", prID)
+		return nil, fmt.Errorf("failed to get SLA actions: %w", err)
+	}
+	defer rows.Close()
+
+	var actions []*models.SLAAction
+	for rows.Next() {
+		action := &models.SLAAction{}
+		var newReviewerID sql.NullString
+		if err := rows.Scan(&action.Id, &action.Repository, &action.PullRequestId, &action.ReviewerId, &action.Action, &newReviewerID, &action.Deadline, &action.CreatedAt); err != nil {
+			p.log.Error("Failed to scan SLA action", "error", err)
+			return nil, fmt.Errorf("failed to scan SLA action: %w", err)
+		}
+		action.NewReviewerId = newReviewerID.String
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// SearchPullRequests returns one page of the pull requests matching query,
+// ordered by query.Sort with the repository and id as tie-breakers so that
+// the keyset cursor is stable.
+func (p *PullRequestStorage) SearchPullRequests(ctx context.Context, query *models.PullRequestQuery) (*models.PullRequestPage, error) {
+	p.log.Debug("Searching pull requests", "reviewer_id", query.ReviewerId, "author_id", query.AuthorId, "team_name", query.TeamName, "sort", query.Sort, "order", query.Order, "limit", query.Limit)
+
+	column := "pr.created_at"
+	if query.Sort == models.SortByUpdatedAt {
+		column = "pr.updated_at"
+	}
+	direction, comparison := "DESC", "<"
+	if query.Order == models.SortAsc {
+		direction, comparison = "ASC", ">"
+	}
+
+	var conditions []string
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.ReviewerId != "" {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM pull_request_reviewers prr
+			WHERE prr.repository = pr.repository AND prr.pr_id = pr.id AND prr.user_id = `+arg(query.ReviewerId)+`
+		)`)
+	}
+	if query.AuthorId != "" {
+		conditions = append(conditions, "pr.author_id = "+arg(query.AuthorId))
+	}
+	if query.TeamName != "" {
+		conditions = append(conditions, "pr.team_name = "+arg(query.TeamName))
+	}
+	if query.Repository != "" {
+		conditions = append(conditions, "pr.repository = "+arg(query.Repository))
+	}
+	if len(query.Statuses) > 0 {
+		statuses := make([]string, 0, len(query.Statuses))
+		for _, status := range query.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		conditions = append(conditions, "pr.status = ANY("+arg(statuses)+")")
+	}
+	if query.CreatedFrom != nil {
+		conditions = append(conditions, "pr.created_at >= "+arg(*query.CreatedFrom))
+	}
+	if query.CreatedTo != nil {
+		conditions = append(conditions, "pr.created_at < "+arg(*query.CreatedTo))
+	}
+	if query.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, pr.repository, pr.id) %s (%s, %s, %s)",
+			column, comparison, arg(query.After.Value), arg(query.After.Repository), arg(query.After.PullRequestId)))
+	}
 
-	rows, err := p.db.Query(ctx, query, reviewerID)
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// One extra row tells whether there is a next page.
+	sqlQuery := fmt.Sprintf(`
+		SELECT pr.repository, pr.id, pr.pull_request_name, pr.author_id, pr.status, pr.team_name, pr.created_at, pr.updated_at
+		FROM pull_requests pr
+		%s
+		ORDER BY %s %s, pr.repository %s, pr.id %s
+		LIMIT %s
+	`, where, column, direction, direction, direction, arg(query.Limit+1))
+
+	rows, err := p.db.Query(ctx, sqlQuery, args...)
 	if err != nil {
-		p.log.Error("Failed to get pull requests by reviewer", "error", err, "reviewer_id", reviewerID)
-		return nil, fmt.Errorf("failed to get pull requests by reviewer: %w", err)
+		p.log.Error("Failed to search pull requests", "error", err)
+		return nil, fmt.Errorf("failed to search pull requests: %w", err)
 	}
 	defer rows.Close()
 
-	var prs []*models.PullRequestShort
+	page := &models.PullRequestPage{PullRequests: []*models.PullRequestShort{}}
 	for rows.Next() {
 		pr := &models.PullRequestShort{}
-		if err := rows.Scan(&pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status); err != nil {
+		var teamName sql.NullString
+		if err := rows.Scan(&pr.Repository, &pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &teamName, &pr.CreatedAt, &pr.UpdatedAt); err != nil {
 			p.log.Error("Failed to scan pull request", "error", err)
 			return nil, fmt.Errorf("failed to scan pull request: %w", err)
 		}
-		prs = append(prs, pr)
+		pr.TeamName = teamName.String
+		page.PullRequests = append(page.PullRequests, pr)
+	}
+	if err := rows.Err(); err != nil {
+		p.log.Error("Failed to search pull requests", "error", err)
+		return nil, fmt.Errorf("failed to search pull requests: %w", err)
 	}
 
-	p.log.Debug("Successfully retrieved pull requests by reviewer", "reviewer_id", reviewerID, "count", len(prs))
-	return prs, nil
+	if len(page.PullRequests) > query.Limit {
+		page.PullRequests = page.PullRequests[:query.Limit]
+		last := page.PullRequests[query.Limit-1]
+		value := *last.CreatedAt
+		if query.Sort == models.SortByUpdatedAt {
+			value = *last.UpdatedAt
+		}
+		page.NextCursor = &models.PullRequestCursor{
+			Sort:          query.Sort,
+			Order:         query.Order,
+			Value:         value,
+			Repository:    last.Repository,
+			PullRequestId: last.PullRequestId,
+		}
+	}
+
+	p.log.Debug("Successfully searched pull requests", "count", len(page.PullRequests), "has_more", page.NextCursor != nil)
+	return page, nil
 }
 
-func (p *PullRequestStorage) MergePullRequest(ctx context.Context, prID string) error {
-	p.log.Info("Merging pull request", "pr_id", prID)
+// MergePullRequest merges an open pull request and returns its new version.
+// Unless force is set, a reviewer whose verdict is CHANGES_REQUESTED blocks the
+// merge. A non-zero version must be the current version of the pull request.
+func (p *PullRequestStorage) MergePullRequest(ctx context.Context, repository, prID string, force bool, version int64) (int64, error) {
+	p.log.Info("Merging pull request", "repository", repository, "pr_id", prID, "force", force)
 
-	var currentStatus models.PullRequestStatus
-	checkQuery := `SELECT status FROM pull_requests WHERE id = $1`
-	err := p.db.QueryRow(ctx, checkQuery, prID).Scan(&currentStatus)
+	tx, err := p.db.Begin(ctx)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			p.log.Warn("Pull request not found for merge", "pr_id", prID)
-			return fmt.Errorf("pull request %s not found", prID)
-		}
-		p.log.Error("Failed to check pull request status", "error", err, "pr_id", prID)
-		return fmt.Errorf("failed to check pull request status: %w", err)
+		p.log.Error("Failed to begin transaction for merge", "error", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	currentStatus, currentVersion, err := p.lockPullRequest(ctx, tx, repository, prID, version)
+	if err != nil {
+		return 0, err
 	}
 
 	if currentStatus == models.MERGED {
 		p.log.Info("Pull request already merged", "pr_id", prID)
-		return nil
+		return currentVersion, nil
+	}
+
+	if currentStatus == models.CLOSED {
+		p.log.Warn("Cannot merge closed pull request", "pr_id", prID)
+		return 0, apperr.Conflict(apperr.CodePullRequestClosed, "cannot merge closed pull request %s", prID)
+	}
+
+	if !force {
+		var changesRequested int
+		changesQuery := `SELECT COUNT(*) FROM pull_request_reviewers WHERE repository = $1 AND pr_id = $2 AND verdict = $3`
+		err = tx.QueryRow(ctx, changesQuery, repository, prID, models.CHANGES_REQUESTED).Scan(&changesRequested)
+		if err != nil {
+			p.log.Error("Failed to check requested changes", "error", err, "pr_id", prID)
+			return 0, fmt.Errorf("failed to check requested changes: %w", err)
+		}
+		if changesRequested > 0 {
+			p.log.Warn("Cannot merge pull request with requested changes", "pr_id", prID, "count", changesRequested)
+			return 0, apperr.PreconditionFailed(apperr.CodeChangesRequested, "pull request %s has %d reviewers requesting changes", prID, changesRequested)
+		}
 	}
 
 	query := `
 		UPDATE pull_requests 
-		SET status = $1, merged_at = $2 
-		WHERE id = $3
+		SET status = $1, merged_at = $2, updated_at = $2, stale_at = NULL
+		WHERE repository = $3 AND id = $4
+		RETURNING version
 	`
-	_, err = p.db.Exec(ctx, query, models.MERGED, time.Now(), prID)
+	var newVersion int64
+	err = tx.QueryRow(ctx, query, models.MERGED, time.Now(), repository, prID).Scan(&newVersion)
 	if err != nil {
 		p.log.Error("Failed to merge pull request", "error", err, "pr_id", prID)
-		return fmt.Errorf("failed to merge pull request: %w", err)
+		return 0, fmt.Errorf("failed to merge pull request: %w", err)
 	}
 
-	p.log.Info("Successfully merged pull request", "pr_id", prID)
-	return nil
+	before := map[string]any{"status": currentStatus}
+	after := map[string]any{"status": models.MERGED}
+	if force {
+		after["forced"] = true
+	}
+	if err = p.insertEvent(ctx, tx, repository, prID, models.EventMerged, before, after); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.log.Error("Failed to commit merge transaction", "error", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	p.log.Info("Successfully merged pull request", "pr_id", prID, "version", newVersion)
+	return newVersion, nil
 }
 
-func (p *PullRequestStorage) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) error {
-	p.log.Info("Reassigning reviewer", "pr_id", prID, "old_reviewer", oldReviewerID)
+// ReassignReviewer replaces oldReviewerID on the pull request and returns its
+// new version. A non-zero version must be the current version of the pull
+// request.
+func (p *PullRequestStorage) ReassignReviewer(ctx context.Context, repository, prID, oldReviewerID string, version int64) (int64, error) {
+	p.log.Info("Reassigning reviewer", "repository", repository, "pr_id", prID, "old_reviewer", oldReviewerID)
 
 	tx, err := p.db.Begin(ctx)
 	if err != nil {
 		p.log.Error("Failed to begin transaction for reassignment", "error", err)
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	var status models.PullRequestStatus
-	checkQuery := `SELECT status FROM pull_requests WHERE id = $1`
-	err = tx.QueryRow(ctx, checkQuery, prID).Scan(&status)
+	if _, _, err := p.lockPullRequest(ctx, tx, repository, prID, version); err != nil {
+		return 0, err
+	}
+
+	newReviewerID, err := p.reassignReviewer(ctx, tx, repository, prID, oldReviewerID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			p.log.Warn("Pull request not found for reassignment", "pr_id", prID)
-			return fmt.Errorf("pull request %s not found", prID)
-		}
-		p.log.Error("Failed to check pull request status for reassignment", "error", err, "pr_id", prID)
-		return fmt.Errorf("failed to check pull request status: %w", err)
+		return 0, err
+	}
+
+	newVersion, err := p.pullRequestVersion(ctx, tx, repository, prID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		p.log.Error("Failed to commit reassignment transaction", "error", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	p.log.Info("Successfully reassigned reviewer", "pr_id", prID, "old_reviewer", oldReviewerID, "new_reviewer", newReviewerID, "version", newVersion)
+	return newVersion, nil
+}
+
+// reassignReviewer replaces oldReviewerID on the pull request inside tx and returns
+// the id of the new reviewer. The replacement is a random active member of the
+// repository reviewer pool when one is configured, otherwise of the pull request's
+// team, or of the old reviewer's primary team for pull requests without one. When
+// that team has no free reviewers, its ancestor teams are tried nearest first.
+func (p *PullRequestStorage) reassignReviewer(ctx context.Context, tx pgx.Tx, repository, prID, oldReviewerID string) (string, error) {
+	return p.replaceReviewer(ctx, tx, repository, prID, oldReviewerID, "", false)
+}
+
+// replaceReviewer is reassignReviewer with an explicit fallback team for pull
+// requests without a repository pool. An empty fallbackTeam keeps the default.
+// With anyTeam, a member of any team is picked when the team and its ancestors
+// have no free reviewers, for reviews whose team is being deleted.
+func (p *PullRequestStorage) replaceReviewer(ctx context.Context, tx pgx.Tx, repository, prID, oldReviewerID, fallbackTeam string, anyTeam bool) (string, error) {
+	status, _, err := p.lockPullRequest(ctx, tx, repository, prID, 0)
+	if err != nil {
+		return "", err
 	}
 
 	if status == models.MERGED {
 		p.log.Warn("Cannot reassign reviewers on merged pull request", "pr_id", prID)
-		return fmt.Errorf("cannot reassign reviewers on merged pull request %s", prID)
+		return "", apperr.Conflict(apperr.CodePullRequestMerged, "cannot reassign reviewers on merged pull request %s", prID)
+	}
+
+	if status == models.CLOSED {
+		p.log.Warn("Cannot reassign reviewers on closed pull request", "pr_id", prID)
+		return "", apperr.Conflict(apperr.CodePullRequestClosed, "cannot reassign reviewers on closed pull request %s", prID)
 	}
 
 	var exists bool
-	existsQuery := `SELECT EXISTS(SELECT 1 FROM pull_request_reviewers WHERE pr_id = $1 AND user_id = $2)`
-	err = tx.QueryRow(ctx, existsQuery, prID, oldReviewerID).Scan(&exists)
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM pull_request_reviewers WHERE repository = $1 AND pr_id = $2 AND user_id = $3)`
+	err = tx.QueryRow(ctx, existsQuery, repository, prID, oldReviewerID).Scan(&exists)
 	if err != nil {
 		p.log.Error("Failed to check reviewer existence", "error", err, "pr_id", prID, "reviewer_id", oldReviewerID)
-		return fmt.Errorf("failed to check reviewer existence: %w", err)
+		return "", fmt.Errorf("failed to check reviewer existence: %w", err)
 	}
 
 	if !exists {
 		p.log.Warn("Old reviewer not assigned to pull request", "pr_id", prID, "old_reviewer", oldReviewerID)
-		return fmt.Errorf("reviewer %s is not assigned to pull request %s", oldReviewerID, prID)
-	}
-
-	var teamName string
-	teamQuery := `SELECT team_name FROM users WHERE id = $1`
-	err = tx.QueryRow(ctx, teamQuery, oldReviewerID).Scan(&teamName)
-	if err != nil {
-		p.log.Error("Failed to get reviewer team", "error", err, "reviewer_id", oldReviewerID)
-		return fmt.Errorf("failed to get reviewer team: %w", err)
+		return "", apperr.Conflict(apperr.CodeNotAssigned, "reviewer %s is not assigned to pull request %s", oldReviewerID, prID)
 	}
 
 	var newReviewerID string
+	// The team fallback walks up the team hierarchy: members of the nearest
+	// team that has a free reviewer win.
 	newReviewerQuery := `
-		SELECT id FROM users 
-		WHERE team_name = $1 
-		AND is_active = true 
-		AND id != $2
-		AND id NOT IN (
-			SELECT user_id FROM pull_request_reviewers WHERE pr_id = $3
+		WITH RECURSIVE chain(name, depth) AS (
+			SELECT name, 0 FROM teams WHERE name = COALESCE(
+				NULLIF($4, ''),
+				(SELECT team_name FROM pull_requests WHERE repository = $2 AND id = $3),
+				(SELECT team_name FROM team_memberships WHERE user_id = $1 AND is_primary)
+			)
+			UNION ALL
+			SELECT t.parent_name, c.depth + 1
+			FROM chain c
+			INNER JOIN teams t ON t.name = c.name
+			WHERE t.parent_name IS NOT NULL
 		)
-		ORDER BY RANDOM()
+		SELECT u.id FROM users u
+		LEFT JOIN LATERAL (
+			SELECT MIN(c.depth) AS depth
+			FROM team_memberships tm
+			INNER JOIN chain c ON c.name = tm.team_name
+			WHERE tm.user_id = u.id AND tm.role != 'observer'
+		) m ON true
+		WHERE u.is_active = true 
+		AND u.id != $1
+		AND u.id NOT IN (
+			SELECT user_id FROM pull_request_reviewers WHERE repository = $2 AND pr_id = $3
+		)
+		AND u.id != (SELECT author_id FROM pull_requests WHERE repository = $2 AND id = $3)
+		AND CASE
+			WHEN EXISTS (SELECT 1 FROM repository_reviewers WHERE repository = $2)
+				THEN u.id IN (SELECT user_id FROM repository_reviewers WHERE repository = $2)
+			ELSE m.depth IS NOT NULL OR ($5 AND EXISTS (
+				SELECT 1 FROM team_memberships WHERE user_id = u.id AND role != 'observer'
+			))
+		END
+		ORDER BY m.depth NULLS LAST, RANDOM()
 		LIMIT 1
 	`
-	err = tx.QueryRow(ctx, newReviewerQuery, teamName, oldReviewerID, prID).Scan(&newReviewerID)
+	err = tx.QueryRow(ctx, newReviewerQuery, oldReviewerID, repository, prID, fallbackTeam, anyTeam).Scan(&newReviewerID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			p.log.Warn("No available reviewers found for reassignment", "pr_id", prID, "team_name", teamName)
-			return fmt.Errorf("no available reviewers found in team %s", teamName)
+			p.log.Warn("No available reviewers found for reassignment", "repository", repository, "pr_id", prID)
+			return "", apperr.NoCandidates(apperr.CodeNoCandidate, "no available reviewers found for pull request %s", prID)
 		}
 		p.log.Error("Failed to find new reviewer", "error", err)
-		return fmt.Errorf("failed to find new reviewer: %w", err)
+		return "", fmt.Errorf("failed to find new reviewer: %w", err)
 	}
 
 	updateQuery := `
 		UPDATE pull_request_reviewers 
-		SET user_id = $1 
-		WHERE pr_id = $2 AND user_id = $3
+		SET user_id = $1, assigned_at = $2, responded_at = NULL, verdict = NULL, escalated_at = NULL
+		WHERE repository = $3 AND pr_id = $4 AND user_id = $5
 	`
-	_, err = tx.Exec(ctx, updateQuery, newReviewerID, prID, oldReviewerID)
+	_, err = tx.Exec(ctx, updateQuery, newReviewerID, time.Now(), repository, prID, oldReviewerID)
 	if err != nil {
 		p.log.Error("Failed to reassign reviewer", "error", err, "pr_id", prID, "old_reviewer", oldReviewerID, "new_reviewer", newReviewerID)
-		return fmt.Errorf("failed to reassign reviewer: %w", err)
+		return "", fmt.Errorf("failed to reassign reviewer: %w", err)
 	}
 
-	err = tx.Commit(ctx)
+	before := map[string]any{"reviewer_id": oldReviewerID}
+	after := map[string]any{"reviewer_id": newReviewerID}
+	if err := p.insertEvent(ctx, tx, repository, prID, models.EventReviewerReassigned, before, after); err != nil {
+		return "", err
+	}
+
+	if err := p.touchPullRequest(ctx, tx, repository, prID); err != nil {
+		return "", err
+	}
+
+	return newReviewerID, nil
+}
+
+// handOverReviews moves each review to a new reviewer from the pull request's
+// team. Reviews nobody can take over are unassigned.
+func (p *PullRequestStorage) handOverReviews(ctx context.Context, tx pgx.Tx, reviews []openReview) (*models.ReassignmentResult, error) {
+	result := &models.ReassignmentResult{}
+	for _, review := range reviews {
+		_, err := p.replaceReviewer(ctx, tx, review.Repository, review.PullRequestId, review.ReviewerId, review.TeamName, false)
+		if errors.Is(err, apperr.ErrNoCandidates) {
+			p.log.Warn("No reviewer can take over open review, unassigning it", "pr_id", review.PullRequestId, "reviewer_id", review.ReviewerId)
+			if err := p.unassignReviewer(ctx, tx, review.Repository, review.PullRequestId, review.ReviewerId); err != nil {
+				return nil, err
+			}
+			result.Unassigned++
+			continue
+		}
+		if err != nil {
+			p.log.Error("Failed to reassign open review", "error", err, "pr_id", review.PullRequestId, "reviewer_id", review.ReviewerId)
+			return nil, fmt.Errorf("failed to reassign review of %s on pull request %s: %w", review.ReviewerId, review.PullRequestId, err)
+		}
+		result.Reassigned++
+	}
+	return result, nil
+}
+
+// unassignReviewer removes reviewerID from the pull request without a
+// replacement, for reviewers who leave when nobody can take over.
+func (p *PullRequestStorage) unassignReviewer(ctx context.Context, tx pgx.Tx, repository, prID, reviewerID string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM pull_request_reviewers WHERE repository = $1 AND pr_id = $2 AND user_id = $3`,
+		repository, prID, reviewerID)
 	if err != nil {
-		p.log.Error("Failed to commit reassignment transaction", "error", err)
+		p.log.Error("Failed to unassign reviewer", "error", err, "pr_id", prID, "reviewer_id", reviewerID)
+		return fmt.Errorf("failed to unassign reviewer: %w", err)
+	}
+
+	before := map[string]any{"reviewer_id": reviewerID}
+	if err := p.insertEvent(ctx, tx, repository, prID, models.EventReviewerUnassigned, before, nil); err != nil {
+		return err
+	}
+
+	return p.touchPullRequest(ctx, tx, repository, prID)
+}
+
+// SubmitReview records the verdict of reviewerID and returns the new version of
+// the pull request. A non-zero version must be its current version.
+func (p *PullRequestStorage) SubmitReview(ctx context.Context, repository, prID, reviewerID string, verdict models.ReviewVerdict, version int64) (int64, error) {
+	p.log.Info("Submitting review", "repository", repository, "pr_id", prID, "reviewer_id", reviewerID, "verdict", verdict)
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.log.Error("Failed to begin transaction for review", "error", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	status, _, err := p.lockPullRequest(ctx, tx, repository, prID, version)
+	if err != nil {
+		return 0, err
+	}
+
+	if status == models.MERGED {
+		p.log.Warn("Cannot review merged pull request", "pr_id", prID)
+		return 0, apperr.Conflict(apperr.CodePullRequestMerged, "cannot review merged pull request %s", prID)
+	}
+
+	if status == models.CLOSED {
+		p.log.Warn("Cannot review closed pull request", "pr_id", prID)
+		return 0, apperr.Conflict(apperr.CodePullRequestClosed, "cannot review closed pull request %s", prID)
+	}
+
+	var previousVerdict sql.NullString
+	verdictQuery := `SELECT verdict FROM pull_request_reviewers WHERE repository = $1 AND pr_id = $2 AND user_id = $3`
+	err = tx.QueryRow(ctx, verdictQuery, repository, prID, reviewerID).Scan(&previousVerdict)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Warn("Reviewer not assigned to pull request", "pr_id", prID, "reviewer_id", reviewerID)
+			return 0, apperr.Conflict(apperr.CodeNotAssigned, "reviewer %s is not assigned to pull request %s", reviewerID, prID)
+		}
+		p.log.Error("Failed to get current verdict", "error", err, "pr_id", prID, "reviewer_id", reviewerID)
+		return 0, fmt.Errorf("failed to get current verdict: %w", err)
+	}
+
+	query := `
+		UPDATE pull_request_reviewers
+		SET responded_at = COALESCE(responded_at, $1), verdict = $2
+		WHERE repository = $3 AND pr_id = $4 AND user_id = $5
+	`
+	_, err = tx.Exec(ctx, query, time.Now(), verdict, repository, prID, reviewerID)
+	if err != nil {
+		p.log.Error("Failed to submit review", "error", err, "pr_id", prID, "reviewer_id", reviewerID)
+		return 0, fmt.Errorf("failed to submit review: %w", err)
+	}
+
+	var before map[string]any
+	if previousVerdict.Valid {
+		before = map[string]any{"reviewer_id": reviewerID, "verdict": previousVerdict.String}
+	}
+	after := map[string]any{"reviewer_id": reviewerID, "verdict": verdict}
+	if err := p.insertEvent(ctx, tx, repository, prID, models.EventVerdict, before, after); err != nil {
+		return 0, err
+	}
+
+	if err := p.touchPullRequest(ctx, tx, repository, prID); err != nil {
+		return 0, err
+	}
+
+	newVersion, err := p.pullRequestVersion(ctx, tx, repository, prID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.log.Error("Failed to commit review transaction", "error", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	p.log.Info("Successfully submitted review", "pr_id", prID, "reviewer_id", reviewerID, "verdict", verdict, "version", newVersion)
+	return newVersion, nil
+}
+
+func (p *PullRequestStorage) GetPendingReviews(ctx context.Context) ([]*models.PendingReview, error) {
+	p.log.Debug("Getting pending reviews")
+
+	query := `
+		SELECT prr.repository, prr.pr_id, prr.user_id, prr.assigned_at,
+			tp.team_name, tp.first_response_hours, tp.business_hours_only, tp.workday_start, tp.workday_end, tp.time_zone,
+			tp.sla_action, tp.escalation_reviewer_id
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.repository = prr.repository AND pr.id = prr.pr_id
+		INNER JOIN team_policies tp ON tp.team_name = pr.team_name
+		WHERE pr.status = 'OPEN'
+		AND prr.responded_at IS NULL
+		AND prr.escalated_at IS NULL
+		ORDER BY prr.assigned_at
+	`
+
+	rows, err := p.db.Query(ctx, query)
+	if err != nil {
+		p.log.Error("Failed to get pending reviews", "error", err)
+		return nil, fmt.Errorf("failed to get pending reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []*models.PendingReview
+	for rows.Next() {
+		review := &models.PendingReview{}
+		var escalationReviewerID sql.NullString
+		err := rows.Scan(
+			&review.Repository,
+			&review.PullRequestId,
+			&review.ReviewerId,
+			&review.AssignedAt,
+			&review.Policy.TeamName,
+			&review.Policy.FirstResponseHours,
+			&review.Policy.BusinessHoursOnly,
+			&review.Policy.WorkdayStart,
+			&review.Policy.WorkdayEnd,
+			&review.Policy.TimeZone,
+			&review.Policy.SLAAction,
+			&escalationReviewerID,
+		)
+		if err != nil {
+			p.log.Error("Failed to scan pending review", "error", err)
+			return nil, fmt.Errorf("failed to scan pending review: %w", err)
+		}
+		review.Policy.EscalationReviewerId = escalationReviewerID.String
+		reviews = append(reviews, review)
+	}
+
+	p.log.Debug("Successfully retrieved pending reviews", "count", len(reviews))
+	return reviews, nil
+}
+
+// ApplySLAAction reassigns or escalates an overdue review and records the action
+// on the pull request in a single transaction. On success action.NewReviewerId
+// holds the reviewer that was brought in.
+func (p *PullRequestStorage) ApplySLAAction(ctx context.Context, action *models.SLAAction) error {
+	p.log.Info("Applying SLA action", "pr_id", action.PullRequestId, "reviewer_id", action.ReviewerId, "action", action.Action)
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.log.Error("Failed to begin transaction for SLA action", "error", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	switch action.Action {
+	case models.SLAActionReassign:
+		newReviewerID, err := p.reassignReviewer(ctx, tx, action.Repository, action.PullRequestId, action.ReviewerId)
+		if err != nil {
+			return err
+		}
+		action.NewReviewerId = newReviewerID
+	case models.SLAActionEscalate:
+		newReviewerID, err := p.escalateReview(ctx, tx, action.Repository, action.PullRequestId, action.ReviewerId, action.NewReviewerId)
+		if err != nil {
+			return err
+		}
+		action.NewReviewerId = newReviewerID
+	default:
+		return fmt.Errorf("unknown SLA action %s", action.Action)
+	}
+
+	insertQuery := `
+		INSERT INTO pull_request_sla_actions (repository, pr_id, reviewer_id, action, new_reviewer_id, deadline, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, insertQuery, action.Repository, action.PullRequestId, action.ReviewerId, action.Action, action.NewReviewerId, action.Deadline, time.Now()).
+		Scan(&action.Id, &action.CreatedAt)
+	if err != nil {
+		p.log.Error("Failed to record SLA action", "error", err, "pr_id", action.PullRequestId)
+		return fmt.Errorf("failed to record SLA action: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.log.Error("Failed to commit SLA action transaction", "error", err)
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	p.log.Info("Successfully reassigned reviewer", "pr_id", prID, "old_reviewer", oldReviewerID, "new_reviewer", newReviewerID)
+	p.log.Info("Successfully applied SLA action", "pr_id", action.PullRequestId, "action", action.Action, "new_reviewer", action.NewReviewerId)
 	return nil
 }
 
+// escalateReview adds an extra reviewer next to the overdue one and marks the
+// overdue assignment as escalated. preferredID is used when it is an eligible
+// reviewer, otherwise a random active member of the overdue reviewer's team is picked.
+func (p *PullRequestStorage) escalateReview(ctx context.Context, tx pgx.Tx, repository, prID, reviewerID, preferredID string) (string, error) {
+	if _, _, err := p.lockPullRequest(ctx, tx, repository, prID, 0); err != nil {
+		return "", err
+	}
+
+	candidateQuery := `
+		SELECT u.id FROM users u
+		INNER JOIN pull_requests pr ON pr.repository = $1 AND pr.id = $2
+		WHERE u.is_active = true
+		AND u.id != pr.author_id
+		AND u.id NOT IN (
+			SELECT user_id FROM pull_request_reviewers WHERE repository = $1 AND pr_id = $2
+		)
+		AND ((u.id = $4) OR ($4 = '' AND u.id IN (
+			SELECT user_id FROM team_memberships WHERE role != 'observer' AND team_name = COALESCE(
+				pr.team_name,
+				(SELECT team_name FROM team_memberships WHERE user_id = $3 AND is_primary)
+			)
+		)))
+		ORDER BY RANDOM()
+		LIMIT 1
+	`
+
+	var newReviewerID string
+	err := tx.QueryRow(ctx, candidateQuery, repository, prID, reviewerID, preferredID).Scan(&newReviewerID)
+	if err != nil && errors.Is(err, pgx.ErrNoRows) && preferredID != "" {
+		err = tx.QueryRow(ctx, candidateQuery, repository, prID, reviewerID, "").Scan(&newReviewerID)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Warn("No available escalation reviewers found", "pr_id", prID, "reviewer_id", reviewerID)
+			return "", apperr.NoCandidates(apperr.CodeNoCandidate, "no available escalation reviewers found for pull request %s", prID)
+		}
+		p.log.Error("Failed to find escalation reviewer", "error", err, "pr_id", prID)
+		return "", fmt.Errorf("failed to find escalation reviewer: %w", err)
+	}
+
+	now := time.Now()
+	_, err = tx.Exec(ctx, `INSERT INTO pull_request_reviewers (repository, pr_id, user_id, assigned_at) VALUES ($1, $2, $3, $4)`,
+		repository, prID, newReviewerID, now)
+	if err != nil {
+		p.log.Error("Failed to add escalation reviewer", "error", err, "pr_id", prID, "reviewer_id", newReviewerID)
+		return "", fmt.Errorf("failed to add escalation reviewer: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE pull_request_reviewers SET escalated_at = $1 WHERE repository = $2 AND pr_id = $3 AND user_id = $4`,
+		now, repository, prID, reviewerID)
+	if err != nil {
+		p.log.Error("Failed to mark review as escalated", "error", err, "pr_id", prID, "reviewer_id", reviewerID)
+		return "", fmt.Errorf("failed to mark review as escalated: %w", err)
+	}
+
+	after := map[string]any{"reviewer_id": newReviewerID, "reason": "SLA_ESCALATION", "escalated_reviewer_id": reviewerID}
+	if err := p.insertEvent(ctx, tx, repository, prID, models.EventReviewerAssigned, nil, after); err != nil {
+		return "", err
+	}
+
+	if err := p.touchPullRequest(ctx, tx, repository, prID); err != nil {
+		return "", err
+	}
+
+	return newReviewerID, nil
+}
+
+// touchPullRequest records activity on the pull request: it bumps updated_at
+// and clears a stale mark, since the pull request is being worked on again.
+func (p *PullRequestStorage) touchPullRequest(ctx context.Context, tx pgx.Tx, repository, prID string) error {
+	_, err := tx.Exec(ctx, `UPDATE pull_requests SET updated_at = $1, stale_at = NULL WHERE repository = $2 AND id = $3`,
+		time.Now(), repository, prID)
+	if err != nil {
+		p.log.Error("Failed to update pull request activity", "error", err, "pr_id", prID)
+		return fmt.Errorf("failed to update pull request activity: %w", err)
+	}
+	return nil
+}
+
+// lockPullRequest locks the pull request row for the rest of tx and returns its
+// status and version. It fails when the pull request does not exist or when
+// version is non-zero and not the current one.
+func (p *PullRequestStorage) lockPullRequest(ctx context.Context, tx pgx.Tx, repository, prID string, version int64) (models.PullRequestStatus, int64, error) {
+	var status models.PullRequestStatus
+	var current int64
+	err := tx.QueryRow(ctx, `SELECT status, version FROM pull_requests WHERE repository = $1 AND id = $2 FOR UPDATE`,
+		repository, prID).Scan(&status, &current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			p.log.Warn("Pull request not found", "repository", repository, "pr_id", prID)
+			return "", 0, apperr.NotFound(apperr.CodePullRequestNotFound, "pull request %s not found", prID)
+		}
+		p.log.Error("Failed to lock pull request", "error", err, "pr_id", prID)
+		return "", 0, fmt.Errorf("failed to lock pull request: %w", err)
+	}
+
+	if version != 0 && version != current {
+		p.log.Warn("Pull request version mismatch", "pr_id", prID, "version", current, "expected_version", version)
+		return "", 0, apperr.VersionMismatch(apperr.CodeVersionMismatch, "pull request %s is at version %d, not %d", prID, current, version)
+	}
+	return status, current, nil
+}
+
+// pullRequestVersion returns the version of the pull request as of tx.
+func (p *PullRequestStorage) pullRequestVersion(ctx context.Context, tx pgx.Tx, repository, prID string) (int64, error) {
+	var version int64
+	err := tx.QueryRow(ctx, `SELECT version FROM pull_requests WHERE repository = $1 AND id = $2`, repository, prID).Scan(&version)
+	if err != nil {
+		p.log.Error("Failed to get pull request version", "error", err, "pr_id", prID)
+		return 0, fmt.Errorf("failed to get pull request version: %w", err)
+	}
+	return version, nil
+}
+
+func (p *PullRequestStorage) MarkStalePullRequests(ctx context.Context) ([]*models.PullRequestShort, error) {
+	p.log.Debug("Marking stale pull requests")
+
+	query := `
+		UPDATE pull_requests pr
+		SET stale_at = $1
+		FROM team_policies tp
+		WHERE tp.team_name = pr.team_name
+		AND tp.stale_after_days > 0
+		AND pr.status = 'OPEN'
+		AND pr.stale_at IS NULL
+		AND pr.updated_at < $1 - make_interval(days => tp.stale_after_days)
+		RETURNING pr.repository, pr.id, pr.pull_request_name, pr.author_id, pr.status
+	`
+	return p.collectPullRequests(ctx, query, "stale", models.EventMarkedStale)
+}
+
+func (p *PullRequestStorage) CloseStalePullRequests(ctx context.Context) ([]*models.PullRequestShort, error) {
+	p.log.Debug("Closing stale pull requests")
+
+	query := `
+		UPDATE pull_requests pr
+		SET status = 'CLOSED', closed_at = $1, updated_at = $1
+		FROM team_policies tp
+		WHERE tp.team_name = pr.team_name
+		AND tp.close_after_days > 0
+		AND pr.status = 'OPEN'
+		AND pr.stale_at IS NOT NULL
+		AND pr.stale_at < $1 - make_interval(days => tp.close_after_days)
+		RETURNING pr.repository, pr.id, pr.pull_request_name, pr.author_id, pr.status
+	`
+	return p.collectPullRequests(ctx, query, "closed", models.EventClosed)
+}
+
+// collectPullRequests runs a bulk status UPDATE ... RETURNING query and records an
+// event of eventType for every affected pull request in the same transaction.
+func (p *PullRequestStorage) collectPullRequests(ctx context.Context, query, kind string, eventType models.PullRequestEventType) ([]*models.PullRequestShort, error) {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		p.log.Error("Failed to begin transaction", "error", err, "kind", kind)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	rows, err := tx.Query(ctx, query, now)
+	if err != nil {
+		p.log.Error("Failed to update pull requests", "error", err, "kind", kind)
+		return nil, fmt.Errorf("failed to update %s pull requests: %w", kind, err)
+	}
+
+	var prs []*models.PullRequestShort
+	for rows.Next() {
+		pr := &models.PullRequestShort{}
+		if err := rows.Scan(&pr.Repository, &pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status); err != nil {
+			rows.Close()
+			p.log.Error("Failed to scan pull request", "error", err)
+			return nil, fmt.Errorf("failed to scan pull request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		p.log.Error("Failed to update pull requests", "error", err, "kind", kind)
+		return nil, fmt.Errorf("failed to update %s pull requests: %w", kind, err)
+	}
+
+	for _, pr := range prs {
+		before := map[string]any{"status": models.OPEN}
+		after := map[string]any{"status": pr.Status}
+		if eventType == models.EventMarkedStale {
+			before = nil
+			after = map[string]any{"stale_at": now}
+		}
+		if err := p.insertEvent(ctx, tx, pr.Repository, pr.PullRequestId, eventType, before, after); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		p.log.Error("Failed to commit transaction", "error", err, "kind", kind)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	p.log.Debug("Updated pull requests", "kind", kind, "count", len(prs))
+	return prs, nil
+}
+
+func (p *PullRequestStorage) GetStalePullRequests(ctx context.Context) ([]*models.PullRequest, error) {
+	p.log.Debug("Getting stale pull requests")
+
+	query := `
+		SELECT repository, id, pull_request_name, author_id, status, created_at, updated_at, stale_at
+		FROM pull_requests
+		WHERE status = 'OPEN' AND stale_at IS NOT NULL
+		ORDER BY stale_at
+	`
+
+	rows, err := p.db.Query(ctx, query)
+	if err != nil {
+		p.log.Error("Failed to get stale pull requests", "error", err)
+		return nil, fmt.Errorf("failed to get stale pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []*models.PullRequest{}
+	for rows.Next() {
+		pr := &models.PullRequest{}
+		if err := rows.Scan(&pr.Repository, &pr.PullRequestId, &pr.PullRequestName, &pr.AuthorId, &pr.Status, &pr.CreatedAt, &pr.UpdatedAt, &pr.StaleAt); err != nil {
+			p.log.Error("Failed to scan stale pull request", "error", err)
+			return nil, fmt.Errorf("failed to scan stale pull request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	p.log.Debug("Successfully retrieved stale pull requests", "count", len(prs))
+	return prs, nil
+}
+
 func (p *PullRequestStorage) GetReviewStatistics(ctx context.Context) (*models.ReviewStatistics, error) {
 	p.log.Debug("Getting review statistics")
 
@@ -307,11 +1024,19 @@ func (p *PullRequestStorage) GetReviewStatistics(ctx context.Context) (*models.R
 	}
 	stats.MergedPRs = mergedPRs
 
+	var closedPRs int
+	err = p.db.QueryRow(ctx, `SELECT COUNT(*) FROM pull_requests WHERE status = 'CLOSED'`).Scan(&closedPRs)
+	if err != nil {
+		p.log.Error("Failed to get closed PRs count", "error", err)
+		return nil, fmt.Errorf("failed to get closed PRs count: %w", err)
+	}
+	stats.ClosedPRs = closedPRs
+
 	reviewerRows, err := p.db.Query(ctx, `
 		SELECT u.id, u.username, COUNT(prr.pr_id) as assigned_count, MAX(pr.created_at) as last_assigned
 		FROM users u
 		LEFT JOIN pull_request_reviewers prr ON u.id = prr.user_id
-		LEFT JOIN pull_requests pr ON prr.pr_id = pr.id
+		LEFT JOIN pull_requests pr ON prr.repository = pr.repository AND prr.pr_id = pr.id
 		WHERE u.is_active = true
 		GROUP BY u.id, u.username
 		ORDER BY assigned_count DESC
@@ -344,16 +1069,47 @@ func (p *PullRequestStorage) GetReviewStatistics(ctx context.Context) (*models.R
 		stats.ReviewerStats = append(stats.ReviewerStats, reviewerStats)
 	}
 
+	// Deleted teams are known by the names kept in membership history and
+	// review attribution. Their pull requests lose the team on deletion.
 	teamRows, err := p.db.Query(ctx, `
+		WITH RECURSIVE all_teams(name, parent_name, deleted) AS (
+			SELECT name, parent_name, false FROM teams
+			UNION
+			SELECT h.team_name, NULL, true FROM (
+				SELECT team_name FROM pull_request_reviewers
+				UNION
+				SELECT team_name FROM team_membership_history
+			) h
+			WHERE h.team_name IS NOT NULL AND h.team_name NOT IN (SELECT name FROM teams)
+		), subtree(root, name) AS (
+			SELECT name, name FROM all_teams
+			UNION ALL
+			SELECT s.root, t.name FROM subtree s INNER JOIN teams t ON t.parent_name = s.name
+		)
 		SELECT 
 			t.name as team_name,
-			COUNT(u.id) as member_count,
-			COUNT(CASE WHEN u.is_active = true THEN 1 END) as active_member_count,
-			COUNT(pr.id) as prs_created
-		FROM teams t
-		LEFT JOIN users u ON t.name = u.team_name
-		LEFT JOIN pull_requests pr ON u.id = pr.author_id
-		GROUP BY t.name
+			COALESCE(t.parent_name, '') as parent_team,
+			t.deleted,
+			(SELECT COUNT(*) FROM team_memberships tm WHERE tm.team_name = t.name) as member_count,
+			(SELECT COUNT(*) FROM team_memberships tm
+				INNER JOIN users u ON u.id = tm.user_id
+				WHERE tm.team_name = t.name AND u.is_active = true) as active_member_count,
+			(SELECT COUNT(*) FROM pull_requests pr WHERE pr.team_name = t.name) as prs_created,
+			(SELECT COUNT(*) FROM pull_request_reviewers prr WHERE prr.team_name = t.name) as reviews_assigned,
+			(SELECT COUNT(DISTINCT tm.user_id) FROM subtree s
+				INNER JOIN team_memberships tm ON tm.team_name = s.name
+				WHERE s.root = t.name) as subtree_member_count,
+			(SELECT COUNT(DISTINCT tm.user_id) FROM subtree s
+				INNER JOIN team_memberships tm ON tm.team_name = s.name
+				INNER JOIN users u ON u.id = tm.user_id
+				WHERE s.root = t.name AND u.is_active = true) as subtree_active_member_count,
+			(SELECT COUNT(*) FROM subtree s
+				INNER JOIN pull_requests pr ON pr.team_name = s.name
+				WHERE s.root = t.name) as subtree_prs_created,
+			(SELECT COUNT(*) FROM subtree s
+				INNER JOIN pull_request_reviewers prr ON prr.team_name = s.name
+				WHERE s.root = t.name) as subtree_reviews_assigned
+		FROM all_teams t
 		ORDER BY team_name
 	`)
 	if err != nil {
@@ -367,9 +1123,16 @@ func (p *PullRequestStorage) GetReviewStatistics(ctx context.Context) (*models.R
 
 		err := teamRows.Scan(
 			&teamStats.TeamName,
+			&teamStats.ParentTeam,
+			&teamStats.Deleted,
 			&teamStats.MemberCount,
 			&teamStats.ActiveMemberCount,
 			&teamStats.PRsCreated,
+			&teamStats.ReviewsAssigned,
+			&teamStats.SubtreeMemberCount,
+			&teamStats.SubtreeActiveMemberCount,
+			&teamStats.SubtreePRsCreated,
+			&teamStats.SubtreeReviewsAssigned,
 		)
 		if err != nil {
 			p.log.Error("Failed to scan team statistics row", "error", err)
@@ -393,10 +1156,11 @@ func (p *PullRequestStorage) GetActiveTeamMembers(ctx context.Context, teamName
 	p.log.Debug("Getting active team members", "team_name", teamName, "exclude_user", excludeUser)
 
 	query := `
-		SELECT id, username, is_active 
-		FROM users 
-		WHERE team_name = $1 AND is_active = true AND id != $2
-		ORDER BY username
+		SELECT u.id, u.username, u.is_active 
+		FROM users u
+		INNER JOIN team_memberships tm ON tm.user_id = u.id
+		WHERE tm.team_name = $1 AND tm.role != 'observer' AND u.is_active = true AND u.id != $2
+		ORDER BY u.username
 	`
 
 	rows, err := p.db.Query(ctx, query, teamName, excludeUser)
@@ -419,3 +1183,171 @@ func (p *PullRequestStorage) GetActiveTeamMembers(ctx context.Context, teamName
 	p.log.Debug("Successfully retrieved active team members", "team_name", teamName, "count", len(users))
 	return users, nil
 }
+
+func (p *PullRequestStorage) GetOpenReviewCounts(ctx context.Context, userIDs []string) (map[string]int, error) {
+	p.log.Debug("Getting open review counts", "users_count", len(userIDs))
+
+	query := `
+		SELECT prr.user_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.repository = prr.repository AND pr.id = prr.pr_id
+		WHERE pr.status = 'OPEN' AND prr.user_id = ANY($1)
+		GROUP BY prr.user_id
+	`
+
+	rows, err := p.db.Query(ctx, query, userIDs)
+	if err != nil {
+		p.log.Error("Failed to get open review counts", "error", err)
+		return nil, fmt.Errorf("failed to get open review counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(userIDs))
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			p.log.Error("Failed to scan open review count", "error", err)
+			return nil, fmt.Errorf("failed to scan open review count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	p.log.Debug("Successfully retrieved open review counts", "users_count", len(counts))
+	return counts, nil
+}
+
+// GetPullRequestsByKeys returns the pull requests with the given keys together
+// with their assigned reviewers, in no particular order. Unknown pull requests
+// are left out. SLA actions are not loaded.
+func (p *PullRequestStorage) GetPullRequestsByKeys(ctx context.Context, keys []models.PullRequestKey) ([]*models.PullRequest, error) {
+	p.log.Debug("Getting pull requests by keys", "count", len(keys))
+
+	repositories, ids := splitPullRequestKeys(keys)
+	query := `
+		SELECT pr.id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at, pr.updated_at,
+			pr.stale_at, pr.closed_at, pr.repository, pr.labels, pr.priority, pr.url, pr.additions, pr.deletions,
+			pr.changed_files, COALESCE(pr.team_name, ''), pr.version,
+			ARRAY(
+				SELECT prr.user_id FROM pull_request_reviewers prr
+				WHERE prr.repository = pr.repository AND prr.pr_id = pr.id
+				ORDER BY prr.assigned_at, prr.user_id
+			)
+		FROM pull_requests pr
+		INNER JOIN UNNEST($1::text[], $2::text[]) AS k(repository, id) ON pr.repository = k.repository AND pr.id = k.id
+	`
+	rows, err := p.db.Query(ctx, query, repositories, ids)
+	if err != nil {
+		p.log.Error("Failed to get pull requests by keys", "error", err)
+		return nil, fmt.Errorf("failed to get pull requests by keys: %w", err)
+	}
+	prs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.PullRequest, error) {
+		pr := &models.PullRequest{}
+		err := row.Scan(
+			&pr.PullRequestId,
+			&pr.PullRequestName,
+			&pr.AuthorId,
+			&pr.Status,
+			&pr.CreatedAt,
+			&pr.MergedAt,
+			&pr.UpdatedAt,
+			&pr.StaleAt,
+			&pr.ClosedAt,
+			&pr.Repository,
+			&pr.Labels,
+			&pr.Priority,
+			&pr.URL,
+			&pr.Additions,
+			&pr.Deletions,
+			&pr.ChangedFiles,
+			&pr.TeamName,
+			&pr.Version,
+			&pr.AssignedReviewers,
+		)
+		return pr, err
+	})
+	if err != nil {
+		p.log.Error("Failed to get pull requests by keys", "error", err)
+		return nil, fmt.Errorf("failed to get pull requests by keys: %w", err)
+	}
+
+	p.log.Debug("Successfully retrieved pull requests by keys", "count", len(prs))
+	return prs, nil
+}
+
+// GetReviewAssignmentsByReviewers returns the current review assignments of
+// the given reviewers, newest first.
+func (p *PullRequestStorage) GetReviewAssignmentsByReviewers(ctx context.Context, reviewerIDs []string) ([]*models.ReviewAssignment, error) {
+	p.log.Debug("Getting review assignments by reviewers", "count", len(reviewerIDs))
+
+	assignments, err := p.queryReviewAssignments(ctx, `
+		WHERE prr.user_id = ANY($1)
+		ORDER BY prr.assigned_at DESC, prr.repository, prr.pr_id
+	`, reviewerIDs)
+	if err != nil {
+		p.log.Error("Failed to get review assignments by reviewers", "error", err)
+		return nil, fmt.Errorf("failed to get review assignments by reviewers: %w", err)
+	}
+
+	p.log.Debug("Successfully retrieved review assignments by reviewers", "count", len(assignments))
+	return assignments, nil
+}
+
+// GetReviewAssignmentsByPullRequests returns the review assignments of the
+// given pull requests in the order the reviewers were assigned.
+func (p *PullRequestStorage) GetReviewAssignmentsByPullRequests(ctx context.Context, keys []models.PullRequestKey) ([]*models.ReviewAssignment, error) {
+	p.log.Debug("Getting review assignments by pull requests", "count", len(keys))
+
+	repositories, ids := splitPullRequestKeys(keys)
+	assignments, err := p.queryReviewAssignments(ctx, `
+		INNER JOIN UNNEST($1::text[], $2::text[]) AS k(repository, id) ON prr.repository = k.repository AND prr.pr_id = k.id
+		ORDER BY prr.assigned_at, prr.user_id
+	`, repositories, ids)
+	if err != nil {
+		p.log.Error("Failed to get review assignments by pull requests", "error", err)
+		return nil, fmt.Errorf("failed to get review assignments by pull requests: %w", err)
+	}
+
+	p.log.Debug("Successfully retrieved review assignments by pull requests", "count", len(assignments))
+	return assignments, nil
+}
+
+// queryReviewAssignments selects review assignments; filter is appended after
+// the join with the pull requests.
+func (p *PullRequestStorage) queryReviewAssignments(ctx context.Context, filter string, args ...any) ([]*models.ReviewAssignment, error) {
+	query := `
+		SELECT prr.repository, prr.pr_id, pr.status, prr.user_id, COALESCE(prr.team_name, ''),
+			prr.assigned_at, prr.responded_at, COALESCE(prr.verdict, ''), prr.escalated_at
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.repository = prr.repository AND pr.id = prr.pr_id
+	` + filter
+	rows, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.ReviewAssignment, error) {
+		a := &models.ReviewAssignment{}
+		err := row.Scan(
+			&a.Repository,
+			&a.PullRequestId,
+			&a.PullRequestStatus,
+			&a.ReviewerId,
+			&a.TeamName,
+			&a.AssignedAt,
+			&a.RespondedAt,
+			&a.Verdict,
+			&a.EscalatedAt,
+		)
+		return a, err
+	})
+}
+
+func splitPullRequestKeys(keys []models.PullRequestKey) ([]string, []string) {
+	repositories := make([]string, len(keys))
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		repositories[i] = key.Repository
+		ids[i] = key.PullRequestId
+	}
+	return repositories, ids
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"avito-autumn-2025/internal/actor"
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// insertEvent appends an entry to the pull request timeline inside tx. The actor
+// is taken from ctx.
+func (p *PullRequestStorage) insertEvent(ctx context.Context, tx pgx.Tx, repository, prID string, eventType models.PullRequestEventType, before, after map[string]any) error {
+	query := `
+		INSERT INTO pull_request_events (repository, pr_id, event_type, actor, before, after, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := tx.Exec(ctx, query, repository, prID, eventType, actor.FromContext(ctx), before, after, time.Now())
+	if err != nil {
+		p.log.Error("Failed to record pull request event", "error", err, "repository", repository, "pr_id", prID, "event_type", eventType)
+		return fmt.Errorf("failed to record pull request event: %w", err)
+	}
+	return nil
+}
+
+func (p *PullRequestStorage) GetPullRequestEvents(ctx context.Context, repository, prID string) ([]*models.PullRequestEvent, error) {
+	p.log.Debug("Getting pull request events", "repository", repository, "pr_id", prID)
+
+	var exists bool
+	err := p.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE repository = $1 AND id = $2)`, repository, prID).Scan(&exists)
+	if err != nil {
+		p.log.Error("Failed to check pull request existence", "error", err, "pr_id", prID)
+		return nil, fmt.Errorf("failed to check pull request existence: %w", err)
+	}
+	if !exists {
+		p.log.Warn("Pull request not found", "repository", repository, "pr_id", prID)
+		return nil, apperr.NotFound(apperr.CodePullRequestNotFound, "pull request %s not found", prID)
+	}
+
+	query := `
+		SELECT id, repository, pr_id, event_type, actor, before, after, created_at
+		FROM pull_request_events
+		WHERE repository = $1 AND pr_id = $2
+		ORDER BY id
+	`
+	rows, err := p.db.Query(ctx, query, repository, prID)
+	if err != nil {
+		p.log.Error("Failed to get pull request events", "error", err, "pr_id", prID)
+		return nil, fmt.Errorf("failed to get pull request events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.PullRequestEvent{}
+	for rows.Next() {
+		event := &models.PullRequestEvent{}
+		err := rows.Scan(
+			&event.Id,
+			&event.Repository,
+			&event.PullRequestId,
+			&event.Type,
+			&event.Actor,
+			&event.Before,
+			&event.After,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			p.log.Error("Failed to scan pull request event", "error", err)
+			return nil, fmt.Errorf("failed to scan pull request event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	p.log.Debug("Successfully retrieved pull request events", "pr_id", prID, "count", len(events))
+	return events, nil
+}
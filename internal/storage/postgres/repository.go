@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RepositoryStorage struct {
+	db  *pgxpool.Pool
+	log logger.Logger
+}
+
+func NewRepositoryStorage(db *pgxpool.Pool, log logger.Logger) RepositoryStorage {
+	return RepositoryStorage{db: db, log: log}
+}
+
+func (r *RepositoryStorage) UpsertRepository(ctx context.Context, repo *models.Repository) (*models.Repository, error) {
+	r.log.Info("Upserting repository", "repository", repo.Name, "reviewers_count", len(repo.Reviewers))
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		r.log.Error("Failed to begin transaction for repository upsert", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO repositories (name, reviewers_count, fallback_to_author_team, team_name)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		ON CONFLICT (name) DO UPDATE SET
+			reviewers_count = EXCLUDED.reviewers_count,
+			fallback_to_author_team = EXCLUDED.fallback_to_author_team,
+			team_name = EXCLUDED.team_name
+	`
+	_, err = tx.Exec(ctx, query, repo.Name, repo.ReviewersCount, repo.FallbackToAuthorTeam, repo.TeamName)
+	if err != nil {
+		r.log.Error("Failed to upsert repository", "error", err, "repository", repo.Name)
+		return nil, fmt.Errorf("failed to upsert repository: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM repository_reviewers WHERE repository = $1`, repo.Name)
+	if err != nil {
+		r.log.Error("Failed to clear repository reviewer pool", "error", err, "repository", repo.Name)
+		return nil, fmt.Errorf("failed to clear repository reviewer pool: %w", err)
+	}
+
+	for _, userID := range repo.Reviewers {
+		result, err := tx.Exec(ctx, `
+			INSERT INTO repository_reviewers (repository, user_id)
+			SELECT $1, id FROM users WHERE id = $2 AND deleted_at IS NULL
+		`, repo.Name, userID)
+		if err != nil {
+			r.log.Error("Failed to add repository reviewer", "error", err, "repository", repo.Name, "user_id", userID)
+			return nil, fmt.Errorf("failed to add repository reviewer %s: %w", userID, err)
+		}
+		if result.RowsAffected() == 0 {
+			r.log.Warn("Repository reviewer not found", "repository", repo.Name, "user_id", userID)
+			return nil, apperr.NotFound(apperr.CodeUserNotFound, "user %s not found", userID)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		r.log.Error("Failed to commit repository upsert transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.log.Info("Successfully upserted repository", "repository", repo.Name)
+	return r.GetRepository(ctx, repo.Name)
+}
+
+func (r *RepositoryStorage) GetRepository(ctx context.Context, name string) (*models.Repository, error) {
+	r.log.Debug("Getting repository", "repository", name)
+
+	repo := &models.Repository{}
+	query := `SELECT name, reviewers_count, fallback_to_author_team, COALESCE(team_name, '') FROM repositories WHERE name = $1`
+	err := r.db.QueryRow(ctx, query, name).Scan(&repo.Name, &repo.ReviewersCount, &repo.FallbackToAuthorTeam, &repo.TeamName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			r.log.Debug("Repository not found", "repository", name)
+			return nil, nil
+		}
+		r.log.Error("Failed to get repository", "error", err, "repository", name)
+		return nil, fmt.Errorf("failed to get repository: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT user_id FROM repository_reviewers WHERE repository = $1 ORDER BY user_id`, name)
+	if err != nil {
+		r.log.Error("Failed to get repository reviewers", "error", err, "repository", name)
+		return nil, fmt.Errorf("failed to get repository reviewers: %w", err)
+	}
+	defer rows.Close()
+
+	repo.Reviewers = []string{}
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			r.log.Error("Failed to scan repository reviewer", "error", err)
+			return nil, fmt.Errorf("failed to scan repository reviewer: %w", err)
+		}
+		repo.Reviewers = append(repo.Reviewers, userID)
+	}
+
+	r.log.Debug("Successfully retrieved repository", "repository", name, "reviewers_count", len(repo.Reviewers))
+	return repo, nil
+}
+
+func (r *RepositoryStorage) GetActivePoolMembers(ctx context.Context, name string, excludeUser string) ([]*models.User, error) {
+	r.log.Debug("Getting active repository pool members", "repository", name, "exclude_user", excludeUser)
+
+	query := `
+		SELECT u.id, u.username, u.is_active
+		FROM users u
+		INNER JOIN repository_reviewers rr ON rr.user_id = u.id
+		WHERE rr.repository = $1 AND u.is_active = true AND u.id != $2
+		ORDER BY u.username
+	`
+
+	rows, err := r.db.Query(ctx, query, name, excludeUser)
+	if err != nil {
+		r.log.Error("Failed to get active repository pool members", "error", err, "repository", name)
+		return nil, fmt.Errorf("failed to get active repository pool members: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		user := &models.User{}
+		if err := rows.Scan(&user.Id, &user.Username, &user.IsActive); err != nil {
+			r.log.Error("Failed to scan user", "error", err)
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	r.log.Debug("Successfully retrieved active repository pool members", "repository", name, "count", len(users))
+	return users, nil
+}
@@ -1,18 +1,40 @@
 package postgres
 
 import (
+	"avito-autumn-2025/internal/apperr"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const upsertTeamPolicyQuery = `
+	INSERT INTO team_policies (team_name, first_response_hours, business_hours_only, sla_action, escalation_reviewer_id,
+		stale_after_days, close_after_days, workday_start, workday_end, time_zone)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	ON CONFLICT (team_name) DO UPDATE SET
+		first_response_hours = EXCLUDED.first_response_hours,
+		business_hours_only = EXCLUDED.business_hours_only,
+		workday_start = EXCLUDED.workday_start,
+		workday_end = EXCLUDED.workday_end,
+		time_zone = EXCLUDED.time_zone,
+		sla_action = EXCLUDED.sla_action,
+		escalation_reviewer_id = EXCLUDED.escalation_reviewer_id,
+		stale_after_days = EXCLUDED.stale_after_days,
+		close_after_days = EXCLUDED.close_after_days
+`
+
 type TeamStorage struct {
 	db          *pgxpool.Pool
 	log         logger.Logger
 	userStorage UserStorage
+	prStorage   PullRequestStorage
 }
 
 func NewTeamStorage(db *pgxpool.Pool, log logger.Logger) TeamStorage {
@@ -20,6 +42,7 @@ func NewTeamStorage(db *pgxpool.Pool, log logger.Logger) TeamStorage {
 		db:          db,
 		log:         log,
 		userStorage: NewUserStorage(db, log),
+		prStorage:   NewPullRequestStorage(db, log),
 	}
 }
 
@@ -33,37 +56,32 @@ func (t *TeamStorage) CreateTeam(ctx context.Context, team *models.Team) (*model
 	}
 	defer tx.Rollback(ctx)
 
-	query := `INSERT INTO teams (name) VALUES($1) RETURNING name`
+	query := `INSERT INTO teams (name, parent_name) VALUES($1, NULLIF($2, '')) RETURNING name, COALESCE(parent_name, '')`
 	var createdTeam models.Team
-	err = tx.QueryRow(ctx, query, team.Name).Scan(&createdTeam.Name)
+	err = tx.QueryRow(ctx, query, team.Name, team.ParentTeam).Scan(&createdTeam.Name, &createdTeam.ParentTeam)
 	if err != nil {
+		if isUniqueViolation(err) {
+			t.log.Warn("Team already exists", "team_name", team.Name)
+			return nil, apperr.Conflict(apperr.CodeTeamExists, "team %s already exists", team.Name)
+		}
 		t.log.Error("Failed to create team", "error", err, "team_name", team.Name)
 		return nil, fmt.Errorf("failed to create team: %w", err)
 	}
 	createdTeam.Id = createdTeam.Name
 
 	for _, member := range team.Users {
-		user := &models.User{
-			Id:       member.Id,
-			Username: member.Username,
-			IsActive: member.IsActive,
-		}
-
-		upsertQuery := `
-			INSERT INTO users (id, username, is_active, team_name) 
-			VALUES($1, $2, $3, $4) 
-			ON CONFLICT (id) DO UPDATE SET 
-				username = EXCLUDED.username,
-				is_active = EXCLUDED.is_active,
-				team_name = EXCLUDED.team_name
-		`
-		_, err = tx.Exec(ctx, upsertQuery, user.Id, user.Username, user.IsActive, createdTeam.Name)
-		if err != nil {
-			t.log.Error("Failed to upsert team member", "error", err, "user_id", user.Id, "team_name", createdTeam.Name)
-			return nil, fmt.Errorf("failed to upsert team member %s: %w", user.Id, err)
+		if err := t.upsertMember(ctx, tx, createdTeam.Name, member, false); err != nil {
+			return nil, err
 		}
 	}
 
+	// Adding members bumps the version
+	err = tx.QueryRow(ctx, `SELECT version FROM teams WHERE name = $1`, createdTeam.Name).Scan(&createdTeam.Version)
+	if err != nil {
+		t.log.Error("Failed to get team version", "error", err, "team_name", createdTeam.Name)
+		return nil, fmt.Errorf("failed to get team version: %w", err)
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		t.log.Error("Failed to commit team creation transaction", "error", err)
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
@@ -83,19 +101,19 @@ func (t *TeamStorage) CreateTeam(ctx context.Context, team *models.Team) (*model
 func (t *TeamStorage) GetTeamWithMembers(ctx context.Context, teamName string) (*models.Team, error) {
 	t.log.Debug("Getting team with members", "team_name", teamName)
 
-	var exists bool
-	checkQuery := `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)`
-	err := t.db.QueryRow(ctx, checkQuery, teamName).Scan(&exists)
+	var parentTeam string
+	var version int64
+	checkQuery := `SELECT COALESCE(parent_name, ''), version FROM teams WHERE name = $1`
+	err := t.db.QueryRow(ctx, checkQuery, teamName).Scan(&parentTeam, &version)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			t.log.Warn("Team not found", "team_name", teamName)
+			return nil, apperr.NotFound(apperr.CodeTeamNotFound, "team %s not found", teamName)
+		}
 		t.log.Error("Failed to check team existence", "error", err, "team_name", teamName)
 		return nil, fmt.Errorf("failed to check team existence: %w", err)
 	}
 
-	if !exists {
-		t.log.Warn("Team not found", "team_name", teamName)
-		return nil, fmt.Errorf("team %s not found", teamName)
-	}
-
 	members, err := t.userStorage.GetUsersByTeam(ctx, teamName)
 	if err != nil {
 		t.log.Error("Failed to get team members", "error", err, "team_name", teamName)
@@ -103,11 +121,916 @@ func (t *TeamStorage) GetTeamWithMembers(ctx context.Context, teamName string) (
 	}
 
 	team := &models.Team{
-		Id:    teamName,
-		Name:  teamName,
-		Users: members,
+		Id:         teamName,
+		Name:       teamName,
+		ParentTeam: parentTeam,
+		Users:      members,
+		Version:    version,
 	}
 
 	t.log.Debug("Successfully retrieved team with members", "team_name", teamName, "members_count", len(members))
 	return team, nil
 }
+
+// ListTeams returns every team with its members, ordered by name.
+func (t *TeamStorage) ListTeams(ctx context.Context) ([]*models.Team, error) {
+	t.log.Debug("Listing teams")
+
+	teams, err := t.queryTeams(ctx, `SELECT name, COALESCE(parent_name, ''), version FROM teams ORDER BY name`)
+	if err != nil {
+		t.log.Error("Failed to list teams", "error", err)
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	byName := make(map[string]*models.Team, len(teams))
+	for _, team := range teams {
+		team.Users = []*models.User{}
+		byName[team.Name] = team
+	}
+
+	members, err := t.listTeamMembers(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for teamName, users := range members {
+		if team, ok := byName[teamName]; ok {
+			team.Users = users
+		}
+	}
+
+	t.log.Debug("Successfully listed teams", "count", len(teams))
+	return teams, nil
+}
+
+// SearchTeams returns a page of teams with their members ordered by name and
+// the number of teams matching the query.
+func (t *TeamStorage) SearchTeams(ctx context.Context, query *models.ListQuery) ([]*models.Team, int, error) {
+	t.log.Debug("Searching teams", "name", query.Name, "offset", query.Offset, "limit", query.Limit)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM teams WHERE $1 = '' OR LOWER(name) = LOWER($1)`
+	if err := t.db.QueryRow(ctx, countQuery, query.Name).Scan(&total); err != nil {
+		t.log.Error("Failed to count teams", "error", err)
+		return nil, 0, fmt.Errorf("failed to count teams: %w", err)
+	}
+
+	pageQuery := `
+		SELECT name, COALESCE(parent_name, ''), version FROM teams
+		WHERE $1 = '' OR LOWER(name) = LOWER($1)
+		ORDER BY name
+		OFFSET $2 LIMIT $3
+	`
+	teams, err := t.queryTeams(ctx, pageQuery, query.Name, query.Offset, query.Limit)
+	if err != nil {
+		t.log.Error("Failed to search teams", "error", err)
+		return nil, 0, fmt.Errorf("failed to search teams: %w", err)
+	}
+	if len(teams) == 0 {
+		return teams, total, nil
+	}
+
+	names := make([]string, 0, len(teams))
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+	members, err := t.listTeamMembers(ctx, names)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, team := range teams {
+		team.Users = members[team.Name]
+		if team.Users == nil {
+			team.Users = []*models.User{}
+		}
+	}
+
+	t.log.Debug("Successfully searched teams", "count", len(teams), "total", total)
+	return teams, total, nil
+}
+
+// GetTeamsByNames returns the teams with the given names, without members, in
+// no particular order. Unknown teams are left out.
+func (t *TeamStorage) GetTeamsByNames(ctx context.Context, names []string) ([]*models.Team, error) {
+	t.log.Debug("Getting teams by names", "count", len(names))
+
+	query := `SELECT name, COALESCE(parent_name, ''), version FROM teams WHERE name = ANY($1)`
+	teams, err := t.queryTeams(ctx, query, names)
+	if err != nil {
+		t.log.Error("Failed to get teams by names", "error", err)
+		return nil, fmt.Errorf("failed to get teams by names: %w", err)
+	}
+
+	t.log.Debug("Successfully retrieved teams by names", "count", len(teams))
+	return teams, nil
+}
+
+// GetSubteams returns the direct subteams of the given teams, without members,
+// ordered by name.
+func (t *TeamStorage) GetSubteams(ctx context.Context, parentNames []string) ([]*models.Team, error) {
+	t.log.Debug("Getting subteams", "count", len(parentNames))
+
+	query := `SELECT name, COALESCE(parent_name, ''), version FROM teams WHERE parent_name = ANY($1) ORDER BY name`
+	teams, err := t.queryTeams(ctx, query, parentNames)
+	if err != nil {
+		t.log.Error("Failed to get subteams", "error", err)
+		return nil, fmt.Errorf("failed to get subteams: %w", err)
+	}
+
+	t.log.Debug("Successfully retrieved subteams", "count", len(teams))
+	return teams, nil
+}
+
+// GetTeamMembers returns the members of the given teams keyed by team name,
+// each list ordered by username. Teams without members are left out.
+func (t *TeamStorage) GetTeamMembers(ctx context.Context, teamNames []string) (map[string][]*models.User, error) {
+	t.log.Debug("Getting team members", "count", len(teamNames))
+
+	members, err := t.listTeamMembers(ctx, teamNames)
+	if err != nil {
+		return nil, err
+	}
+
+	t.log.Debug("Successfully retrieved team members", "count", len(members))
+	return members, nil
+}
+
+func (t *TeamStorage) queryTeams(ctx context.Context, query string, args ...any) ([]*models.Team, error) {
+	rows, err := t.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.Team, error) {
+		team := &models.Team{}
+		err := row.Scan(&team.Name, &team.ParentTeam, &team.Version)
+		team.Id = team.Name
+		return team, err
+	})
+}
+
+// listTeamMembers loads the members of the given teams, or of every team when
+// teamNames is nil, keyed by team name.
+func (t *TeamStorage) listTeamMembers(ctx context.Context, teamNames []string) (map[string][]*models.User, error) {
+	query := `
+		SELECT tm.team_name, u.id, u.username, u.is_active, COALESCE(p.team_name, ''), tm.role
+		FROM team_memberships tm
+		INNER JOIN users u ON u.id = tm.user_id
+		LEFT JOIN team_memberships p ON p.user_id = u.id AND p.is_primary
+		WHERE $1::text[] IS NULL OR tm.team_name = ANY($1)
+		ORDER BY tm.team_name, u.username
+	`
+	rows, err := t.db.Query(ctx, query, teamNames)
+	if err != nil {
+		t.log.Error("Failed to list team members", "error", err)
+		return nil, fmt.Errorf("failed to list team members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make(map[string][]*models.User)
+	for rows.Next() {
+		var teamName string
+		user := &models.User{}
+		if err := rows.Scan(&teamName, &user.Id, &user.Username, &user.IsActive, &user.TeamName, &user.Role); err != nil {
+			t.log.Error("Failed to scan team member", "error", err)
+			return nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+		members[teamName] = append(members[teamName], user)
+	}
+	if err := rows.Err(); err != nil {
+		t.log.Error("Failed to list team members", "error", err)
+		return nil, fmt.Errorf("failed to list team members: %w", err)
+	}
+	return members, nil
+}
+
+func (t *TeamStorage) GetTeamPolicy(ctx context.Context, teamName string) (*models.TeamPolicy, error) {
+	t.log.Debug("Getting team policy", "team_name", teamName)
+
+	query := `
+		SELECT team_name, first_response_hours, business_hours_only, sla_action, escalation_reviewer_id,
+			stale_after_days, close_after_days, workday_start, workday_end, time_zone
+		FROM team_policies
+		WHERE team_name = $1
+	`
+
+	policy := &models.TeamPolicy{}
+	var escalationReviewerID sql.NullString
+	err := t.db.QueryRow(ctx, query, teamName).Scan(
+		&policy.TeamName,
+		&policy.FirstResponseHours,
+		&policy.BusinessHoursOnly,
+		&policy.SLAAction,
+		&escalationReviewerID,
+		&policy.StaleAfterDays,
+		&policy.CloseAfterDays,
+		&policy.WorkdayStart,
+		&policy.WorkdayEnd,
+		&policy.TimeZone,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			t.log.Debug("Team policy not found", "team_name", teamName)
+			return nil, nil
+		}
+		t.log.Error("Failed to get team policy", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get team policy: %w", err)
+	}
+	policy.EscalationReviewerId = escalationReviewerID.String
+
+	t.log.Debug("Successfully retrieved team policy", "team_name", teamName)
+	return policy, nil
+}
+
+func (t *TeamStorage) UpsertTeamPolicy(ctx context.Context, policy *models.TeamPolicy) (*models.TeamPolicy, error) {
+	t.log.Info("Upserting team policy", "team_name", policy.TeamName, "sla_action", policy.SLAAction)
+
+	var escalationReviewerID sql.NullString
+	if policy.EscalationReviewerId != "" {
+		escalationReviewerID = sql.NullString{String: policy.EscalationReviewerId, Valid: true}
+	}
+
+	_, err := t.db.Exec(ctx, upsertTeamPolicyQuery, policy.TeamName, policy.FirstResponseHours, policy.BusinessHoursOnly, policy.SLAAction, escalationReviewerID,
+		policy.StaleAfterDays, policy.CloseAfterDays, policy.WorkdayStart, policy.WorkdayEnd, policy.TimeZone)
+	if err != nil {
+		t.log.Error("Failed to upsert team policy", "error", err, "team_name", policy.TeamName)
+		return nil, fmt.Errorf("failed to upsert team policy: %w", err)
+	}
+
+	t.log.Info("Successfully upserted team policy", "team_name", policy.TeamName)
+	return t.GetTeamPolicy(ctx, policy.TeamName)
+}
+
+// GetTeamWithDescendants returns the team with members and, recursively, its subteams.
+func (t *TeamStorage) GetTeamWithDescendants(ctx context.Context, teamName string) (*models.Team, error) {
+	t.log.Debug("Getting team with descendants", "team_name", teamName)
+
+	team, err := t.GetTeamWithMembers(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := t.db.Query(ctx, `SELECT name FROM teams WHERE parent_name = $1 ORDER BY name`, teamName)
+	if err != nil {
+		t.log.Error("Failed to get subteams", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get subteams: %w", err)
+	}
+	children, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		t.log.Error("Failed to get subteams", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get subteams: %w", err)
+	}
+
+	for _, child := range children {
+		subteam, err := t.GetTeamWithDescendants(ctx, child)
+		if err != nil {
+			return nil, err
+		}
+		team.Subteams = append(team.Subteams, subteam)
+	}
+
+	return team, nil
+}
+
+// GetTeamAncestors returns the parent chain of the team, nearest parent first.
+func (t *TeamStorage) GetTeamAncestors(ctx context.Context, teamName string) ([]string, error) {
+	t.log.Debug("Getting team ancestors", "team_name", teamName)
+
+	query := `
+		WITH RECURSIVE ancestors(name, depth) AS (
+			SELECT parent_name, 1 FROM teams WHERE name = $1 AND parent_name IS NOT NULL
+			UNION ALL
+			SELECT t.parent_name, a.depth + 1
+			FROM ancestors a
+			INNER JOIN teams t ON t.name = a.name
+			WHERE t.parent_name IS NOT NULL
+		)
+		SELECT name FROM ancestors ORDER BY depth
+	`
+	rows, err := t.db.Query(ctx, query, teamName)
+	if err != nil {
+		t.log.Error("Failed to get team ancestors", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get team ancestors: %w", err)
+	}
+	ancestors, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		t.log.Error("Failed to get team ancestors", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get team ancestors: %w", err)
+	}
+
+	return ancestors, nil
+}
+
+// IsTeamLead reports whether the user leads the team or one of its ancestors.
+func (t *TeamStorage) IsTeamLead(ctx context.Context, teamName, userID string) (bool, error) {
+	query := `
+		WITH RECURSIVE chain(name) AS (
+			SELECT name FROM teams WHERE name = $1
+			UNION ALL
+			SELECT t.parent_name FROM chain c
+			INNER JOIN teams t ON t.name = c.name
+			WHERE t.parent_name IS NOT NULL
+		)
+		SELECT EXISTS(
+			SELECT 1 FROM team_memberships tm
+			INNER JOIN chain c ON c.name = tm.team_name
+			WHERE tm.user_id = $2 AND tm.role = 'lead'
+		)
+	`
+	var lead bool
+	if err := t.db.QueryRow(ctx, query, teamName, userID).Scan(&lead); err != nil {
+		t.log.Error("Failed to check team lead", "error", err, "team_name", teamName, "user_id", userID)
+		return false, fmt.Errorf("failed to check team lead: %w", err)
+	}
+	return lead, nil
+}
+
+// GetMembershipHistory lists the membership intervals of the team, oldest first.
+func (t *TeamStorage) GetMembershipHistory(ctx context.Context, teamName string) ([]*models.TeamMembershipInterval, error) {
+	t.log.Debug("Getting team membership history", "team_name", teamName)
+
+	// The history of a deleted team outlives it
+	var exists bool
+	existsQuery := `
+		SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)
+			OR EXISTS(SELECT 1 FROM team_membership_history WHERE team_name = $1)
+	`
+	err := t.db.QueryRow(ctx, existsQuery, teamName).Scan(&exists)
+	if err != nil {
+		t.log.Error("Failed to check team existence", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		t.log.Warn("Team not found", "team_name", teamName)
+		return nil, apperr.NotFound(apperr.CodeTeamNotFound, "team %s not found", teamName)
+	}
+
+	query := `
+		SELECT team_name, user_id, joined_at, left_at
+		FROM team_membership_history
+		WHERE team_name = $1
+		ORDER BY joined_at, id
+	`
+	rows, err := t.db.Query(ctx, query, teamName)
+	if err != nil {
+		t.log.Error("Failed to get team membership history", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get team membership history: %w", err)
+	}
+	defer rows.Close()
+
+	history := []*models.TeamMembershipInterval{}
+	for rows.Next() {
+		interval := &models.TeamMembershipInterval{}
+		if err := rows.Scan(&interval.TeamName, &interval.UserId, &interval.JoinedAt, &interval.LeftAt); err != nil {
+			t.log.Error("Failed to scan membership interval", "error", err)
+			return nil, fmt.Errorf("failed to scan membership interval: %w", err)
+		}
+		history = append(history, interval)
+	}
+	if err := rows.Err(); err != nil {
+		t.log.Error("Failed to get team membership history", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get team membership history: %w", err)
+	}
+
+	return history, nil
+}
+
+// UpdateTeam renames or moves the team. A non-zero version must be the current
+// version of the team.
+func (t *TeamStorage) UpdateTeam(ctx context.Context, teamName string, update *models.TeamUpdate, version int64) (*models.Team, error) {
+	t.log.Info("Updating team", "team_name", teamName, "new_team_name", update.Name)
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("Failed to begin transaction for team update", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := t.lockTeam(ctx, tx, teamName, version); err != nil {
+		return nil, err
+	}
+
+	if update.Name != "" && update.Name != teamName {
+		if err := t.renameTeam(ctx, tx, teamName, update.Name); err != nil {
+			return nil, err
+		}
+		teamName = update.Name
+	}
+
+	if update.ParentTeam != nil {
+		if err := t.setParentTeam(ctx, tx, teamName, *update.ParentTeam); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		t.log.Error("Failed to commit team update transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	t.log.Info("Successfully updated team", "team_name", teamName)
+	return t.GetTeamWithMembers(ctx, teamName)
+}
+
+// renameTeam renames the team to name, which must be free.
+func (t *TeamStorage) renameTeam(ctx context.Context, tx pgx.Tx, teamName, name string) error {
+	var exists bool
+	err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)`, name).Scan(&exists)
+	if err != nil {
+		t.log.Error("Failed to check team existence", "error", err, "team_name", name)
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if exists {
+		t.log.Warn("Team already exists", "team_name", name)
+		return apperr.Conflict(apperr.CodeTeamExists, "team %s already exists", name)
+	}
+
+	// Memberships, policies, repositories and pull requests follow through ON UPDATE CASCADE.
+	_, err = tx.Exec(ctx, `UPDATE teams SET name = $1 WHERE name = $2`, name, teamName)
+	if err != nil {
+		if isUniqueViolation(err) {
+			t.log.Warn("Team already exists", "team_name", name)
+			return apperr.Conflict(apperr.CodeTeamExists, "team %s already exists", name)
+		}
+		t.log.Error("Failed to rename team", "error", err, "team_name", teamName, "new_team_name", name)
+		return fmt.Errorf("failed to rename team: %w", err)
+	}
+	return nil
+}
+
+// setParentTeam moves the team under parentName, or to the top level when
+// parentName is empty. A team cannot be moved into its own subtree.
+func (t *TeamStorage) setParentTeam(ctx context.Context, tx pgx.Tx, teamName, parentName string) error {
+	if parentName != "" {
+		query := `
+			WITH RECURSIVE subtree(name) AS (
+				SELECT name FROM teams WHERE name = $1
+				UNION ALL
+				SELECT t.name FROM teams t INNER JOIN subtree s ON t.parent_name = s.name
+			)
+			SELECT EXISTS(SELECT 1 FROM teams WHERE name = $2), EXISTS(SELECT 1 FROM subtree WHERE name = $2)
+		`
+		var exists, cycle bool
+		if err := tx.QueryRow(ctx, query, teamName, parentName).Scan(&exists, &cycle); err != nil {
+			t.log.Error("Failed to check parent team", "error", err, "team_name", teamName, "parent_team", parentName)
+			return fmt.Errorf("failed to check parent team: %w", err)
+		}
+		if !exists {
+			t.log.Warn("Parent team not found", "parent_team", parentName)
+			return apperr.NotFound(apperr.CodeTeamNotFound, "team %s not found", parentName)
+		}
+		if cycle {
+			t.log.Warn("Parent team is in the subtree of the team", "team_name", teamName, "parent_team", parentName)
+			return apperr.Conflict(apperr.CodeTeamCycle, "team %s cannot be moved under its own subteam %s", teamName, parentName)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `UPDATE teams SET parent_name = NULLIF($1, '') WHERE name = $2`, parentName, teamName)
+	if err != nil {
+		t.log.Error("Failed to set parent team", "error", err, "team_name", teamName, "parent_team", parentName)
+		return fmt.Errorf("failed to set parent team: %w", err)
+	}
+	return nil
+}
+
+// DeleteTeam removes the team and its memberships. Open reviews that members
+// hold through the team, see openReviews, either block the deletion or are
+// moved to reviewers outside the team, depending on onOpenReviews. A non-zero version must be the current version of
+// the team. It returns the number of reassigned reviews.
+func (t *TeamStorage) DeleteTeam(ctx context.Context, teamName string, onOpenReviews models.OpenReviewsAction, version int64) (int, error) {
+	t.log.Info("Deleting team", "team_name", teamName, "on_open_reviews", onOpenReviews)
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("Failed to begin transaction for team deletion", "error", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := t.lockTeam(ctx, tx, teamName, version); err != nil {
+		return 0, err
+	}
+
+	reviews, err := t.openReviews(ctx, tx, teamName)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(reviews) > 0 && onOpenReviews != models.OpenReviewsReassign {
+		t.log.Warn("Team members have open reviews", "team_name", teamName, "count", len(reviews))
+		return 0, apperr.Conflict(apperr.CodeOpenReviews, "team %s has %d open reviews held by its members", teamName, len(reviews))
+	}
+
+	rows, err := tx.Query(ctx, `DELETE FROM team_memberships WHERE team_name = $1 RETURNING user_id`, teamName)
+	if err != nil {
+		t.log.Error("Failed to detach team members", "error", err, "team_name", teamName)
+		return 0, fmt.Errorf("failed to detach team members: %w", err)
+	}
+	memberIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		t.log.Error("Failed to detach team members", "error", err, "team_name", teamName)
+		return 0, fmt.Errorf("failed to detach team members: %w", err)
+	}
+
+	if err := t.ensurePrimaryTeams(ctx, tx, memberIDs); err != nil {
+		return 0, err
+	}
+
+	// Members are detached first, so the fallback to the pull request's team
+	// never picks someone through the deleted team. Repository pools and the
+	// parent teams come first; the members of any other team are the last
+	// resort, so that a top-level team reviewing its own pull requests can go.
+	if err := t.reassignReviews(ctx, tx, reviews, true); err != nil {
+		return 0, err
+	}
+
+	// Subteams move up to the parent of the deleted team.
+	_, err = tx.Exec(ctx, `UPDATE teams SET parent_name = (SELECT parent_name FROM teams WHERE name = $1) WHERE parent_name = $1`, teamName)
+	if err != nil {
+		t.log.Error("Failed to move subteams", "error", err, "team_name", teamName)
+		return 0, fmt.Errorf("failed to move subteams: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM teams WHERE name = $1`, teamName)
+	if err != nil {
+		t.log.Error("Failed to delete team", "error", err, "team_name", teamName)
+		return 0, fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		t.log.Error("Failed to commit team deletion transaction", "error", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	t.log.Info("Successfully deleted team", "team_name", teamName, "reassigned_reviews", len(reviews))
+	return len(reviews), nil
+}
+
+// AddTeamMember upserts member and adds them to teamName. The membership becomes
+// the user's primary one when primary is set or the user has no primary team yet.
+// A non-zero version must be the current version of the team.
+func (t *TeamStorage) AddTeamMember(ctx context.Context, teamName string, member *models.User, primary bool, version int64) (*models.Team, error) {
+	t.log.Info("Adding team member", "team_name", teamName, "user_id", member.Id, "is_primary", primary)
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("Failed to begin transaction for adding team member", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := t.lockTeam(ctx, tx, teamName, version); err != nil {
+		return nil, err
+	}
+
+	if err := t.upsertMember(ctx, tx, teamName, member, primary); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		t.log.Error("Failed to commit add team member transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	t.log.Info("Successfully added team member", "team_name", teamName, "user_id", member.Id)
+	return t.GetTeamWithMembers(ctx, teamName)
+}
+
+// RemoveTeamMember removes userID from teamName and reassigns their open reviews
+// on the team's pull requests; reviews nobody else in the team can take over are
+// unassigned. A non-zero version must be the current version of the team. It
+// returns the number of reassigned reviews.
+func (t *TeamStorage) RemoveTeamMember(ctx context.Context, teamName, userID string, version int64) (int, error) {
+	t.log.Info("Removing team member", "team_name", teamName, "user_id", userID)
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("Failed to begin transaction for removing team member", "error", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := t.lockTeam(ctx, tx, teamName, version); err != nil {
+		return 0, err
+	}
+
+	result, err := t.removeMember(ctx, tx, teamName, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		t.log.Error("Failed to commit remove team member transaction", "error", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	t.log.Info("Successfully removed team member", "team_name", teamName, "user_id", userID,
+		"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	return result.Reassigned, nil
+}
+
+// ReplaceTeam renames the team to name and makes members its only members in
+// one transaction. New members are upserted like in AddTeamMember, existing
+// ones keep their membership, and the others are removed like in
+// RemoveTeamMember. A non-zero version must be the current version of the team.
+func (t *TeamStorage) ReplaceTeam(ctx context.Context, teamName, name string, members []*models.User, version int64) (*models.Team, error) {
+	t.log.Info("Replacing team", "team_name", teamName, "new_team_name", name, "members_count", len(members))
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("Failed to begin transaction for team replacement", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := t.lockTeam(ctx, tx, teamName, version); err != nil {
+		return nil, err
+	}
+
+	if name != teamName {
+		if err := t.renameTeam(ctx, tx, teamName, name); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.Query(ctx, `SELECT user_id FROM team_memberships WHERE team_name = $1`, name)
+	if err != nil {
+		t.log.Error("Failed to get team members", "error", err, "team_name", name)
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+	currentIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		t.log.Error("Failed to get team members", "error", err, "team_name", name)
+		return nil, fmt.Errorf("failed to get team members: %w", err)
+	}
+
+	desired := map[string]bool{}
+	for _, member := range members {
+		desired[member.Id] = true
+		if slices.Contains(currentIDs, member.Id) {
+			continue
+		}
+		if err := t.upsertMember(ctx, tx, name, member, false); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &models.ReassignmentResult{}
+	for _, userID := range currentIDs {
+		if desired[userID] {
+			continue
+		}
+		removed, err := t.removeMember(ctx, tx, name, userID)
+		if err != nil {
+			return nil, err
+		}
+		result.Reassigned += removed.Reassigned
+		result.Unassigned += removed.Unassigned
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		t.log.Error("Failed to commit team replacement transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	t.log.Info("Successfully replaced team", "team_name", name,
+		"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	return t.GetTeamWithMembers(ctx, name)
+}
+
+// removeMember detaches userID from teamName and hands over their open reviews
+// on the team's pull requests.
+func (t *TeamStorage) removeMember(ctx context.Context, tx pgx.Tx, teamName, userID string) (*models.ReassignmentResult, error) {
+	reviews, err := t.teamReviewsOf(ctx, tx, teamName, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM team_memberships WHERE team_name = $1 AND user_id = $2`, teamName, userID)
+	if err != nil {
+		t.log.Error("Failed to remove team member", "error", err, "team_name", teamName, "user_id", userID)
+		return nil, fmt.Errorf("failed to remove team member: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		t.log.Warn("User is not a team member", "team_name", teamName, "user_id", userID)
+		return nil, apperr.NotFound(apperr.CodeNotMember, "user %s is not a member of team %s", userID, teamName)
+	}
+
+	if err := t.ensurePrimaryTeams(ctx, tx, []string{userID}); err != nil {
+		return nil, err
+	}
+
+	return t.prStorage.handOverReviews(ctx, tx, reviews)
+}
+
+// upsertMember creates or updates the user and their membership in teamName.
+// An empty role keeps the role of an existing membership and makes new members
+// plain members.
+func (t *TeamStorage) upsertMember(ctx context.Context, tx pgx.Tx, teamName string, member *models.User, primary bool) error {
+	upsertQuery := `
+		INSERT INTO users (id, username, is_active) 
+		VALUES($1, $2, $3) 
+		ON CONFLICT (id) DO UPDATE SET 
+			username = EXCLUDED.username,
+			is_active = EXCLUDED.is_active
+		WHERE users.deleted_at IS NULL
+		RETURNING id
+	`
+	var id string
+	err := tx.QueryRow(ctx, upsertQuery, member.Id, member.Username, member.IsActive).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			t.log.Warn("Cannot add deleted user to team", "user_id", member.Id, "team_name", teamName)
+			return apperr.Conflict(apperr.CodeUserDeleted, "user %s is deleted", member.Id)
+		}
+		t.log.Error("Failed to upsert team member", "error", err, "user_id", member.Id, "team_name", teamName)
+		return fmt.Errorf("failed to upsert team member %s: %w", member.Id, err)
+	}
+
+	if primary {
+		_, err = tx.Exec(ctx, `UPDATE team_memberships SET is_primary = false WHERE user_id = $1 AND team_name != $2`, member.Id, teamName)
+		if err != nil {
+			t.log.Error("Failed to reset primary team", "error", err, "user_id", member.Id)
+			return fmt.Errorf("failed to reset primary team of %s: %w", member.Id, err)
+		}
+	}
+
+	membershipQuery := `
+		INSERT INTO team_memberships (team_name, user_id, is_primary, role)
+		VALUES ($1, $2, $3 OR NOT EXISTS (SELECT 1 FROM team_memberships WHERE user_id = $2 AND is_primary), COALESCE(NULLIF($4::text, ''), 'member'))
+		ON CONFLICT (team_name, user_id) DO UPDATE SET
+			is_primary = team_memberships.is_primary OR $3,
+			role = CASE WHEN $4::text = '' THEN team_memberships.role ELSE EXCLUDED.role END
+	`
+	_, err = tx.Exec(ctx, membershipQuery, teamName, member.Id, primary, string(member.Role))
+	if err != nil {
+		t.log.Error("Failed to add team membership", "error", err, "user_id", member.Id, "team_name", teamName)
+		return fmt.Errorf("failed to add %s to team %s: %w", member.Id, teamName, err)
+	}
+
+	return nil
+}
+
+// ensurePrimaryTeams promotes the oldest remaining membership to primary for
+// users that lost their primary team.
+func (t *TeamStorage) ensurePrimaryTeams(ctx context.Context, tx pgx.Tx, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		UPDATE team_memberships SET is_primary = true
+		WHERE (user_id, team_name) IN (
+			SELECT DISTINCT ON (user_id) user_id, team_name
+			FROM team_memberships
+			WHERE user_id = ANY($1)
+			AND user_id NOT IN (SELECT user_id FROM team_memberships WHERE is_primary)
+			ORDER BY user_id, created_at, team_name
+		)
+	`
+	_, err := tx.Exec(ctx, query, userIDs)
+	if err != nil {
+		t.log.Error("Failed to promote primary teams", "error", err)
+		return fmt.Errorf("failed to promote primary teams: %w", err)
+	}
+	return nil
+}
+
+// reassignReviews moves each review to a new reviewer, falling back to the pull
+// request's team, and with anyTeam to the members of any team. Callers detach
+// the old reviewers first.
+func (t *TeamStorage) reassignReviews(ctx context.Context, tx pgx.Tx, reviews []openReview, anyTeam bool) error {
+	for _, review := range reviews {
+		_, err := t.prStorage.replaceReviewer(ctx, tx, review.Repository, review.PullRequestId, review.ReviewerId, review.TeamName, anyTeam)
+		if err != nil {
+			t.log.Error("Failed to reassign open review", "error", err, "pr_id", review.PullRequestId, "reviewer_id", review.ReviewerId)
+			return fmt.Errorf("failed to reassign review of %s on pull request %s: %w", review.ReviewerId, review.PullRequestId, err)
+		}
+	}
+	return nil
+}
+
+// lockTeam locks the team row for the rest of tx. It fails when the team does
+// not exist or when version is non-zero and not the current one.
+func (t *TeamStorage) lockTeam(ctx context.Context, tx pgx.Tx, teamName string, version int64) error {
+	var current int64
+	err := tx.QueryRow(ctx, `SELECT version FROM teams WHERE name = $1 FOR UPDATE`, teamName).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			t.log.Warn("Team not found", "team_name", teamName)
+			return apperr.NotFound(apperr.CodeTeamNotFound, "team %s not found", teamName)
+		}
+		t.log.Error("Failed to lock team", "error", err, "team_name", teamName)
+		return fmt.Errorf("failed to lock team: %w", err)
+	}
+
+	if version != 0 && version != current {
+		t.log.Warn("Team version mismatch", "team_name", teamName, "version", current, "expected_version", version)
+		return apperr.VersionMismatch(apperr.CodeVersionMismatch, "team %s is at version %d, not %d", teamName, current, version)
+	}
+	return nil
+}
+
+type openReview struct {
+	Repository    string
+	PullRequestId string
+	ReviewerId    string
+	TeamName      string
+}
+
+// openReviews lists the reviews on open pull requests that members of teamName
+// could only hold through it: all reviews on the team's pull requests, and
+// those on pull requests of its subteams when neither a repository pool nor
+// membership in another team up the hierarchy makes the reviewer eligible.
+// Reviews members hold on pull requests of unrelated teams are left alone.
+func (t *TeamStorage) openReviews(ctx context.Context, tx pgx.Tx, teamName string) ([]openReview, error) {
+	query := `
+		WITH RECURSIVE subteams(name) AS (
+			SELECT name FROM teams WHERE parent_name = $1
+			UNION ALL
+			SELECT t.name FROM teams t INNER JOIN subteams s ON t.parent_name = s.name
+		), chains(team_name, ancestor) AS (
+			SELECT name, name FROM subteams
+			UNION ALL
+			SELECT c.team_name, t.parent_name
+			FROM chains c
+			INNER JOIN teams t ON t.name = c.ancestor
+			WHERE t.parent_name IS NOT NULL
+		)
+		SELECT prr.repository, prr.pr_id, prr.user_id, pr.team_name
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.repository = prr.repository AND pr.id = prr.pr_id
+		WHERE pr.status = 'OPEN'
+		AND EXISTS (SELECT 1 FROM team_memberships WHERE team_name = $1 AND user_id = prr.user_id)
+		AND (
+			pr.team_name = $1
+			OR (
+				pr.team_name IN (SELECT name FROM subteams)
+				AND NOT EXISTS (SELECT 1 FROM repository_reviewers WHERE repository = pr.repository)
+				AND NOT EXISTS (
+					SELECT 1
+					FROM chains c
+					INNER JOIN team_memberships tm ON tm.team_name = c.ancestor
+					WHERE c.team_name = pr.team_name AND c.ancestor != $1
+					AND tm.user_id = prr.user_id AND tm.role != 'observer'
+				)
+			)
+		)
+		ORDER BY prr.repository, prr.pr_id, prr.user_id
+	`
+	rows, err := tx.Query(ctx, query, teamName)
+	if err != nil {
+		t.log.Error("Failed to get open reviews of team members", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get open reviews of team members: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []openReview
+	for rows.Next() {
+		var review openReview
+		if err := rows.Scan(&review.Repository, &review.PullRequestId, &review.ReviewerId, &review.TeamName); err != nil {
+			t.log.Error("Failed to scan open review", "error", err)
+			return nil, fmt.Errorf("failed to scan open review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		t.log.Error("Failed to get open reviews of team members", "error", err, "team_name", teamName)
+		return nil, fmt.Errorf("failed to get open reviews of team members: %w", err)
+	}
+
+	return reviews, nil
+}
+
+// teamReviewsOf lists open reviews held by reviewerID on pull requests of teamName.
+func (t *TeamStorage) teamReviewsOf(ctx context.Context, tx pgx.Tx, teamName, reviewerID string) ([]openReview, error) {
+	query := `
+		SELECT prr.repository, prr.pr_id, prr.user_id, pr.team_name
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.repository = prr.repository AND pr.id = prr.pr_id
+		WHERE prr.user_id = $1 AND pr.team_name = $2 AND pr.status = 'OPEN'
+		ORDER BY prr.repository, prr.pr_id
+	`
+	rows, err := tx.Query(ctx, query, reviewerID, teamName)
+	if err != nil {
+		t.log.Error("Failed to get open reviews of team member", "error", err, "team_name", teamName, "user_id", reviewerID)
+		return nil, fmt.Errorf("failed to get open reviews of team member: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []openReview
+	for rows.Next() {
+		var review openReview
+		if err := rows.Scan(&review.Repository, &review.PullRequestId, &review.ReviewerId, &review.TeamName); err != nil {
+			t.log.Error("Failed to scan open review", "error", err)
+			return nil, fmt.Errorf("failed to scan open review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		t.log.Error("Failed to get open reviews of team member", "error", err, "team_name", teamName, "user_id", reviewerID)
+		return nil, fmt.Errorf("failed to get open reviews of team member: %w", err)
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"avito-autumn-2025/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SyncDirectory makes the users and teams managed by the directory match dir
+// and records a row for each change. Users of dir are created or renamed, and
+// teams are created for its groups; both are marked with their DN. A team with
+// the same name that was created by hand is adopted; a user with the same id
+// is adopted only with adoptUsers and is left alone otherwise. Managed users
+// missing from dir are deactivated and reactivated once they are back, while
+// users deactivated through the API stay inactive. Managed members missing
+// from their group leave the team; users and memberships created by hand are
+// never removed. Open reviews of deactivated users and of members leaving a
+// team are reassigned. Everything runs in one transaction.
+func (t *TeamStorage) SyncDirectory(ctx context.Context, dir *models.Directory, adoptUsers bool) (*models.ImportResult, error) {
+	t.log.Info("Syncing directory", "users_count", len(dir.Users), "groups_count", len(dir.Groups))
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("Failed to begin transaction for directory sync", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Several instances may sync at the same time
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('directory_sync'))`); err != nil {
+		t.log.Error("Failed to lock directory sync", "error", err)
+		return nil, fmt.Errorf("failed to lock directory sync: %w", err)
+	}
+
+	result := &models.ImportResult{}
+	deactivated, skipped, err := t.syncDirectoryUsers(ctx, tx, dir.Users, adoptUsers, result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reviews are collected while the memberships still exist, so that pull
+	// requests without a team fall back to the reviewer's primary team.
+	reviews, err := t.userReviews(ctx, tx, deactivated)
+	if err != nil {
+		return nil, err
+	}
+
+	var affected []string
+	for _, group := range dir.Groups {
+		removed, err := t.syncDirectoryGroup(ctx, tx, group, skipped, result)
+		if err != nil {
+			return nil, err
+		}
+		for _, userID := range removed {
+			if !slices.Contains(deactivated, userID) {
+				teamReviews, err := t.teamReviewsOf(ctx, tx, group.Name, userID)
+				if err != nil {
+					return nil, err
+				}
+				reviews = append(reviews, teamReviews...)
+			}
+			affected = append(affected, userID)
+		}
+	}
+
+	if err := t.ensurePrimaryTeams(ctx, tx, affected); err != nil {
+		return nil, err
+	}
+
+	for _, review := range reviews {
+		newReviewerID, err := t.prStorage.replaceReviewer(ctx, tx, review.Repository, review.PullRequestId, review.ReviewerId, review.TeamName, false)
+		if err != nil {
+			t.log.Error("Failed to reassign open review", "error", err, "pr_id", review.PullRequestId, "reviewer_id", review.ReviewerId)
+			return nil, fmt.Errorf("failed to reassign review of %s on pull request %s: %w", review.ReviewerId, review.PullRequestId, err)
+		}
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:          models.ImportRowReview,
+			TeamName:      review.TeamName,
+			UserId:        review.ReviewerId,
+			Repository:    review.Repository,
+			PullRequestId: review.PullRequestId,
+			Action:        models.ImportUpdate,
+			Before:        map[string]any{"reviewer_id": review.ReviewerId},
+			After:         map[string]any{"reviewer_id": newReviewerID},
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		t.log.Error("Failed to commit directory sync transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	result.Applied = true
+
+	t.log.Info("Successfully synced directory", "changes", len(result.Rows), "reassigned_reviews", len(reviews))
+	return result, nil
+}
+
+type directoryUserState struct {
+	Username             string
+	IsActive             bool
+	DirectoryDN          string
+	DirectoryDeactivated bool
+	Deleted              bool
+}
+
+// syncDirectoryUsers upserts the users of the directory and deactivates
+// managed users missing from it. It returns the deactivated users in order and
+// the users of the directory that are left untouched: deleted users and, unless
+// adoptUsers is set, users created by hand.
+func (t *TeamStorage) syncDirectoryUsers(ctx context.Context, tx pgx.Tx, users []*models.DirectoryUser, adoptUsers bool, result *models.ImportResult) ([]string, map[string]bool, error) {
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.Id)
+	}
+
+	query := `
+		SELECT id, username, is_active, COALESCE(directory_dn, ''), directory_deactivated, deleted_at IS NOT NULL
+		FROM users
+		WHERE id = ANY($1)
+	`
+	rows, err := tx.Query(ctx, query, userIDs)
+	if err != nil {
+		t.log.Error("Failed to get directory users", "error", err)
+		return nil, nil, fmt.Errorf("failed to get directory users: %w", err)
+	}
+	current := map[string]directoryUserState{}
+	_, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (struct{}, error) {
+		var id string
+		var state directoryUserState
+		err := row.Scan(&id, &state.Username, &state.IsActive, &state.DirectoryDN, &state.DirectoryDeactivated, &state.Deleted)
+		current[id] = state
+		return struct{}{}, err
+	})
+	if err != nil {
+		t.log.Error("Failed to get directory users", "error", err)
+		return nil, nil, fmt.Errorf("failed to get directory users: %w", err)
+	}
+
+	skipped := map[string]bool{}
+	for _, user := range users {
+		state, exists := current[user.Id]
+		if !exists {
+			_, err := tx.Exec(ctx, `INSERT INTO users (id, username, is_active, directory_dn) VALUES ($1, $2, true, $3)`,
+				user.Id, user.Username, user.DN)
+			if err != nil {
+				t.log.Error("Failed to create directory user", "error", err, "user_id", user.Id)
+				return nil, nil, fmt.Errorf("failed to create user %s: %w", user.Id, err)
+			}
+			result.Rows = append(result.Rows, &models.ImportRowResult{
+				Kind:   models.ImportRowUser,
+				UserId: user.Id,
+				Action: models.ImportCreate,
+				After:  map[string]any{"username": user.Username, "is_active": true, "directory_dn": user.DN},
+			})
+			continue
+		}
+		if state.Deleted {
+			t.log.Warn("Skipping deleted directory user", "user_id", user.Id)
+			skipped[user.Id] = true
+			continue
+		}
+		if state.DirectoryDN == "" && !adoptUsers {
+			t.log.Warn("Skipping directory user created by hand", "user_id", user.Id)
+			skipped[user.Id] = true
+			continue
+		}
+
+		// Only a deactivation made by the sync itself is undone
+		active := state.IsActive || state.DirectoryDeactivated
+		before, after := diffAttributes(
+			map[string]any{"username": state.Username, "is_active": state.IsActive, "directory_dn": state.DirectoryDN},
+			map[string]any{"username": user.Username, "is_active": active, "directory_dn": user.DN},
+		)
+		if len(after) == 0 {
+			continue
+		}
+		_, err := tx.Exec(ctx, `UPDATE users SET username = $2, is_active = $3, directory_dn = $4, directory_deactivated = false WHERE id = $1`,
+			user.Id, user.Username, active, user.DN)
+		if err != nil {
+			t.log.Error("Failed to update directory user", "error", err, "user_id", user.Id)
+			return nil, nil, fmt.Errorf("failed to update user %s: %w", user.Id, err)
+		}
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:   models.ImportRowUser,
+			UserId: user.Id,
+			Action: models.ImportUpdate,
+			Before: before,
+			After:  after,
+		})
+	}
+
+	deactivated, err := t.collectNames(ctx, tx, `
+		UPDATE users SET is_active = false, directory_deactivated = true
+		WHERE directory_dn IS NOT NULL AND is_active AND deleted_at IS NULL AND id <> ALL($1)
+		RETURNING id
+	`, userIDs)
+	if err != nil {
+		t.log.Error("Failed to deactivate users missing from directory", "error", err)
+		return nil, nil, fmt.Errorf("failed to deactivate users: %w", err)
+	}
+	for _, userID := range deactivated {
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:   models.ImportRowUser,
+			UserId: userID,
+			Action: models.ImportUpdate,
+			Before: map[string]any{"is_active": true},
+			After:  map[string]any{"is_active": false},
+		})
+	}
+
+	return deactivated, skipped, nil
+}
+
+// syncDirectoryGroup creates or adopts the team of group and makes its
+// managed members match the group. It returns the users that left the team.
+func (t *TeamStorage) syncDirectoryGroup(ctx context.Context, tx pgx.Tx, group *models.DirectoryGroup, skipped map[string]bool, result *models.ImportResult) ([]string, error) {
+	var directoryDN string
+	err := tx.QueryRow(ctx, `SELECT COALESCE(directory_dn, '') FROM teams WHERE name = $1 FOR UPDATE`, group.Name).Scan(&directoryDN)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		if _, err := tx.Exec(ctx, `INSERT INTO teams (name, directory_dn) VALUES ($1, $2)`, group.Name, group.DN); err != nil {
+			t.log.Error("Failed to create directory team", "error", err, "team_name", group.Name)
+			return nil, fmt.Errorf("failed to create team %s: %w", group.Name, err)
+		}
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:     models.ImportRowTeam,
+			TeamName: group.Name,
+			Action:   models.ImportCreate,
+			After:    map[string]any{"directory_dn": group.DN},
+		})
+	case err != nil:
+		t.log.Error("Failed to get directory team", "error", err, "team_name", group.Name)
+		return nil, fmt.Errorf("failed to get team %s: %w", group.Name, err)
+	case directoryDN != group.DN:
+		if _, err := tx.Exec(ctx, `UPDATE teams SET directory_dn = $2 WHERE name = $1`, group.Name, group.DN); err != nil {
+			t.log.Error("Failed to update directory team", "error", err, "team_name", group.Name)
+			return nil, fmt.Errorf("failed to update team %s: %w", group.Name, err)
+		}
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:     models.ImportRowTeam,
+			TeamName: group.Name,
+			Action:   models.ImportUpdate,
+			Before:   map[string]any{"directory_dn": directoryDN},
+			After:    map[string]any{"directory_dn": group.DN},
+		})
+	}
+
+	query := `
+		SELECT tm.user_id, tm.role, u.directory_dn IS NOT NULL
+		FROM team_memberships tm
+		INNER JOIN users u ON u.id = tm.user_id
+		WHERE tm.team_name = $1
+		ORDER BY tm.user_id
+	`
+	rows, err := tx.Query(ctx, query, group.Name)
+	if err != nil {
+		t.log.Error("Failed to get team memberships", "error", err, "team_name", group.Name)
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+	type membership struct {
+		UserId  string
+		Role    models.MembershipRole
+		Managed bool
+	}
+	memberships, err := pgx.CollectRows(rows, pgx.RowToStructByPos[membership])
+	if err != nil {
+		t.log.Error("Failed to get team memberships", "error", err, "team_name", group.Name)
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+
+	members := map[string]bool{}
+	var removed []string
+	for _, membership := range memberships {
+		members[membership.UserId] = true
+		if !membership.Managed || slices.Contains(group.MemberIds, membership.UserId) {
+			continue
+		}
+
+		_, err := tx.Exec(ctx, `DELETE FROM team_memberships WHERE team_name = $1 AND user_id = $2`, group.Name, membership.UserId)
+		if err != nil {
+			t.log.Error("Failed to remove team member", "error", err, "team_name", group.Name, "user_id", membership.UserId)
+			return nil, fmt.Errorf("failed to remove team member: %w", err)
+		}
+		removed = append(removed, membership.UserId)
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:     models.ImportRowMember,
+			TeamName: group.Name,
+			UserId:   membership.UserId,
+			Action:   models.ImportDelete,
+			Before:   map[string]any{"role": membership.Role},
+		})
+	}
+
+	for _, userID := range group.MemberIds {
+		if members[userID] || skipped[userID] {
+			continue
+		}
+
+		membershipQuery := `
+			INSERT INTO team_memberships (team_name, user_id, is_primary, role)
+			VALUES ($1, $2, NOT EXISTS (SELECT 1 FROM team_memberships WHERE user_id = $2 AND is_primary), $3)
+		`
+		if _, err := tx.Exec(ctx, membershipQuery, group.Name, userID, models.RoleMember); err != nil {
+			t.log.Error("Failed to add team membership", "error", err, "user_id", userID, "team_name", group.Name)
+			return nil, fmt.Errorf("failed to add %s to team %s: %w", userID, group.Name, err)
+		}
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:     models.ImportRowMember,
+			TeamName: group.Name,
+			UserId:   userID,
+			Action:   models.ImportCreate,
+			After:    map[string]any{"role": models.RoleMember},
+		})
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,361 @@
+package postgres
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ImportTeams applies a validated import in one transaction. Teams must come
+// after their parents. Every entry is compared with the current state first, so
+// the result doubles as a diff; a dry run rolls the transaction back. Open
+// reviews of users the import deactivates are reassigned. The first failing
+// entry rolls back the whole import and the remaining ones are skipped.
+func (t *TeamStorage) ImportTeams(ctx context.Context, imp *models.Import, dryRun bool) (*models.ImportResult, error) {
+	t.log.Info("Importing teams", "teams_count", len(imp.Teams), "dry_run", dryRun)
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("Failed to begin transaction for team import", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := &models.ImportResult{DryRun: dryRun}
+	failed, deactivated := t.applyImport(ctx, tx, imp, result)
+	if !failed {
+		reviews, err := t.userReviews(ctx, tx, deactivated)
+		if err != nil {
+			return nil, err
+		}
+		failed = t.reassignImportReviews(ctx, tx, reviews, result)
+	}
+
+	return t.finishImport(ctx, tx, result, failed)
+}
+
+// applyImport creates and updates the teams, policies and members of imp and
+// records a row for each of them. It reports whether an entry failed and
+// returns the users it deactivated in order.
+func (t *TeamStorage) applyImport(ctx context.Context, tx pgx.Tx, imp *models.Import, result *models.ImportResult) (bool, []string) {
+	failed := false
+	var deactivated []string
+	record := func(row *models.ImportRowResult, err error) {
+		if err != nil {
+			row.Action = models.ImportFailed
+			row.Error = err.Error()
+			failed = true
+		}
+		if row.Before["is_active"] == true && row.After["is_active"] == false && !slices.Contains(deactivated, row.UserId) {
+			deactivated = append(deactivated, row.UserId)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	for _, team := range imp.Teams {
+		if failed {
+			result.Rows = append(result.Rows, skippedRows(team)...)
+			continue
+		}
+
+		row, err := t.importTeam(ctx, tx, team)
+		record(row, err)
+		if failed {
+			result.Rows = append(result.Rows, skippedRows(team)[1:]...)
+			continue
+		}
+
+		if team.Policy != nil {
+			row, err := t.importPolicy(ctx, tx, team)
+			record(row, err)
+		}
+
+		for i, member := range team.Members {
+			if failed {
+				for _, rest := range team.Members[i:] {
+					result.Rows = append(result.Rows, skippedMemberRow(team, rest))
+				}
+				break
+			}
+			row, err := t.importMember(ctx, tx, team, member)
+			record(row, err)
+		}
+	}
+
+	return failed, deactivated
+}
+
+// reassignImportReviews moves reviews to other reviewers and records a row for
+// each of them. It stops at the first review nobody can take over, marks the
+// remaining ones skipped and reports the failure.
+func (t *TeamStorage) reassignImportReviews(ctx context.Context, tx pgx.Tx, reviews []openReview, result *models.ImportResult) bool {
+	for i, review := range reviews {
+		row := &models.ImportRowResult{
+			Kind:          models.ImportRowReview,
+			TeamName:      review.TeamName,
+			UserId:        review.ReviewerId,
+			Repository:    review.Repository,
+			PullRequestId: review.PullRequestId,
+			Action:        models.ImportUpdate,
+			Before:        map[string]any{"reviewer_id": review.ReviewerId},
+		}
+		result.Rows = append(result.Rows, row)
+
+		newReviewerID, err := t.prStorage.replaceReviewer(ctx, tx, review.Repository, review.PullRequestId, review.ReviewerId, review.TeamName, false)
+		if err != nil {
+			t.log.Warn("Failed to reassign open review", "error", err, "pr_id", review.PullRequestId, "reviewer_id", review.ReviewerId)
+			row.Action = models.ImportFailed
+			row.Error = err.Error()
+			for _, rest := range reviews[i+1:] {
+				result.Rows = append(result.Rows, &models.ImportRowResult{
+					Kind:          models.ImportRowReview,
+					TeamName:      rest.TeamName,
+					UserId:        rest.ReviewerId,
+					Repository:    rest.Repository,
+					PullRequestId: rest.PullRequestId,
+					Action:        models.ImportSkipped,
+				})
+			}
+			return true
+		}
+		row.After = map[string]any{"reviewer_id": newReviewerID}
+	}
+	return false
+}
+
+// finishImport commits tx unless the import failed or is a dry run.
+func (t *TeamStorage) finishImport(ctx context.Context, tx pgx.Tx, result *models.ImportResult, failed bool) (*models.ImportResult, error) {
+	if failed || result.DryRun {
+		t.log.Info("Team import not applied", "failed", failed, "dry_run", result.DryRun)
+		return result, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.log.Error("Failed to commit team import transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	result.Applied = true
+
+	t.log.Info("Successfully imported teams", "rows_count", len(result.Rows))
+	return result, nil
+}
+
+func (t *TeamStorage) importTeam(ctx context.Context, tx pgx.Tx, team *models.ImportTeam) (*models.ImportRowResult, error) {
+	row := &models.ImportRowResult{Row: team.Row, Kind: models.ImportRowTeam, TeamName: team.Name}
+
+	var currentParent string
+	err := tx.QueryRow(ctx, `SELECT COALESCE(parent_name, '') FROM teams WHERE name = $1 FOR UPDATE`, team.Name).Scan(&currentParent)
+	if errors.Is(err, pgx.ErrNoRows) {
+		row.Action = models.ImportCreate
+		row.After = map[string]any{"parent_team": team.ParentTeam}
+		if team.ParentTeam != "" {
+			if err := t.requireTeam(ctx, tx, team.ParentTeam); err != nil {
+				return row, err
+			}
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO teams (name, parent_name) VALUES ($1, NULLIF($2, ''))`, team.Name, team.ParentTeam)
+		if err != nil {
+			t.log.Error("Failed to create team", "error", err, "team_name", team.Name)
+			return row, fmt.Errorf("failed to create team: %w", err)
+		}
+		return row, nil
+	}
+	if err != nil {
+		t.log.Error("Failed to get team", "error", err, "team_name", team.Name)
+		return row, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if currentParent == team.ParentTeam {
+		row.Action = models.ImportUnchanged
+		return row, nil
+	}
+
+	row.Action = models.ImportUpdate
+	row.Before = map[string]any{"parent_team": currentParent}
+	row.After = map[string]any{"parent_team": team.ParentTeam}
+	return row, t.setParentTeam(ctx, tx, team.Name, team.ParentTeam)
+}
+
+func (t *TeamStorage) importPolicy(ctx context.Context, tx pgx.Tx, team *models.ImportTeam) (*models.ImportRowResult, error) {
+	row := &models.ImportRowResult{Row: team.Row, Kind: models.ImportRowPolicy, TeamName: team.Name}
+
+	policy := *team.Policy
+	policy.TeamName = team.Name
+	desired := policyAttributes(&policy)
+
+	query := `
+		SELECT first_response_hours, business_hours_only, sla_action, escalation_reviewer_id,
+			stale_after_days, close_after_days, workday_start, workday_end, time_zone
+		FROM team_policies
+		WHERE team_name = $1
+	`
+	current := models.TeamPolicy{TeamName: team.Name}
+	var escalationReviewerID sql.NullString
+	err := tx.QueryRow(ctx, query, team.Name).Scan(
+		&current.FirstResponseHours,
+		&current.BusinessHoursOnly,
+		&current.SLAAction,
+		&escalationReviewerID,
+		&current.StaleAfterDays,
+		&current.CloseAfterDays,
+		&current.WorkdayStart,
+		&current.WorkdayEnd,
+		&current.TimeZone,
+	)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		row.Action = models.ImportCreate
+		row.After = desired
+	case err != nil:
+		t.log.Error("Failed to get team policy", "error", err, "team_name", team.Name)
+		return row, fmt.Errorf("failed to get team policy: %w", err)
+	default:
+		current.EscalationReviewerId = escalationReviewerID.String
+		row.Before, row.After = diffAttributes(policyAttributes(&current), desired)
+		if len(row.After) == 0 {
+			row.Action = models.ImportUnchanged
+			return row, nil
+		}
+		row.Action = models.ImportUpdate
+	}
+
+	var escalation sql.NullString
+	if policy.EscalationReviewerId != "" {
+		escalation = sql.NullString{String: policy.EscalationReviewerId, Valid: true}
+	}
+	_, err = tx.Exec(ctx, upsertTeamPolicyQuery, policy.TeamName, policy.FirstResponseHours, policy.BusinessHoursOnly, policy.SLAAction, escalation,
+		policy.StaleAfterDays, policy.CloseAfterDays, policy.WorkdayStart, policy.WorkdayEnd, policy.TimeZone)
+	if err != nil {
+		t.log.Error("Failed to upsert team policy", "error", err, "team_name", team.Name)
+		return row, fmt.Errorf("failed to upsert team policy: %w", err)
+	}
+	return row, nil
+}
+
+func (t *TeamStorage) importMember(ctx context.Context, tx pgx.Tx, team *models.ImportTeam, member *models.ImportMember) (*models.ImportRowResult, error) {
+	row := &models.ImportRowResult{Row: member.Row, Kind: models.ImportRowMember, TeamName: team.Name, UserId: member.Id}
+
+	// An omitted role keeps the current one; new memberships default to member.
+	desired := map[string]any{"username": member.Username}
+	if member.Role != "" {
+		desired["role"] = member.Role
+	}
+	if member.IsActive != nil {
+		desired["is_active"] = *member.IsActive
+	}
+	if member.IsPrimary {
+		desired["is_primary"] = true
+	}
+
+	query := `
+		SELECT u.username, u.is_active, u.deleted_at IS NOT NULL, tm.role, COALESCE(tm.is_primary, false)
+		FROM users u
+		LEFT JOIN team_memberships tm ON tm.user_id = u.id AND tm.team_name = $2
+		WHERE u.id = $1
+	`
+	var username string
+	var isActive, deleted, isPrimary bool
+	var currentRole sql.NullString
+	err := tx.QueryRow(ctx, query, member.Id, team.Name).Scan(&username, &isActive, &deleted, &currentRole, &isPrimary)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		isActive = true
+		if member.IsActive != nil {
+			isActive = *member.IsActive
+		}
+		row.Action = models.ImportCreate
+		row.After = desired
+		row.After["is_active"] = isActive
+		if member.Role == "" {
+			row.After["role"] = models.RoleMember
+		}
+	case err != nil:
+		t.log.Error("Failed to get team member", "error", err, "user_id", member.Id, "team_name", team.Name)
+		return row, fmt.Errorf("failed to get team member: %w", err)
+	case deleted:
+		return row, apperr.Conflict(apperr.CodeUserDeleted, "user %s is deleted", member.Id)
+	default:
+		current := map[string]any{"username": username, "is_active": isActive, "role": models.MembershipRole(currentRole.String)}
+		if !currentRole.Valid {
+			current["role"] = nil
+			if member.Role == "" {
+				desired["role"] = models.RoleMember
+			}
+		}
+		if member.IsPrimary {
+			current["is_primary"] = isPrimary
+		}
+		row.Before, row.After = diffAttributes(current, desired)
+		if len(row.After) == 0 {
+			row.Action = models.ImportUnchanged
+			return row, nil
+		}
+		row.Action = models.ImportUpdate
+	}
+
+	if member.IsActive != nil {
+		isActive = *member.IsActive
+	}
+	user := &models.User{Id: member.Id, Username: member.Username, IsActive: isActive, Role: member.Role}
+	return row, t.upsertMember(ctx, tx, team.Name, user, member.IsPrimary)
+}
+
+// requireTeam fails when the team does not exist in tx.
+func (t *TeamStorage) requireTeam(ctx context.Context, tx pgx.Tx, teamName string) error {
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)`, teamName).Scan(&exists); err != nil {
+		t.log.Error("Failed to check team existence", "error", err, "team_name", teamName)
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return apperr.NotFound(apperr.CodeTeamNotFound, "team %s not found", teamName)
+	}
+	return nil
+}
+
+func policyAttributes(policy *models.TeamPolicy) map[string]any {
+	return map[string]any{
+		"first_response_hours":   policy.FirstResponseHours,
+		"business_hours_only":    policy.BusinessHoursOnly,
+		"workday_start":          policy.WorkdayStart,
+		"workday_end":            policy.WorkdayEnd,
+		"time_zone":              policy.TimeZone,
+		"sla_action":             policy.SLAAction,
+		"escalation_reviewer_id": policy.EscalationReviewerId,
+		"stale_after_days":       policy.StaleAfterDays,
+		"close_after_days":       policy.CloseAfterDays,
+	}
+}
+
+// diffAttributes returns the attributes whose values differ.
+func diffAttributes(current, desired map[string]any) (map[string]any, map[string]any) {
+	before, after := map[string]any{}, map[string]any{}
+	for key, value := range desired {
+		if current[key] != value {
+			before[key] = current[key]
+			after[key] = value
+		}
+	}
+	return before, after
+}
+
+func skippedRows(team *models.ImportTeam) []*models.ImportRowResult {
+	rows := []*models.ImportRowResult{{Row: team.Row, Kind: models.ImportRowTeam, TeamName: team.Name, Action: models.ImportSkipped}}
+	if team.Policy != nil {
+		rows = append(rows, &models.ImportRowResult{Row: team.Row, Kind: models.ImportRowPolicy, TeamName: team.Name, Action: models.ImportSkipped})
+	}
+	for _, member := range team.Members {
+		rows = append(rows, skippedMemberRow(team, member))
+	}
+	return rows
+}
+
+func skippedMemberRow(team *models.ImportTeam, member *models.ImportMember) *models.ImportRowResult {
+	return &models.ImportRowResult{Row: member.Row, Kind: models.ImportRowMember, TeamName: team.Name, UserId: member.Id, Action: models.ImportSkipped}
+}
@@ -0,0 +1,239 @@
+package postgres
+
+import (
+	"avito-autumn-2025/internal/models"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReconcileTeams makes the database match imp, which describes the whole
+// organization. Entries of imp are applied like an import; afterwards users
+// missing from it are deactivated, memberships and teams missing from it are
+// removed, policies of teams without one are dropped, and open reviews of
+// deactivated users and of members leaving a team are reassigned. Everything
+// runs in one transaction, which a dry run or any failure rolls back.
+func (t *TeamStorage) ReconcileTeams(ctx context.Context, imp *models.Import, dryRun bool) (*models.ImportResult, error) {
+	t.log.Info("Reconciling teams", "teams_count", len(imp.Teams), "dry_run", dryRun)
+
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		t.log.Error("Failed to begin transaction for team reconciliation", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := &models.ImportResult{DryRun: dryRun}
+	failed, deactivated := t.applyImport(ctx, tx, imp, result)
+	if !failed {
+		if failed, err = t.pruneImport(ctx, tx, imp, deactivated, result); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.finishImport(ctx, tx, result, failed)
+}
+
+type membershipKey struct {
+	TeamName string
+	UserId   string
+}
+
+// pruneImport removes everything missing from imp and records a row for each
+// change. Open reviews of users applyImport deactivated, passed in
+// deactivated, are reassigned along with those of the users it deactivates
+// itself. It reports whether a review could not be reassigned.
+func (t *TeamStorage) pruneImport(ctx context.Context, tx pgx.Tx, imp *models.Import, deactivated []string, result *models.ImportResult) (bool, error) {
+	var teamNames, userIDs, withoutPolicy []string
+	memberships := map[membershipKey]bool{}
+	for _, team := range imp.Teams {
+		teamNames = append(teamNames, team.Name)
+		if team.Policy == nil {
+			withoutPolicy = append(withoutPolicy, team.Name)
+		}
+		for _, member := range team.Members {
+			userIDs = append(userIDs, member.Id)
+			memberships[membershipKey{team.Name, member.Id}] = true
+		}
+	}
+
+	missing, err := t.deactivateMissingUsers(ctx, tx, userIDs)
+	if err != nil {
+		return false, err
+	}
+	deactivated = append(deactivated, missing...)
+	for _, userID := range missing {
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:   models.ImportRowUser,
+			UserId: userID,
+			Action: models.ImportUpdate,
+			Before: map[string]any{"is_active": true},
+			After:  map[string]any{"is_active": false},
+		})
+	}
+
+	// Reviews are collected while the memberships still exist, so that pull
+	// requests without a team fall back to the reviewer's primary team.
+	reviews, err := t.userReviews(ctx, tx, deactivated)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := t.missingMemberships(ctx, tx, memberships)
+	if err != nil {
+		return false, err
+	}
+	var affected []string
+	for _, membership := range removed {
+		if !slices.Contains(deactivated, membership.UserId) {
+			teamReviews, err := t.teamReviewsOf(ctx, tx, membership.TeamName, membership.UserId)
+			if err != nil {
+				return false, err
+			}
+			reviews = append(reviews, teamReviews...)
+		}
+
+		_, err := tx.Exec(ctx, `DELETE FROM team_memberships WHERE team_name = $1 AND user_id = $2`, membership.TeamName, membership.UserId)
+		if err != nil {
+			t.log.Error("Failed to remove team member", "error", err, "team_name", membership.TeamName, "user_id", membership.UserId)
+			return false, fmt.Errorf("failed to remove team member: %w", err)
+		}
+		affected = append(affected, membership.UserId)
+		result.Rows = append(result.Rows, &models.ImportRowResult{
+			Kind:     models.ImportRowMember,
+			TeamName: membership.TeamName,
+			UserId:   membership.UserId,
+			Action:   models.ImportDelete,
+			Before:   map[string]any{"role": membership.Role},
+		})
+	}
+
+	if err := t.ensurePrimaryTeams(ctx, tx, affected); err != nil {
+		return false, err
+	}
+
+	// Members are detached first, so that no review moves to someone who is
+	// leaving the team. Teams are deleted afterwards, so that the fallback
+	// still walks up from the pull request's team.
+	if t.reassignImportReviews(ctx, tx, reviews, result) {
+		return true, nil
+	}
+
+	deletedTeams, err := t.collectNames(ctx, tx, `DELETE FROM teams WHERE name <> ALL($1) RETURNING name`, teamNames)
+	if err != nil {
+		t.log.Error("Failed to delete teams", "error", err)
+		return false, fmt.Errorf("failed to delete teams: %w", err)
+	}
+	for _, teamName := range deletedTeams {
+		result.Rows = append(result.Rows, &models.ImportRowResult{Kind: models.ImportRowTeam, TeamName: teamName, Action: models.ImportDelete})
+	}
+
+	deletedPolicies, err := t.collectNames(ctx, tx, `DELETE FROM team_policies WHERE team_name = ANY($1) RETURNING team_name`, withoutPolicy)
+	if err != nil {
+		t.log.Error("Failed to delete team policies", "error", err)
+		return false, fmt.Errorf("failed to delete team policies: %w", err)
+	}
+	for _, teamName := range deletedPolicies {
+		result.Rows = append(result.Rows, &models.ImportRowResult{Kind: models.ImportRowPolicy, TeamName: teamName, Action: models.ImportDelete})
+	}
+
+	return false, nil
+}
+
+// deactivateMissingUsers deactivates active users other than userIDs and
+// returns their ids in order.
+func (t *TeamStorage) deactivateMissingUsers(ctx context.Context, tx pgx.Tx, userIDs []string) ([]string, error) {
+	query := `
+		UPDATE users SET is_active = false
+		WHERE is_active AND deleted_at IS NULL AND id <> ALL($1)
+		RETURNING id
+	`
+	deactivated, err := t.collectNames(ctx, tx, query, userIDs)
+	if err != nil {
+		t.log.Error("Failed to deactivate users", "error", err)
+		return nil, fmt.Errorf("failed to deactivate users: %w", err)
+	}
+	return deactivated, nil
+}
+
+// userReviews lists reviews on open pull requests held by userIDs. Pull
+// requests without a team fall back to the reviewer's primary team.
+func (t *TeamStorage) userReviews(ctx context.Context, tx pgx.Tx, userIDs []string) ([]openReview, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT prr.repository, prr.pr_id, prr.user_id, COALESCE(
+			pr.team_name,
+			(SELECT team_name FROM team_memberships WHERE user_id = prr.user_id AND is_primary),
+			''
+		)
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.repository = prr.repository AND pr.id = prr.pr_id
+		WHERE prr.user_id = ANY($1) AND pr.status = 'OPEN'
+		ORDER BY prr.user_id, prr.repository, prr.pr_id
+	`
+	rows, err := tx.Query(ctx, query, userIDs)
+	if err != nil {
+		t.log.Error("Failed to get open reviews of users", "error", err)
+		return nil, fmt.Errorf("failed to get open reviews of users: %w", err)
+	}
+	reviews, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (openReview, error) {
+		var review openReview
+		err := row.Scan(&review.Repository, &review.PullRequestId, &review.ReviewerId, &review.TeamName)
+		return review, err
+	})
+	if err != nil {
+		t.log.Error("Failed to get open reviews of users", "error", err)
+		return nil, fmt.Errorf("failed to get open reviews of users: %w", err)
+	}
+	return reviews, nil
+}
+
+type removedMembership struct {
+	membershipKey
+	Role models.MembershipRole
+}
+
+// missingMemberships lists memberships other than desired in order.
+func (t *TeamStorage) missingMemberships(ctx context.Context, tx pgx.Tx, desired map[membershipKey]bool) ([]removedMembership, error) {
+	rows, err := tx.Query(ctx, `SELECT team_name, user_id, role FROM team_memberships ORDER BY team_name, user_id`)
+	if err != nil {
+		t.log.Error("Failed to get team memberships", "error", err)
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+	memberships, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (removedMembership, error) {
+		var membership removedMembership
+		err := row.Scan(&membership.TeamName, &membership.UserId, &membership.Role)
+		return membership, err
+	})
+	if err != nil {
+		t.log.Error("Failed to get team memberships", "error", err)
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+
+	return slices.DeleteFunc(memberships, func(membership removedMembership) bool {
+		return desired[membership.membershipKey]
+	}), nil
+}
+
+// collectNames runs a statement with one text array argument that returns one
+// text column and returns the values sorted.
+func (t *TeamStorage) collectNames(ctx context.Context, tx pgx.Tx, query string, arg []string) ([]string, error) {
+	if arg == nil {
+		arg = []string{}
+	}
+	rows, err := tx.Query(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	values, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(values)
+	return values, nil
+}
@@ -1,24 +1,27 @@
 package postgres
 
 import (
+	"avito-autumn-2025/internal/apperr"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type UserStorage struct {
-	db  *pgxpool.Pool
-	log logger.Logger
+	db        *pgxpool.Pool
+	log       logger.Logger
+	prStorage PullRequestStorage
 }
 
 func NewUserStorage(db *pgxpool.Pool, log logger.Logger) UserStorage {
-	return UserStorage{db: db, log: log}
+	return UserStorage{db: db, log: log, prStorage: NewPullRequestStorage(db, log)}
 }
 
 func (u *UserStorage) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
@@ -28,6 +31,10 @@ func (u *UserStorage) CreateUser(ctx context.Context, user *models.User) (*model
 	var createdUser models.User
 	err := u.db.QueryRow(ctx, query, user.Id, user.Username, user.IsActive).Scan(&createdUser.Id, &createdUser.Username, &createdUser.IsActive)
 	if err != nil {
+		if isUniqueViolation(err) {
+			u.log.Warn("User already exists", "user_id", user.Id)
+			return nil, apperr.Conflict(apperr.CodeUserExists, "user %s already exists", user.Id)
+		}
 		u.log.Error("Failed to create user", "error", err, "user_id", user.Id)
 		return nil, err
 	}
@@ -40,7 +47,12 @@ func (u *UserStorage) GetUserByID(ctx context.Context, id string) (*models.User,
 	u.log.Debug("Getting user by ID", "user_id", id)
 
 	data := &models.User{}
-	query := `SELECT id, username, is_active, team_name FROM users WHERE id = $1`
+	query := `
+		SELECT u.id, u.username, u.is_active, tm.team_name
+		FROM users u
+		LEFT JOIN team_memberships tm ON tm.user_id = u.id AND tm.is_primary
+		WHERE u.id = $1 AND u.deleted_at IS NULL
+	`
 	row := u.db.QueryRow(ctx, query, id)
 	var teamName sql.NullString
 	err := row.Scan(&data.Id, &data.Username, &data.IsActive, &teamName)
@@ -57,14 +69,140 @@ func (u *UserStorage) GetUserByID(ctx context.Context, id string) (*models.User,
 		data.TeamName = teamName.String
 	}
 
+	teams, err := u.getUserTeams(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	data.Teams = teams
+
 	u.log.Debug("Successfully retrieved user", "user_id", id)
 	return data, nil
 }
 
+// ListUsers returns every user that is not deleted, ordered by id, with their
+// teams, primary team first.
+func (u *UserStorage) ListUsers(ctx context.Context) ([]*models.User, error) {
+	u.log.Debug("Listing users")
+
+	query := `
+		SELECT u.id, u.username, u.is_active, COALESCE(
+			ARRAY_AGG(tm.team_name ORDER BY tm.is_primary DESC, tm.team_name) FILTER (WHERE tm.team_name IS NOT NULL),
+			'{}'
+		)
+		FROM users u
+		LEFT JOIN team_memberships tm ON tm.user_id = u.id
+		WHERE u.deleted_at IS NULL
+		GROUP BY u.id
+		ORDER BY u.id
+	`
+	rows, err := u.db.Query(ctx, query)
+	if err != nil {
+		u.log.Error("Failed to list users", "error", err)
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	users, err := pgx.CollectRows(rows, scanUserWithTeams)
+	if err != nil {
+		u.log.Error("Failed to list users", "error", err)
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	u.log.Debug("Successfully listed users", "count", len(users))
+	return users, nil
+}
+
+// SearchUsers returns a page of users ordered by id and the number of users
+// matching the query.
+func (u *UserStorage) SearchUsers(ctx context.Context, query *models.ListQuery) ([]*models.User, int, error) {
+	u.log.Debug("Searching users", "name", query.Name, "offset", query.Offset, "limit", query.Limit)
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM users WHERE deleted_at IS NULL AND ($1 = '' OR LOWER(username) = LOWER($1))`
+	if err := u.db.QueryRow(ctx, countQuery, query.Name).Scan(&total); err != nil {
+		u.log.Error("Failed to count users", "error", err)
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	pageQuery := `
+		SELECT u.id, u.username, u.is_active, COALESCE(
+			ARRAY_AGG(tm.team_name ORDER BY tm.is_primary DESC, tm.team_name) FILTER (WHERE tm.team_name IS NOT NULL),
+			'{}'
+		)
+		FROM users u
+		LEFT JOIN team_memberships tm ON tm.user_id = u.id
+		WHERE u.deleted_at IS NULL AND ($1 = '' OR LOWER(u.username) = LOWER($1))
+		GROUP BY u.id
+		ORDER BY u.id
+		OFFSET $2 LIMIT $3
+	`
+	rows, err := u.db.Query(ctx, pageQuery, query.Name, query.Offset, query.Limit)
+	if err != nil {
+		u.log.Error("Failed to search users", "error", err)
+		return nil, 0, fmt.Errorf("failed to search users: %w", err)
+	}
+	users, err := pgx.CollectRows(rows, scanUserWithTeams)
+	if err != nil {
+		u.log.Error("Failed to search users", "error", err)
+		return nil, 0, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	u.log.Debug("Successfully searched users", "count", len(users), "total", total)
+	return users, total, nil
+}
+
+// GetUsersByIDs returns the users with the given IDs in no particular order.
+// Unknown and deleted users are left out.
+func (u *UserStorage) GetUsersByIDs(ctx context.Context, ids []string) ([]*models.User, error) {
+	u.log.Debug("Getting users by IDs", "count", len(ids))
+
+	query := `
+		SELECT u.id, u.username, u.is_active, COALESCE(
+			ARRAY_AGG(tm.team_name ORDER BY tm.is_primary DESC, tm.team_name) FILTER (WHERE tm.team_name IS NOT NULL),
+			'{}'
+		)
+		FROM users u
+		LEFT JOIN team_memberships tm ON tm.user_id = u.id
+		WHERE u.id = ANY($1) AND u.deleted_at IS NULL
+		GROUP BY u.id
+	`
+	rows, err := u.db.Query(ctx, query, ids)
+	if err != nil {
+		u.log.Error("Failed to get users by IDs", "error", err)
+		return nil, fmt.Errorf("failed to get users by IDs: %w", err)
+	}
+	users, err := pgx.CollectRows(rows, scanUserWithTeams)
+	if err != nil {
+		u.log.Error("Failed to get users by IDs", "error", err)
+		return nil, fmt.Errorf("failed to get users by IDs: %w", err)
+	}
+
+	u.log.Debug("Successfully retrieved users by IDs", "count", len(users))
+	return users, nil
+}
+
+// scanUserWithTeams scans a user followed by the names of their teams, the
+// primary team first.
+func scanUserWithTeams(row pgx.CollectableRow) (*models.User, error) {
+	user := &models.User{}
+	if err := row.Scan(&user.Id, &user.Username, &user.IsActive, &user.Teams); err != nil {
+		return nil, err
+	}
+	if len(user.Teams) > 0 {
+		user.TeamName = user.Teams[0]
+	}
+	return user, nil
+}
+
 func (u *UserStorage) GetUsersByTeam(ctx context.Context, teamName string) ([]*models.User, error) {
 	u.log.Debug("Getting users by team", "team_name", teamName)
 
-	query := `SELECT id, username, is_active, team_name FROM users WHERE team_name = $1 ORDER BY username`
+	query := `
+		SELECT u.id, u.username, u.is_active, COALESCE(p.team_name, ''), tm.role
+		FROM team_memberships tm
+		INNER JOIN users u ON u.id = tm.user_id
+		LEFT JOIN team_memberships p ON p.user_id = u.id AND p.is_primary
+		WHERE tm.team_name = $1
+		ORDER BY u.username
+	`
 	rows, err := u.db.Query(ctx, query, teamName)
 	if err != nil {
 		u.log.Error("Failed to get users by team", "error", err, "team_name", teamName)
@@ -75,7 +213,7 @@ func (u *UserStorage) GetUsersByTeam(ctx context.Context, teamName string) ([]*m
 	var users []*models.User
 	for rows.Next() {
 		user := &models.User{}
-		if err := rows.Scan(&user.Id, &user.Username, &user.IsActive, &user.TeamName); err != nil {
+		if err := rows.Scan(&user.Id, &user.Username, &user.IsActive, &user.TeamName, &user.Role); err != nil {
 			u.log.Error("Failed to scan user", "error", err)
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
@@ -86,10 +224,33 @@ func (u *UserStorage) GetUsersByTeam(ctx context.Context, teamName string) ([]*m
 	return users, nil
 }
 
+// getUserTeams lists the teams of the user, primary team first.
+func (u *UserStorage) getUserTeams(ctx context.Context, userID string) ([]string, error) {
+	query := `SELECT team_name FROM team_memberships WHERE user_id = $1 ORDER BY is_primary DESC, team_name`
+	rows, err := u.db.Query(ctx, query, userID)
+	if err != nil {
+		u.log.Error("Failed to get user teams", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to get user teams: %w", err)
+	}
+	defer rows.Close()
+
+	var teams []string
+	for rows.Next() {
+		var teamName string
+		if err := rows.Scan(&teamName); err != nil {
+			u.log.Error("Failed to scan user team", "error", err)
+			return nil, fmt.Errorf("failed to scan user team: %w", err)
+		}
+		teams = append(teams, teamName)
+	}
+
+	return teams, nil
+}
+
 func (u *UserStorage) SetUserActive(ctx context.Context, userID string, isActive bool) error {
 	u.log.Info("Setting user active status", "user_id", userID, "is_active", isActive)
 
-	query := `UPDATE users SET is_active = $1 WHERE id = $2`
+	query := `UPDATE users SET is_active = $1 WHERE id = $2 AND deleted_at IS NULL`
 	result, err := u.db.Exec(ctx, query, isActive, userID)
 	if err != nil {
 		u.log.Error("Failed to set user active status", "error", err, "user_id", userID)
@@ -99,9 +260,237 @@ func (u *UserStorage) SetUserActive(ctx context.Context, userID string, isActive
 	rowsAffected := result.RowsAffected()
 	if rowsAffected == 0 {
 		u.log.Warn("User not found for active status update", "user_id", userID)
-		return fmt.Errorf("user %s not found", userID)
+		return apperr.NotFound(apperr.CodeUserNotFound, "user %s not found", userID)
 	}
 
 	u.log.Info("Successfully updated user active status", "user_id", userID, "is_active", isActive)
 	return nil
 }
+
+// UpdateUser edits the user. Deactivating them reassigns their open reviews in
+// the same transaction, as DeactivateUser does.
+func (u *UserStorage) UpdateUser(ctx context.Context, userID string, update *models.UserUpdate) (*models.User, error) {
+	u.log.Info("Updating user", "user_id", userID)
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("Failed to begin transaction for user update", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := u.lockUser(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	if update.Username != nil {
+		_, err = tx.Exec(ctx, `UPDATE users SET username = $1 WHERE id = $2`, *update.Username, userID)
+		if err != nil {
+			u.log.Error("Failed to update username", "error", err, "user_id", userID)
+			return nil, fmt.Errorf("failed to update username: %w", err)
+		}
+	}
+
+	if update.IsActive != nil {
+		_, err = tx.Exec(ctx, `UPDATE users SET is_active = $1 WHERE id = $2`, *update.IsActive, userID)
+		if err != nil {
+			u.log.Error("Failed to update user active status", "error", err, "user_id", userID)
+			return nil, fmt.Errorf("failed to update user active status: %w", err)
+		}
+	}
+
+	if update.IsActive != nil && !*update.IsActive {
+		result, err := u.reassignOpenReviews(ctx, tx, userID)
+		if err != nil {
+			return nil, err
+		}
+		u.log.Info("Reassigned open reviews of deactivated user", "user_id", userID,
+			"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	}
+
+	if update.TeamName != nil {
+		if err := u.setPrimaryTeam(ctx, tx, userID, *update.TeamName); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		u.log.Error("Failed to commit user update transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	u.log.Info("Successfully updated user", "user_id", userID)
+	return u.GetUserByID(ctx, userID)
+}
+
+// DeleteUser soft-deletes the user: the row stays for the history of pull
+// requests and reviews, while open reviews are reassigned and team and pool
+// memberships are dropped. Reviews nobody can take over are dropped rather
+// than blocking the deletion.
+func (u *UserStorage) DeleteUser(ctx context.Context, userID string) (*models.ReassignmentResult, error) {
+	u.log.Info("Deleting user", "user_id", userID)
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("Failed to begin transaction for user deletion", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := u.lockUser(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET deleted_at = $1, is_active = false WHERE id = $2`, time.Now(), userID)
+	if err != nil {
+		u.log.Error("Failed to delete user", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	// Reviews are reassigned while the memberships still exist, so that pull
+	// requests without a team fall back to the user's primary team.
+	result, err := u.reassignOpenReviews(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM team_memberships WHERE user_id = $1`, userID); err != nil {
+		u.log.Error("Failed to remove team memberships", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to remove team memberships: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM repository_reviewers WHERE user_id = $1`, userID); err != nil {
+		u.log.Error("Failed to remove repository pool memberships", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to remove repository pool memberships: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		u.log.Error("Failed to commit user deletion transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	u.log.Info("Successfully deleted user", "user_id", userID,
+		"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	return result, nil
+}
+
+// DeactivateUser marks the user inactive and reassigns their open reviews.
+// Unlike DeleteUser it keeps the memberships, so the user can come back.
+// Reviews nobody can take over are dropped rather than blocking the
+// deactivation.
+func (u *UserStorage) DeactivateUser(ctx context.Context, userID string) (*models.ReassignmentResult, error) {
+	u.log.Info("Deactivating user", "user_id", userID)
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		u.log.Error("Failed to begin transaction for user deactivation", "error", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := u.lockUser(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE users SET is_active = false WHERE id = $1`, userID); err != nil {
+		u.log.Error("Failed to deactivate user", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to deactivate user: %w", err)
+	}
+
+	result, err := u.reassignOpenReviews(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		u.log.Error("Failed to commit user deactivation transaction", "error", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	u.log.Info("Successfully deactivated user", "user_id", userID,
+		"reassigned_reviews", result.Reassigned, "unassigned_reviews", result.Unassigned)
+	return result, nil
+}
+
+// reassignOpenReviews moves every review the user holds on open pull requests
+// to another reviewer. Reviews without a candidate are unassigned instead, so
+// that a departing user never blocks on the reviews they leave behind.
+func (u *UserStorage) reassignOpenReviews(ctx context.Context, tx pgx.Tx, userID string) (*models.ReassignmentResult, error) {
+	query := `
+		SELECT prr.repository, prr.pr_id, COALESCE(pr.team_name, '')
+		FROM pull_request_reviewers prr
+		INNER JOIN pull_requests pr ON pr.repository = prr.repository AND pr.id = prr.pr_id
+		WHERE prr.user_id = $1 AND pr.status = 'OPEN'
+		ORDER BY prr.repository, prr.pr_id
+	`
+	rows, err := tx.Query(ctx, query, userID)
+	if err != nil {
+		u.log.Error("Failed to get open reviews of user", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to get open reviews of user: %w", err)
+	}
+	var reviews []openReview
+	for rows.Next() {
+		review := openReview{ReviewerId: userID}
+		if err := rows.Scan(&review.Repository, &review.PullRequestId, &review.TeamName); err != nil {
+			rows.Close()
+			u.log.Error("Failed to scan open review", "error", err)
+			return nil, fmt.Errorf("failed to scan open review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		u.log.Error("Failed to get open reviews of user", "error", err, "user_id", userID)
+		return nil, fmt.Errorf("failed to get open reviews of user: %w", err)
+	}
+
+	return u.prStorage.handOverReviews(ctx, tx, reviews)
+}
+
+// lockUser locks the user row for the rest of tx and fails when the user does
+// not exist or is deleted.
+func (u *UserStorage) lockUser(ctx context.Context, tx pgx.Tx, userID string) error {
+	var id string
+	err := tx.QueryRow(ctx, `SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, userID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			u.log.Warn("User not found", "user_id", userID)
+			return apperr.NotFound(apperr.CodeUserNotFound, "user %s not found", userID)
+		}
+		u.log.Error("Failed to lock user", "error", err, "user_id", userID)
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+	return nil
+}
+
+// setPrimaryTeam makes teamName the primary team of the user, joining it when
+// the user is not a member yet. Other memberships are kept.
+func (u *UserStorage) setPrimaryTeam(ctx context.Context, tx pgx.Tx, userID, teamName string) error {
+	var exists bool
+	err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)`, teamName).Scan(&exists)
+	if err != nil {
+		u.log.Error("Failed to check team existence", "error", err, "team_name", teamName)
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		u.log.Warn("Team not found", "team_name", teamName)
+		return apperr.NotFound(apperr.CodeTeamNotFound, "team %s not found", teamName)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE team_memberships SET is_primary = false WHERE user_id = $1 AND team_name != $2`, userID, teamName)
+	if err != nil {
+		u.log.Error("Failed to reset primary team", "error", err, "user_id", userID)
+		return fmt.Errorf("failed to reset primary team of %s: %w", userID, err)
+	}
+
+	query := `
+		INSERT INTO team_memberships (team_name, user_id, is_primary)
+		VALUES ($1, $2, true)
+		ON CONFLICT (team_name, user_id) DO UPDATE SET is_primary = true
+	`
+	if _, err = tx.Exec(ctx, query, teamName, userID); err != nil {
+		u.log.Error("Failed to set primary team", "error", err, "user_id", userID, "team_name", teamName)
+		return fmt.Errorf("failed to set primary team of %s: %w", userID, err)
+	}
+	return nil
+}
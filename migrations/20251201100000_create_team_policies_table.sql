@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE team_policies (
+    team_name VARCHAR(50) PRIMARY KEY REFERENCES teams(name) ON DELETE CASCADE,
+    first_response_hours INT NOT NULL DEFAULT 24,
+    business_hours_only BOOLEAN NOT NULL DEFAULT TRUE,
+    sla_action VARCHAR(20) NOT NULL DEFAULT 'REASSIGN',
+    escalation_reviewer_id VARCHAR(50) REFERENCES users(id)
+);
+
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN responded_at TIMESTAMP,
+    ADD COLUMN verdict VARCHAR(20),
+    ADD COLUMN escalated_at TIMESTAMP;
+
+CREATE TABLE pull_request_sla_actions (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(50) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(50) NOT NULL REFERENCES users(id),
+    action VARCHAR(20) NOT NULL,
+    new_reviewer_id VARCHAR(50) REFERENCES users(id),
+    deadline TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pull_request_sla_actions_pr_id ON pull_request_sla_actions(pr_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS pull_request_sla_actions;
+
+ALTER TABLE pull_request_reviewers
+    DROP COLUMN IF EXISTS assigned_at,
+    DROP COLUMN IF EXISTS responded_at,
+    DROP COLUMN IF EXISTS verdict,
+    DROP COLUMN IF EXISTS escalated_at;
+
+DROP TABLE IF EXISTS team_policies;
+-- +goose StatementEnd
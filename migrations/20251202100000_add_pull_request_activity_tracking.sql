@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_requests
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN stale_at TIMESTAMP,
+    ADD COLUMN closed_at TIMESTAMP;
+
+UPDATE pull_requests SET updated_at = COALESCE(merged_at, created_at, CURRENT_TIMESTAMP);
+
+CREATE INDEX idx_pull_requests_open_updated_at ON pull_requests(updated_at) WHERE status = 'OPEN';
+
+ALTER TABLE team_policies
+    ADD COLUMN stale_after_days INT NOT NULL DEFAULT 0,
+    ADD COLUMN close_after_days INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_policies
+    DROP COLUMN IF EXISTS stale_after_days,
+    DROP COLUMN IF EXISTS close_after_days;
+
+DROP INDEX IF EXISTS idx_pull_requests_open_updated_at;
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS stale_at,
+    DROP COLUMN IF EXISTS closed_at;
+-- +goose StatementEnd
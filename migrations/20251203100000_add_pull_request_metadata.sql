@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE pull_requests
+    ADD COLUMN repository VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN labels TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'NORMAL',
+    ADD COLUMN url VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN additions INT NOT NULL DEFAULT 0,
+    ADD COLUMN deletions INT NOT NULL DEFAULT 0,
+    ADD COLUMN changed_files INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_pull_requests_repository ON pull_requests(repository);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pull_requests_repository;
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS repository,
+    DROP COLUMN IF EXISTS labels,
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS url,
+    DROP COLUMN IF EXISTS additions,
+    DROP COLUMN IF EXISTS deletions,
+    DROP COLUMN IF EXISTS changed_files;
+-- +goose StatementEnd
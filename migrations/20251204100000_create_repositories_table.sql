@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE repositories (
+    name VARCHAR(100) PRIMARY KEY,
+    reviewers_count INT NOT NULL DEFAULT 0,
+    fallback_to_author_team BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE repository_reviewers (
+    repository VARCHAR(100) NOT NULL REFERENCES repositories(name) ON DELETE CASCADE,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id),
+    PRIMARY KEY (repository, user_id)
+);
+
+-- Идентификатор PR уникален в пределах репозитория
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pr_id_fkey;
+ALTER TABLE pull_request_sla_actions DROP CONSTRAINT pull_request_sla_actions_pr_id_fkey;
+
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey;
+ALTER TABLE pull_requests ADD PRIMARY KEY (repository, id);
+
+ALTER TABLE pull_request_reviewers ADD COLUMN repository VARCHAR(100) NOT NULL DEFAULT '';
+UPDATE pull_request_reviewers prr SET repository = pr.repository
+FROM pull_requests pr WHERE pr.id = prr.pr_id;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey;
+ALTER TABLE pull_request_reviewers ADD PRIMARY KEY (repository, pr_id, user_id);
+ALTER TABLE pull_request_reviewers ADD CONSTRAINT pull_request_reviewers_pr_fkey
+FOREIGN KEY (repository, pr_id) REFERENCES pull_requests(repository, id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE pull_request_sla_actions ADD COLUMN repository VARCHAR(100) NOT NULL DEFAULT '';
+UPDATE pull_request_sla_actions a SET repository = pr.repository
+FROM pull_requests pr WHERE pr.id = a.pr_id;
+ALTER TABLE pull_request_sla_actions ADD CONSTRAINT pull_request_sla_actions_pr_fkey
+FOREIGN KEY (repository, pr_id) REFERENCES pull_requests(repository, id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+DROP INDEX IF EXISTS idx_pull_request_sla_actions_pr_id;
+CREATE INDEX idx_pull_request_sla_actions_pr ON pull_request_sla_actions(repository, pr_id);
+CREATE INDEX idx_pull_request_reviewers_user_id ON pull_request_reviewers(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pull_request_reviewers_user_id;
+DROP INDEX IF EXISTS idx_pull_request_sla_actions_pr;
+
+ALTER TABLE pull_request_sla_actions DROP CONSTRAINT IF EXISTS pull_request_sla_actions_pr_fkey;
+ALTER TABLE pull_request_sla_actions DROP COLUMN IF EXISTS repository;
+
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT IF EXISTS pull_request_reviewers_pr_fkey;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey;
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS repository;
+ALTER TABLE pull_request_reviewers ADD PRIMARY KEY (pr_id, user_id);
+
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey;
+ALTER TABLE pull_requests ADD PRIMARY KEY (id);
+
+ALTER TABLE pull_request_reviewers ADD CONSTRAINT pull_request_reviewers_pr_id_fkey
+FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE;
+ALTER TABLE pull_request_sla_actions ADD CONSTRAINT pull_request_sla_actions_pr_id_fkey
+FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE;
+CREATE INDEX idx_pull_request_sla_actions_pr_id ON pull_request_sla_actions(pr_id);
+
+DROP TABLE IF EXISTS repository_reviewers;
+DROP TABLE IF EXISTS repositories;
+-- +goose StatementEnd
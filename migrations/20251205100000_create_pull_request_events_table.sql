@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE pull_request_events (
+    id BIGSERIAL PRIMARY KEY,
+    repository VARCHAR(100) NOT NULL,
+    pr_id VARCHAR(50) NOT NULL,
+    event_type VARCHAR(30) NOT NULL,
+    actor VARCHAR(50) NOT NULL,
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (repository, pr_id) REFERENCES pull_requests(repository, id)
+);
+
+CREATE INDEX idx_pull_request_events_pr ON pull_request_events(repository, pr_id, id);
+
+-- Журнал событий только дополняется
+CREATE FUNCTION pull_request_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pull_request_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pull_request_events_no_update_delete
+BEFORE UPDATE OR DELETE ON pull_request_events
+FOR EACH ROW EXECUTE FUNCTION pull_request_events_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS pull_request_events_no_update_delete ON pull_request_events;
+DROP FUNCTION IF EXISTS pull_request_events_append_only();
+DROP TABLE IF EXISTS pull_request_events;
+-- +goose StatementEnd
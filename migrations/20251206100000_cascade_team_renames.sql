@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Переименование команды каскадно обновляет ссылки на неё
+ALTER TABLE users DROP CONSTRAINT fk_user_team;
+ALTER TABLE users ADD CONSTRAINT fk_user_team
+FOREIGN KEY (team_name) REFERENCES teams(name) ON UPDATE CASCADE;
+
+ALTER TABLE team_policies DROP CONSTRAINT team_policies_team_name_fkey;
+ALTER TABLE team_policies ADD CONSTRAINT team_policies_team_name_fkey
+FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_policies DROP CONSTRAINT team_policies_team_name_fkey;
+ALTER TABLE team_policies ADD CONSTRAINT team_policies_team_name_fkey
+FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE;
+
+ALTER TABLE users DROP CONSTRAINT fk_user_team;
+ALTER TABLE users ADD CONSTRAINT fk_user_team
+FOREIGN KEY (team_name) REFERENCES teams(name);
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Пользователь может состоять в нескольких командах, одна из них основная
+CREATE TABLE team_memberships (
+    team_name VARCHAR(50) NOT NULL REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id),
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_name, user_id)
+);
+
+CREATE INDEX idx_team_memberships_user_id ON team_memberships(user_id);
+CREATE UNIQUE INDEX idx_team_memberships_primary ON team_memberships(user_id) WHERE is_primary;
+
+INSERT INTO team_memberships (team_name, user_id, is_primary)
+SELECT team_name, id, TRUE FROM users WHERE team_name IS NOT NULL;
+
+-- Команда, ревьюеры которой назначаются на PR
+ALTER TABLE pull_requests ADD COLUMN team_name VARCHAR(50)
+REFERENCES teams(name) ON DELETE SET NULL ON UPDATE CASCADE;
+
+UPDATE pull_requests pr SET team_name = u.team_name
+FROM users u WHERE u.id = pr.author_id;
+
+CREATE INDEX idx_pull_requests_team_name ON pull_requests(team_name);
+
+ALTER TABLE repositories ADD COLUMN team_name VARCHAR(50)
+REFERENCES teams(name) ON DELETE SET NULL ON UPDATE CASCADE;
+
+ALTER TABLE users DROP CONSTRAINT fk_user_team;
+ALTER TABLE users DROP COLUMN team_name;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN team_name VARCHAR(50);
+ALTER TABLE users ADD CONSTRAINT fk_user_team
+FOREIGN KEY (team_name) REFERENCES teams(name) ON UPDATE CASCADE;
+
+UPDATE users u SET team_name = tm.team_name
+FROM team_memberships tm WHERE tm.user_id = u.id AND tm.is_primary;
+
+ALTER TABLE repositories DROP COLUMN IF EXISTS team_name;
+
+DROP INDEX IF EXISTS idx_pull_requests_team_name;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS team_name;
+
+DROP TABLE IF EXISTS team_memberships;
+-- +goose StatementEnd
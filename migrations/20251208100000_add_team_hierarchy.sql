@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Команды образуют дерево: департаменты -> команды -> сквады
+ALTER TABLE teams ADD COLUMN parent_name VARCHAR(50)
+REFERENCES teams(name) ON DELETE SET NULL ON UPDATE CASCADE;
+
+ALTER TABLE teams ADD CONSTRAINT teams_parent_not_self CHECK (parent_name <> name);
+
+CREATE INDEX idx_teams_parent_name ON teams(parent_name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_teams_parent_name;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_parent_not_self;
+ALTER TABLE teams DROP COLUMN IF EXISTS parent_name;
+-- +goose StatementEnd
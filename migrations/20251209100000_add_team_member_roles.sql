@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Роль участника в команде: лид, участник или наблюдатель (не назначается ревьюером)
+ALTER TABLE team_memberships ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'member'
+CHECK (role IN ('lead', 'member', 'observer'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_memberships DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
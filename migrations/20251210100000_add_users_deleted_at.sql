@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Мягкое удаление: строки пользователя остаются для истории PR и ревью
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+
+-- Удалённый пользователь всегда неактивен, поэтому не выбирается ревьюером
+ALTER TABLE users ADD CONSTRAINT users_deleted_inactive CHECK (deleted_at IS NULL OR NOT is_active);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_deleted_inactive;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
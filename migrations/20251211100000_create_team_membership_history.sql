@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Интервалы участия пользователей в командах
+CREATE TABLE team_membership_history (
+    id BIGSERIAL PRIMARY KEY,
+    team_name VARCHAR(50) NOT NULL REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    user_id VARCHAR(50) NOT NULL REFERENCES users(id),
+    joined_at TIMESTAMP NOT NULL,
+    left_at TIMESTAMP
+);
+
+CREATE INDEX idx_team_membership_history_team ON team_membership_history(team_name, joined_at);
+CREATE INDEX idx_team_membership_history_user ON team_membership_history(user_id, joined_at);
+
+INSERT INTO team_membership_history (team_name, user_id, joined_at)
+SELECT team_name, user_id, created_at FROM team_memberships;
+
+-- Интервал открывается при вступлении в команду и закрывается при выходе
+CREATE FUNCTION team_memberships_track_history() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO team_membership_history (team_name, user_id, joined_at)
+        VALUES (NEW.team_name, NEW.user_id, NEW.created_at);
+        RETURN NEW;
+    END IF;
+
+    UPDATE team_membership_history SET left_at = CURRENT_TIMESTAMP
+    WHERE team_name = OLD.team_name AND user_id = OLD.user_id AND left_at IS NULL;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER team_memberships_history
+AFTER INSERT OR DELETE ON team_memberships
+FOR EACH ROW EXECUTE FUNCTION team_memberships_track_history();
+
+-- Ревью относится к команде ревьюера на момент назначения: к команде PR,
+-- если ревьюер в ней состоит, иначе к его основной команде
+ALTER TABLE pull_request_reviewers ADD COLUMN team_name VARCHAR(50)
+REFERENCES teams(name) ON DELETE SET NULL ON UPDATE CASCADE;
+
+UPDATE pull_request_reviewers prr SET team_name = COALESCE(
+    (SELECT tm.team_name FROM team_memberships tm
+        INNER JOIN pull_requests pr ON pr.team_name = tm.team_name
+        WHERE tm.user_id = prr.user_id AND pr.repository = prr.repository AND pr.id = prr.pr_id),
+    (SELECT tm.team_name FROM team_memberships tm WHERE tm.user_id = prr.user_id AND tm.is_primary)
+);
+
+CREATE INDEX idx_pull_request_reviewers_team_name ON pull_request_reviewers(team_name);
+
+CREATE FUNCTION pull_request_reviewers_set_team() RETURNS trigger AS $$
+BEGIN
+    NEW.team_name := COALESCE(
+        (SELECT tm.team_name FROM team_memberships tm
+            INNER JOIN pull_requests pr ON pr.team_name = tm.team_name
+            WHERE tm.user_id = NEW.user_id AND pr.repository = NEW.repository AND pr.id = NEW.pr_id),
+        (SELECT tm.team_name FROM team_memberships tm WHERE tm.user_id = NEW.user_id AND tm.is_primary)
+    );
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pull_request_reviewers_team
+BEFORE INSERT OR UPDATE OF user_id ON pull_request_reviewers
+FOR EACH ROW EXECUTE FUNCTION pull_request_reviewers_set_team();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS pull_request_reviewers_team ON pull_request_reviewers;
+DROP FUNCTION IF EXISTS pull_request_reviewers_set_team();
+DROP INDEX IF EXISTS idx_pull_request_reviewers_team_name;
+ALTER TABLE pull_request_reviewers DROP COLUMN IF EXISTS team_name;
+
+DROP TRIGGER IF EXISTS team_memberships_history ON team_memberships;
+DROP FUNCTION IF EXISTS team_memberships_track_history();
+DROP TABLE IF EXISTS team_membership_history;
+-- +goose StatementEnd
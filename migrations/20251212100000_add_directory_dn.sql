@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- DN записи в LDAP: пользователями и командами с DN управляет синхронизация каталога
+ALTER TABLE users ADD COLUMN directory_dn VARCHAR(512);
+ALTER TABLE teams ADD COLUMN directory_dn VARCHAR(512);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE teams DROP COLUMN IF EXISTS directory_dn;
+ALTER TABLE users DROP COLUMN IF EXISTS directory_dn;
+-- +goose StatementEnd
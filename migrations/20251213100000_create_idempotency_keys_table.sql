@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ключи идемпотентности: отпечаток запроса и сохранённый ответ для повторов.
+-- Пока запрос выполняется, completed_at пуст
+CREATE TABLE idempotency_keys (
+    actor_id VARCHAR(50) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    status_code INTEGER,
+    content_type VARCHAR(255),
+    response_body BYTEA,
+    created_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    PRIMARY KEY (actor_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
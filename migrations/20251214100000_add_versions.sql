@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Версии PR и команд для оптимистичной блокировки (ETag / If-Match).
+-- Любое изменение строки увеличивает версию
+ALTER TABLE pull_requests ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE teams ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+
+CREATE FUNCTION bump_version() RETURNS trigger AS $$
+BEGIN
+    IF NEW.version = OLD.version THEN
+        NEW.version := OLD.version + 1;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pull_requests_version
+BEFORE UPDATE ON pull_requests
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION bump_version();
+
+CREATE TRIGGER teams_version
+BEFORE UPDATE ON teams
+FOR EACH ROW WHEN (OLD.* IS DISTINCT FROM NEW.*) EXECUTE FUNCTION bump_version();
+
+-- Состав и роли участников входят в версию команды. Каскадное обновление
+-- team_name при переименовании команды триггер не вызывает
+CREATE FUNCTION team_memberships_bump_team_version() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE teams SET version = version + 1 WHERE name = OLD.team_name;
+    ELSE
+        UPDATE teams SET version = version + 1 WHERE name = NEW.team_name;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER team_memberships_team_version
+AFTER INSERT OR DELETE OR UPDATE OF is_primary, role ON team_memberships
+FOR EACH ROW EXECUTE FUNCTION team_memberships_bump_team_version();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS team_memberships_team_version ON team_memberships;
+DROP FUNCTION IF EXISTS team_memberships_bump_team_version();
+DROP TRIGGER IF EXISTS teams_version ON teams;
+DROP TRIGGER IF EXISTS pull_requests_version ON pull_requests;
+DROP FUNCTION IF EXISTS bump_version();
+ALTER TABLE teams DROP COLUMN IF EXISTS version;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
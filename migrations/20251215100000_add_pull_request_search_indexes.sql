@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Курсорная пагинация сортирует по (created_at | updated_at, repository, id),
+-- поэтому created_at не может быть пустым
+UPDATE pull_requests SET created_at = updated_at WHERE created_at IS NULL;
+ALTER TABLE pull_requests ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX idx_pull_requests_created_at ON pull_requests(created_at, repository, id);
+CREATE INDEX idx_pull_requests_updated_at ON pull_requests(updated_at, repository, id);
+CREATE INDEX idx_pull_requests_author_created_at ON pull_requests(author_id, created_at);
+CREATE INDEX idx_pull_requests_team_created_at ON pull_requests(team_name, created_at);
+CREATE INDEX idx_pull_requests_status_created_at ON pull_requests(status, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pull_requests_status_created_at;
+DROP INDEX IF EXISTS idx_pull_requests_team_created_at;
+DROP INDEX IF EXISTS idx_pull_requests_author_created_at;
+DROP INDEX IF EXISTS idx_pull_requests_updated_at;
+DROP INDEX IF EXISTS idx_pull_requests_created_at;
+
+ALTER TABLE pull_requests ALTER COLUMN created_at DROP NOT NULL;
+-- +goose StatementEnd
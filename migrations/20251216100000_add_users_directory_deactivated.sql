@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Пользователь деактивирован синхронизацией каталога, потому что пропал из
+-- него. Только таких пользователей синхронизация активирует снова, когда они
+-- возвращаются в каталог; деактивацию через API она не отменяет
+ALTER TABLE users ADD COLUMN directory_deactivated BOOLEAN NOT NULL DEFAULT false;
+
+-- Любое другое изменение is_active снимает отметку
+CREATE FUNCTION users_clear_directory_deactivated() RETURNS trigger AS $$
+BEGIN
+    IF NEW.directory_deactivated = OLD.directory_deactivated THEN
+        NEW.directory_deactivated := false;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_directory_deactivated
+BEFORE UPDATE OF is_active ON users
+FOR EACH ROW EXECUTE FUNCTION users_clear_directory_deactivated();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS users_directory_deactivated ON users;
+DROP FUNCTION IF EXISTS users_clear_directory_deactivated();
+ALTER TABLE users DROP COLUMN IF EXISTS directory_deactivated;
+-- +goose StatementEnd
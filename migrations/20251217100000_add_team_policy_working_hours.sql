@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Рабочие часы команды: при business_hours_only в SLA засчитываются только
+-- часы с workday_start до workday_end по будням в часовом поясе time_zone
+ALTER TABLE team_policies
+    ADD COLUMN workday_start INT NOT NULL DEFAULT 9,
+    ADD COLUMN workday_end INT NOT NULL DEFAULT 18,
+    ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD CONSTRAINT team_policies_workday_check
+        CHECK (workday_start >= 0 AND workday_start < workday_end AND workday_end <= 24);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE team_policies
+    DROP CONSTRAINT IF EXISTS team_policies_workday_check,
+    DROP COLUMN IF EXISTS time_zone,
+    DROP COLUMN IF EXISTS workday_end,
+    DROP COLUMN IF EXISTS workday_start;
+-- +goose StatementEnd
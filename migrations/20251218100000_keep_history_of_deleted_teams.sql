@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- История состава и команда ревьюера на момент назначения хранят имя команды
+-- без внешнего ключа: удаление команды не стирает её историю и статистику.
+-- Переименование по-прежнему переносит их на новое имя
+ALTER TABLE team_membership_history DROP CONSTRAINT team_membership_history_team_name_fkey;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_team_name_fkey;
+
+CREATE FUNCTION teams_rename_history() RETURNS trigger AS $$
+BEGIN
+    UPDATE team_membership_history SET team_name = NEW.name WHERE team_name = OLD.name;
+    UPDATE pull_request_reviewers SET team_name = NEW.name WHERE team_name = OLD.name;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER teams_rename_history
+AFTER UPDATE OF name ON teams
+FOR EACH ROW WHEN (OLD.name IS DISTINCT FROM NEW.name) EXECUTE FUNCTION teams_rename_history();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS teams_rename_history ON teams;
+DROP FUNCTION IF EXISTS teams_rename_history();
+
+DELETE FROM team_membership_history WHERE team_name NOT IN (SELECT name FROM teams);
+UPDATE pull_request_reviewers SET team_name = NULL WHERE team_name NOT IN (SELECT name FROM teams);
+
+ALTER TABLE pull_request_reviewers ADD CONSTRAINT pull_request_reviewers_team_name_fkey
+FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE team_membership_history ADD CONSTRAINT team_membership_history_team_name_fkey
+FOREIGN KEY (team_name) REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,152 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// apiRequest sends a JSON request to the API and decodes the response.
+func apiRequest(t *testing.T, method, path string, body any) (int, map[string]interface{}) {
+	t.Helper()
+
+	var reader *bytes.Buffer
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewBuffer(data)
+	} else {
+		reader = &bytes.Buffer{}
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	GetTestServer().GetRouter().ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return w.Code, response
+}
+
+func assertAPIError(t *testing.T, code int, response map[string]interface{}, wantStatus int, wantCode string) {
+	t.Helper()
+
+	assert.Equal(t, wantStatus, code)
+	require.IsType(t, map[string]interface{}{}, response["error"])
+	apiError := response["error"].(map[string]interface{})
+	assert.Equal(t, wantCode, apiError["code"])
+	assert.NotEmpty(t, apiError["message"])
+}
+
+func TestE2E_ErrorResponses(t *testing.T) {
+	SetupE2ETest(t)
+	defer TeardownE2ETest()
+
+	code, _ := apiRequest(t, "POST", "/api/v1/team/add", map[string]interface{}{
+		"team_name": "team1",
+		"members": []map[string]interface{}{
+			{"id": "author1", "username": "author1", "is_active": true},
+			{"id": "reviewer1", "username": "reviewer1", "is_active": true},
+			{"id": "inactive1", "username": "inactive1", "is_active": false},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = apiRequest(t, "POST", "/api/v1/pull-request/create", map[string]interface{}{
+		"pull_request_id":   "pr1",
+		"pull_request_name": "Test PR",
+		"author_id":         "author1",
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	t.Run("invalid body", func(t *testing.T) {
+		code, response := apiRequest(t, "POST", "/api/v1/pull-request/merge", map[string]interface{}{})
+		assertAPIError(t, code, response, http.StatusBadRequest, "INVALID_REQUEST")
+	})
+
+	t.Run("unknown pull request", func(t *testing.T) {
+		code, response := apiRequest(t, "POST", "/api/v1/pull-request/merge", map[string]interface{}{"pull_request_id": "missing"})
+		assertAPIError(t, code, response, http.StatusNotFound, "PR_NOT_FOUND")
+
+		code, response = apiRequest(t, "GET", "/api/v1/pull-request/missing", nil)
+		assertAPIError(t, code, response, http.StatusNotFound, "PR_NOT_FOUND")
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		code, response := apiRequest(t, "GET", "/api/v1/team/missing", nil)
+		assertAPIError(t, code, response, http.StatusNotFound, "TEAM_NOT_FOUND")
+	})
+
+	t.Run("inactive author", func(t *testing.T) {
+		code, response := apiRequest(t, "POST", "/api/v1/pull-request/create", map[string]interface{}{
+			"pull_request_id":   "pr2",
+			"pull_request_name": "Test PR",
+			"author_id":         "inactive1",
+		})
+		assertAPIError(t, code, response, http.StatusUnprocessableEntity, "AUTHOR_INACTIVE")
+	})
+
+	t.Run("reviewer not assigned", func(t *testing.T) {
+		code, response := apiRequest(t, "POST", "/api/v1/pull-request/reassign", map[string]interface{}{
+			"pull_request_id": "pr1",
+			"old_user_id":     "author1",
+		})
+		assertAPIError(t, code, response, http.StatusConflict, "NOT_ASSIGNED")
+	})
+
+	t.Run("no candidates", func(t *testing.T) {
+		code, response := apiRequest(t, "POST", "/api/v1/pull-request/reassign", map[string]interface{}{
+			"pull_request_id": "pr1",
+			"old_user_id":     "reviewer1",
+		})
+		assertAPIError(t, code, response, http.StatusUnprocessableEntity, "NO_CANDIDATE")
+	})
+
+	t.Run("merged pull request", func(t *testing.T) {
+		code, _ := apiRequest(t, "POST", "/api/v1/pull-request/merge", map[string]interface{}{"pull_request_id": "pr1"})
+		require.Equal(t, http.StatusOK, code)
+
+		code, response := apiRequest(t, "POST", "/api/v1/pull-request/reassign", map[string]interface{}{
+			"pull_request_id": "pr1",
+			"old_user_id":     "reviewer1",
+		})
+		assertAPIError(t, code, response, http.StatusConflict, "PR_MERGED")
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		code, response := apiRequest(t, "POST", "/api/v1/users", map[string]interface{}{"id": "author1", "username": "author1", "is_active": true})
+		assertAPIError(t, code, response, http.StatusConflict, "USER_EXISTS")
+
+		code, response = apiRequest(t, "POST", "/api/v1/team/add", map[string]interface{}{"team_name": "team1", "members": []interface{}{}})
+		assertAPIError(t, code, response, http.StatusConflict, "TEAM_EXISTS")
+
+		code, response = apiRequest(t, "POST", "/api/v1/pull-request/create", map[string]interface{}{
+			"pull_request_id":   "pr1",
+			"pull_request_name": "Test PR",
+			"author_id":         "author1",
+		})
+		assertAPIError(t, code, response, http.StatusConflict, "PR_EXISTS")
+	})
+
+	t.Run("webhook retry returns the existing pull request", func(t *testing.T) {
+		code, response := apiRequest(t, "POST", "/api/v1/pull-request/create?on_exists=return", map[string]interface{}{
+			"pull_request_id":   "pr1",
+			"pull_request_name": "Test PR",
+			"author_id":         "author1",
+		})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "pr1", response["pull_request_id"])
+		assert.Equal(t, []interface{}{"reviewer1"}, response["assigned_reviewers"])
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		code, response := apiRequest(t, "GET", "/api/v1/missing", nil)
+		assertAPIError(t, code, response, http.StatusNotFound, "NOT_FOUND")
+	})
+}
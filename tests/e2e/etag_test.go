@@ -0,0 +1,122 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conditionalRequest sends a JSON request with an If-Match header and returns
+// the raw recorder so the ETag can be inspected.
+func conditionalRequest(t *testing.T, method, path, ifMatch string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	reader := &bytes.Buffer{}
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewBuffer(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	GetTestServer().GetRouter().ServeHTTP(w, req)
+	return w
+}
+
+func decodeResponse(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func TestE2E_ETagConcurrencyControl(t *testing.T) {
+	SetupE2ETest(t)
+	defer TeardownE2ETest()
+
+	w := conditionalRequest(t, "POST", "/api/v1/team/add", "", map[string]interface{}{
+		"team_name": "team1",
+		"members": []map[string]interface{}{
+			{"id": "author1", "username": "author1", "is_active": true},
+			{"id": "reviewer1", "username": "reviewer1", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+
+	w = conditionalRequest(t, "POST", "/api/v1/pull-request/create", "", map[string]interface{}{
+		"pull_request_id":   "pr1",
+		"pull_request_name": "Test PR",
+		"author_id":         "author1",
+	})
+	require.Equal(t, http.StatusCreated, w.Code)
+	created := w.Header().Get("ETag")
+	require.NotEmpty(t, created)
+
+	w = conditionalRequest(t, "GET", "/api/v1/pull-request/pr1", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, created, w.Header().Get("ETag"))
+	assert.EqualValues(t, 1, decodeResponse(t, w)["version"])
+
+	review := map[string]interface{}{
+		"pull_request_id": "pr1",
+		"user_id":         "reviewer1",
+		"verdict":         "COMMENTED",
+	}
+
+	t.Run("matching If-Match succeeds and returns a new ETag", func(t *testing.T) {
+		w := conditionalRequest(t, "POST", "/api/v1/pull-request/review", created, review)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+		assert.NotEqual(t, created, w.Header().Get("ETag"))
+	})
+
+	t.Run("stale If-Match is rejected", func(t *testing.T) {
+		w := conditionalRequest(t, "POST", "/api/v1/pull-request/merge", created, map[string]interface{}{"pull_request_id": "pr1"})
+		assertAPIError(t, w.Code, decodeResponse(t, w), http.StatusPreconditionFailed, "VERSION_MISMATCH")
+
+		w = conditionalRequest(t, "GET", "/api/v1/pull-request/pr1", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "OPEN", decodeResponse(t, w)["status"])
+	})
+
+	t.Run("malformed If-Match is rejected", func(t *testing.T) {
+		w := conditionalRequest(t, "POST", "/api/v1/pull-request/merge", "version-1", map[string]interface{}{"pull_request_id": "pr1"})
+		assertAPIError(t, w.Code, decodeResponse(t, w), http.StatusBadRequest, "INVALID_REQUEST")
+	})
+
+	t.Run("current ETag allows the merge", func(t *testing.T) {
+		w := conditionalRequest(t, "GET", "/api/v1/pull-request/pr1", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		current := w.Header().Get("ETag")
+
+		w = conditionalRequest(t, "POST", "/api/v1/pull-request/merge", current, map[string]interface{}{"pull_request_id": "pr1"})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, current, w.Header().Get("ETag"))
+	})
+
+	t.Run("team updates honor If-Match", func(t *testing.T) {
+		w := conditionalRequest(t, "GET", "/api/v1/team/team1", "", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		current := w.Header().Get("ETag")
+		require.NotEmpty(t, current)
+
+		w = conditionalRequest(t, "PATCH", "/api/v1/team/team1", `"999"`, map[string]interface{}{"team_name": "team2"})
+		assertAPIError(t, w.Code, decodeResponse(t, w), http.StatusPreconditionFailed, "VERSION_MISMATCH")
+
+		w = conditionalRequest(t, "PATCH", "/api/v1/team/team1", current, map[string]interface{}{"team_name": "team2"})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+	})
+}
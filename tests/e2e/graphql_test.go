@@ -0,0 +1,429 @@
+package e2e
+
+import (
+	"avito-autumn-2025/internal/graphql"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// graphqlRequest posts query to /graphql and decodes the GraphQL response.
+func graphqlRequest(t *testing.T, router *gin.Engine, query string, variables map[string]any) graphqlResponse {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "/graphql", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response graphqlResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
+
+func TestGraphQL_InvalidRequests(t *testing.T) {
+	router := routesServer().GetRouter()
+
+	t.Run("malformed body", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/graphql", bytes.NewBufferString(`{"query":`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assertAPIError(t, w.Code, response, http.StatusBadRequest, "INVALID_REQUEST")
+	})
+
+	t.Run("missing query", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/graphql", bytes.NewBufferString(`{"variables":{}}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assertAPIError(t, w.Code, response, http.StatusBadRequest, "INVALID_REQUEST")
+	})
+
+	t.Run("syntax and schema errors", func(t *testing.T) {
+		response := graphqlRequest(t, router, `{ team(name: "backend") {`, nil)
+		assert.NotEmpty(t, response.Errors)
+		assert.Empty(t, response.Data)
+
+		response = graphqlRequest(t, router, `{ team(name: "backend") { budget } }`, nil)
+		require.NotEmpty(t, response.Errors)
+		assert.Contains(t, response.Errors[0].Message, "budget")
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		response := graphqlRequest(t, router, `{ pullRequests(first: 0) { nextCursor } }`, nil)
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "INVALID_REQUEST", response.Errors[0].Extensions.Code)
+		assert.Contains(t, response.Errors[0].Message, "first")
+	})
+
+	t.Run("cursor of another order", func(t *testing.T) {
+		cursor := (&models.PullRequestCursor{Sort: models.SortByCreatedAt, Order: models.SortDesc, PullRequestId: "pr1"}).Encode()
+		response := graphqlRequest(t, router, `query($after: String) { pullRequests(order: ASC, after: $after) { nextCursor } }`,
+			map[string]any{"after": cursor})
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, "INVALID_REQUEST", response.Errors[0].Extensions.Code)
+	})
+}
+
+// graphqlFixture is an in-memory storage that counts the calls made to it.
+type graphqlFixture struct {
+	mu          sync.Mutex
+	calls       map[string]int
+	users       map[string]*models.User
+	members     []*models.User
+	prs         map[models.PullRequestKey]*models.PullRequest
+	assignments []*models.ReviewAssignment
+}
+
+func (f *graphqlFixture) called(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[method]++
+}
+
+type fixtureUserStorage struct {
+	storage.User
+	*graphqlFixture
+}
+
+func (s fixtureUserStorage) GetUsersByIDs(_ context.Context, ids []string) ([]*models.User, error) {
+	s.called("GetUsersByIDs")
+	var users []*models.User
+	for _, id := range ids {
+		if user, ok := s.users[id]; ok {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+type fixtureTeamStorage struct {
+	storage.Team
+	*graphqlFixture
+}
+
+func (s fixtureTeamStorage) GetTeamsByNames(_ context.Context, names []string) ([]*models.Team, error) {
+	s.called("GetTeamsByNames")
+	var teams []*models.Team
+	for _, name := range names {
+		if name == "backend" {
+			teams = append(teams, &models.Team{Id: name, Name: name, Version: 1})
+		}
+	}
+	return teams, nil
+}
+
+func (s fixtureTeamStorage) GetTeamMembers(_ context.Context, names []string) (map[string][]*models.User, error) {
+	s.called("GetTeamMembers")
+	return map[string][]*models.User{"backend": s.members}, nil
+}
+
+type fixturePullRequestStorage struct {
+	storage.PullRequest
+	*graphqlFixture
+}
+
+func (s fixturePullRequestStorage) GetPullRequestsByKeys(_ context.Context, keys []models.PullRequestKey) ([]*models.PullRequest, error) {
+	s.called("GetPullRequestsByKeys")
+	var prs []*models.PullRequest
+	for _, key := range keys {
+		if pr, ok := s.prs[key]; ok {
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
+
+func (s fixturePullRequestStorage) GetReviewAssignmentsByReviewers(_ context.Context, ids []string) ([]*models.ReviewAssignment, error) {
+	s.called("GetReviewAssignmentsByReviewers")
+	var assignments []*models.ReviewAssignment
+	for _, a := range s.assignments {
+		for _, id := range ids {
+			if a.ReviewerId == id {
+				assignments = append(assignments, a)
+			}
+		}
+	}
+	return assignments, nil
+}
+
+func (s fixturePullRequestStorage) GetReviewAssignmentsByPullRequests(_ context.Context, keys []models.PullRequestKey) ([]*models.ReviewAssignment, error) {
+	s.called("GetReviewAssignmentsByPullRequests")
+	var assignments []*models.ReviewAssignment
+	for _, a := range s.assignments {
+		for _, key := range keys {
+			if a.Repository == key.Repository && a.PullRequestId == key.PullRequestId {
+				assignments = append(assignments, a)
+			}
+		}
+	}
+	return assignments, nil
+}
+
+func TestGraphQL_BatchesNestedQueries(t *testing.T) {
+	const memberCount = 30
+
+	// Every member authors one pull request reviewed by the next two members.
+	fixture := &graphqlFixture{
+		calls: map[string]int{},
+		users: map[string]*models.User{},
+		prs:   map[models.PullRequestKey]*models.PullRequest{},
+	}
+	userID := func(i int) string { return fmt.Sprintf("user%02d", i%memberCount) }
+	for i := 0; i < memberCount; i++ {
+		user := &models.User{Id: userID(i), Username: userID(i), IsActive: true, TeamName: "backend", Teams: []string{"backend"}}
+		fixture.users[user.Id] = user
+		fixture.members = append(fixture.members, &models.User{Id: user.Id, Username: user.Username, IsActive: true, TeamName: "backend", Role: models.RoleMember})
+
+		key := models.PullRequestKey{PullRequestId: fmt.Sprintf("pr%02d", i)}
+		reviewers := []string{userID(i + 1), userID(i + 2)}
+		fixture.prs[key] = &models.PullRequest{
+			PullRequestId: key.PullRequestId, PullRequestName: "PR " + key.PullRequestId, AuthorId: user.Id,
+			Status: models.OPEN, Priority: models.NORMAL, TeamName: "backend", AssignedReviewers: reviewers,
+		}
+		for _, reviewer := range reviewers {
+			fixture.assignments = append(fixture.assignments, &models.ReviewAssignment{
+				PullRequestId: key.PullRequestId, PullRequestStatus: models.OPEN, ReviewerId: reviewer, TeamName: "backend",
+			})
+		}
+	}
+
+	executor := graphql.NewExecutor(
+		fixtureUserStorage{graphqlFixture: fixture},
+		fixtureTeamStorage{graphqlFixture: fixture},
+		fixturePullRequestStorage{graphqlFixture: fixture},
+		logger.NewStdLogger(),
+	)
+
+	response := executor.Execute(context.Background(), &graphql.Request{Query: `{
+		team(name: "backend") {
+			members {
+				role
+				user {
+					id
+					team { name }
+					reviews {
+						pullRequest {
+							id
+							author { username }
+							reviewers { reviewer { id } team { name } }
+						}
+					}
+				}
+			}
+		}
+	}`})
+	require.Empty(t, response.Errors)
+
+	var data struct {
+		Team struct {
+			Members []struct {
+				Role string
+				User struct {
+					ID      string
+					Team    struct{ Name string }
+					Reviews []struct {
+						PullRequest struct {
+							ID        string
+							Author    struct{ Username string }
+							Reviewers []struct {
+								Reviewer struct{ ID string }
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(response.Data, &data))
+	require.Len(t, data.Team.Members, memberCount)
+	for _, member := range data.Team.Members {
+		assert.Equal(t, "MEMBER", member.Role)
+		assert.Equal(t, "backend", member.User.Team.Name)
+		require.Len(t, member.User.Reviews, 2)
+		for _, review := range member.User.Reviews {
+			assert.NotEmpty(t, review.PullRequest.Author.Username)
+			assert.Len(t, review.PullRequest.Reviewers, 2)
+		}
+	}
+
+	// One call per level regardless of the number of members and reviews.
+	assert.Equal(t, map[string]int{
+		"GetTeamsByNames":                    1,
+		"GetTeamMembers":                     1,
+		"GetUsersByIDs":                      1,
+		"GetReviewAssignmentsByReviewers":    1,
+		"GetPullRequestsByKeys":              1,
+		"GetReviewAssignmentsByPullRequests": 1,
+	}, fixture.calls)
+}
+
+func TestE2E_GraphQLDashboard(t *testing.T) {
+	SetupE2ETest(t)
+	defer TeardownE2ETest()
+
+	router := GetTestServer().GetRouter()
+
+	code, _ := apiRequest(t, "POST", "/api/v1/team/add", map[string]interface{}{
+		"team_name": "backend",
+		"members": []map[string]interface{}{
+			{"id": "author1", "username": "author1", "is_active": true},
+			{"id": "reviewer1", "username": "reviewer1", "is_active": true},
+			{"id": "reviewer2", "username": "reviewer2", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	code, _ = apiRequest(t, "POST", "/api/v1/team/add", map[string]interface{}{
+		"team_name":   "payments",
+		"parent_team": "backend",
+		"members":     []map[string]interface{}{},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	for _, id := range []string{"pr1", "pr2"} {
+		code, _ := apiRequest(t, "POST", "/api/v1/pull-request/create", map[string]interface{}{
+			"pull_request_id":   id,
+			"pull_request_name": "PR " + id,
+			"author_id":         "author1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+	}
+	code, _ = apiRequest(t, "POST", "/api/v1/pull-request/merge", map[string]interface{}{"pull_request_id": "pr2"})
+	require.Equal(t, http.StatusOK, code)
+
+	t.Run("team with members and their open reviews", func(t *testing.T) {
+		response := graphqlRequest(t, router, `query Dashboard($team: String!) {
+			team(name: $team) {
+				name
+				subteams { name parent { name } }
+				members {
+					role
+					user {
+						id
+						reviews(statuses: [OPEN]) {
+							verdict
+							pullRequest { id status author { id } reviewers { reviewerId } }
+						}
+					}
+				}
+			}
+		}`, map[string]any{"team": "backend"})
+		require.Empty(t, response.Errors)
+
+		var data struct {
+			Team struct {
+				Name     string
+				Subteams []struct {
+					Name   string
+					Parent struct{ Name string }
+				}
+				Members []struct {
+					Role string
+					User struct {
+						ID      string
+						Reviews []struct {
+							Verdict     *string
+							PullRequest struct {
+								ID        string
+								Status    string
+								Author    struct{ ID string }
+								Reviewers []struct{ ReviewerID string }
+							}
+						}
+					}
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(response.Data, &data))
+		assert.Equal(t, "backend", data.Team.Name)
+		require.Len(t, data.Team.Subteams, 1)
+		assert.Equal(t, "payments", data.Team.Subteams[0].Name)
+		assert.Equal(t, "backend", data.Team.Subteams[0].Parent.Name)
+
+		require.Len(t, data.Team.Members, 3)
+		assert.Equal(t, "author1", data.Team.Members[0].User.ID)
+		assert.Empty(t, data.Team.Members[0].User.Reviews)
+		for _, member := range data.Team.Members[1:] {
+			assert.Equal(t, "MEMBER", member.Role)
+			require.Len(t, member.User.Reviews, 1)
+			review := member.User.Reviews[0]
+			assert.Nil(t, review.Verdict)
+			assert.Equal(t, "pr1", review.PullRequest.ID)
+			assert.Equal(t, "OPEN", review.PullRequest.Status)
+			assert.Equal(t, "author1", review.PullRequest.Author.ID)
+			assert.Len(t, review.PullRequest.Reviewers, 2)
+		}
+	})
+
+	t.Run("paginated pull requests", func(t *testing.T) {
+		query := `query($after: String) {
+			pullRequests(filter: {authorId: "author1"}, order: ASC, first: 1, after: $after) {
+				nodes { id team { name } }
+				nextCursor
+			}
+		}`
+		var page struct {
+			PullRequests struct {
+				Nodes []struct {
+					ID   string
+					Team struct{ Name string }
+				}
+				NextCursor *string
+			}
+		}
+
+		response := graphqlRequest(t, router, query, nil)
+		require.Empty(t, response.Errors)
+		require.NoError(t, json.Unmarshal(response.Data, &page))
+		require.Len(t, page.PullRequests.Nodes, 1)
+		assert.Equal(t, "pr1", page.PullRequests.Nodes[0].ID)
+		assert.Equal(t, "backend", page.PullRequests.Nodes[0].Team.Name)
+		require.NotNil(t, page.PullRequests.NextCursor)
+
+		response = graphqlRequest(t, router, query, map[string]any{"after": *page.PullRequests.NextCursor})
+		require.Empty(t, response.Errors)
+		require.NoError(t, json.Unmarshal(response.Data, &page))
+		require.Len(t, page.PullRequests.Nodes, 1)
+		assert.Equal(t, "pr2", page.PullRequests.Nodes[0].ID)
+		assert.Nil(t, page.PullRequests.NextCursor)
+	})
+
+	t.Run("missing objects are null", func(t *testing.T) {
+		response := graphqlRequest(t, router, `{ team(name: "missing") { name } user(id: "missing") { id } }`, nil)
+		require.Empty(t, response.Errors)
+		assert.JSONEq(t, `{"team": null, "user": null}`, string(response.Data))
+	})
+}
@@ -0,0 +1,303 @@
+package e2e
+
+import (
+	"avito-autumn-2025/internal/auth"
+	"avito-autumn-2025/internal/grpc/reviewerv1"
+	grpcserver "avito-autumn-2025/internal/grpc/server"
+	"avito-autumn-2025/internal/logger"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// grpcConn serves the gRPC API over pool in memory and connects to it. A nil
+// pool is enough for calls that never reach the storage.
+func grpcConn(t *testing.T, pool *pgxpool.Pool, authenticator *auth.Authenticator) *grpc.ClientConn {
+	t.Helper()
+
+	log := logger.NewStdLogger()
+	services := newServices(pool, log)
+	srv := grpcserver.NewServer(services.Users, services.Teams, services.PullRequests, log, authenticator)
+
+	listener := bufconn.Listen(1 << 20)
+	go srv.Serve(listener)
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// grpcError returns the status code of err, the apperr code carried by its
+// ErrorInfo detail and the fields of its BadRequest detail.
+func grpcError(t *testing.T, err error) (codes.Code, string, []string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, "not a status: %v", err)
+	require.NotEqual(t, codes.OK, st.Code())
+
+	var reason string
+	var fields []string
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			reason = detail.GetReason()
+		case *errdetails.BadRequest:
+			for _, violation := range detail.GetFieldViolations() {
+				fields = append(fields, violation.GetField())
+			}
+		}
+	}
+	return st.Code(), reason, fields
+}
+
+func TestGRPC_HealthAndReflection(t *testing.T) {
+	conn := grpcConn(t, nil, nil)
+	ctx := context.Background()
+
+	health := healthpb.NewHealthClient(conn)
+	for _, service := range []string{"", "reviewer.v1.TeamService", "reviewer.v1.UserService", "reviewer.v1.PullRequestService"} {
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err, service)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus(), service)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Subset(t, services, []string{
+		"reviewer.v1.TeamService",
+		"reviewer.v1.UserService",
+		"reviewer.v1.PullRequestService",
+		"grpc.health.v1.Health",
+	})
+}
+
+func TestGRPC_InvalidRequests(t *testing.T) {
+	conn := grpcConn(t, nil, nil)
+	ctx := context.Background()
+	longID := strings.Repeat("x", 51)
+
+	t.Run("every invalid field is reported", func(t *testing.T) {
+		_, err := reviewerv1.NewPullRequestServiceClient(conn).CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+			PullRequestId: longID,
+			Priority:      reviewerv1.PullRequestPriority(42),
+			Additions:     -1,
+		})
+		code, reason, fields := grpcError(t, err)
+		assert.Equal(t, codes.InvalidArgument, code)
+		assert.Equal(t, "INVALID_REQUEST", reason)
+		assert.ElementsMatch(t, []string{"pull_request_id", "pull_request_name", "author_id", "priority", "additions"}, fields)
+	})
+
+	t.Run("nested fields", func(t *testing.T) {
+		_, err := reviewerv1.NewTeamServiceClient(conn).CreateTeam(ctx, &reviewerv1.CreateTeamRequest{
+			TeamName: "team1",
+			Members:  []*reviewerv1.User{{Id: longID, Username: "u1", Role: reviewerv1.MembershipRole(9)}},
+		})
+		code, _, fields := grpcError(t, err)
+		assert.Equal(t, codes.InvalidArgument, code)
+		assert.ElementsMatch(t, []string{"members.0.id", "members.0.role"}, fields)
+	})
+
+	t.Run("empty update", func(t *testing.T) {
+		_, err := reviewerv1.NewUserServiceClient(conn).UpdateUser(ctx, &reviewerv1.UpdateUserRequest{Id: "user1"})
+		code, _, fields := grpcError(t, err)
+		assert.Equal(t, codes.InvalidArgument, code)
+		assert.Equal(t, []string{""}, fields)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		_, err := reviewerv1.NewPullRequestServiceClient(conn).GetUserReviews(ctx, &reviewerv1.GetUserReviewsRequest{
+			UserId:    "user1",
+			PageSize:  500,
+			PageToken: "not a token",
+		})
+		code, _, fields := grpcError(t, err)
+		assert.Equal(t, codes.InvalidArgument, code)
+		assert.ElementsMatch(t, []string{"page_size", "page_token"}, fields)
+	})
+
+	t.Run("actor metadata", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(ctx, "x-actor-id", longID)
+		_, err := reviewerv1.NewUserServiceClient(conn).GetUser(ctx, &reviewerv1.GetUserRequest{Id: "user1"})
+		code, reason, _ := grpcError(t, err)
+		assert.Equal(t, codes.InvalidArgument, code)
+		assert.Equal(t, "INVALID_REQUEST", reason)
+	})
+}
+
+func TestGRPC_Authentication(t *testing.T) {
+	authenticator := auth.NewAuthenticator("secret", nil)
+	conn := grpcConn(t, nil, authenticator)
+	ctx := context.Background()
+
+	_, err := reviewerv1.NewUserServiceClient(conn).GetUser(ctx, &reviewerv1.GetUserRequest{Id: "user1"})
+	code, reason, _ := grpcError(t, err)
+	assert.Equal(t, codes.Unauthenticated, code)
+	assert.Equal(t, "UNAUTHORIZED", reason)
+
+	// The actor metadata is not trusted, let alone validated, without a token
+	forged := metadata.AppendToOutgoingContext(ctx, "x-actor-id", strings.Repeat("a", 100))
+	_, err = reviewerv1.NewUserServiceClient(conn).GetUser(forged, &reviewerv1.GetUserRequest{Id: "user1"})
+	code, reason, _ = grpcError(t, err)
+	assert.Equal(t, codes.Unauthenticated, code)
+	assert.Equal(t, "UNAUTHORIZED", reason)
+
+	badToken := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer user1.forged")
+	_, err = reviewerv1.NewUserServiceClient(conn).GetUser(badToken, &reviewerv1.GetUserRequest{Id: "user1"})
+	code, reason, _ = grpcError(t, err)
+	assert.Equal(t, codes.Unauthenticated, code)
+	assert.Equal(t, "INVALID_TOKEN", reason)
+
+	expired := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+authenticator.Token("user1", -time.Minute))
+	_, err = reviewerv1.NewUserServiceClient(conn).GetUser(expired, &reviewerv1.GetUserRequest{Id: "user1"})
+	code, reason, _ = grpcError(t, err)
+	assert.Equal(t, codes.Unauthenticated, code)
+	assert.Equal(t, "INVALID_TOKEN", reason)
+
+	// Moving the expiry breaks the signature
+	token := authenticator.Token("user1", time.Minute)
+	parts := strings.Split(token, ".")
+	parts[1] += "0"
+	extended := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+strings.Join(parts, "."))
+	_, err = reviewerv1.NewUserServiceClient(conn).GetUser(extended, &reviewerv1.GetUserRequest{Id: "user1"})
+	code, reason, _ = grpcError(t, err)
+	assert.Equal(t, codes.Unauthenticated, code)
+	assert.Equal(t, "INVALID_TOKEN", reason)
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err, "health checks are public")
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestGRPC_ReviewWorkflow(t *testing.T) {
+	SetupE2ETest(t)
+	defer TeardownE2ETest()
+
+	conn := grpcConn(t, testDB, nil)
+	ctx := context.Background()
+	teams := reviewerv1.NewTeamServiceClient(conn)
+	users := reviewerv1.NewUserServiceClient(conn)
+	prs := reviewerv1.NewPullRequestServiceClient(conn)
+
+	created, err := teams.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{
+		TeamName: "team1",
+		Members: []*reviewerv1.User{
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "reviewer1", Username: "reviewer1", IsActive: true},
+			{Id: "reviewer2", Username: "reviewer2", IsActive: true},
+			{Id: "reviewer3", Username: "reviewer3", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+	assert.Len(t, created.GetTeam().GetMembers(), 4)
+	assert.Positive(t, created.GetTeam().GetVersion())
+
+	_, err = teams.CreateTeam(ctx, &reviewerv1.CreateTeamRequest{TeamName: "team1"})
+	code, reason, _ := grpcError(t, err)
+	assert.Equal(t, codes.AlreadyExists, code)
+	assert.Equal(t, "TEAM_EXISTS", reason)
+
+	got, err := users.GetUser(ctx, &reviewerv1.GetUserRequest{Id: "reviewer1"})
+	require.NoError(t, err)
+	assert.Equal(t, "team1", got.GetUser().GetTeamName())
+
+	_, err = users.GetUser(ctx, &reviewerv1.GetUserRequest{Id: "nobody"})
+	code, reason, _ = grpcError(t, err)
+	assert.Equal(t, codes.NotFound, code)
+	assert.Equal(t, "USER_NOT_FOUND", reason)
+
+	inactive := false
+	updated, err := users.UpdateUser(ctx, &reviewerv1.UpdateUserRequest{Id: "reviewer3", IsActive: &inactive})
+	require.NoError(t, err)
+	assert.False(t, updated.GetUser().GetIsActive())
+
+	pr, err := prs.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		Priority:        reviewerv1.PullRequestPriority_PULL_REQUEST_PRIORITY_HIGH,
+	})
+	require.NoError(t, err)
+	assert.True(t, pr.GetCreated())
+	assert.Equal(t, reviewerv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, pr.GetPullRequest().GetStatus())
+	assert.Equal(t, reviewerv1.PullRequestPriority_PULL_REQUEST_PRIORITY_HIGH, pr.GetPullRequest().GetPriority())
+	assert.ElementsMatch(t, []string{"reviewer1", "reviewer2"}, pr.GetPullRequest().GetAssignedReviewers())
+
+	existing, err := prs.CreatePullRequest(ctx, &reviewerv1.CreatePullRequestRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		ReturnExisting:  true,
+	})
+	require.NoError(t, err)
+	assert.False(t, existing.GetCreated())
+
+	reviews, err := prs.GetUserReviews(ctx, &reviewerv1.GetUserReviewsRequest{UserId: "reviewer1"})
+	require.NoError(t, err)
+	require.Len(t, reviews.GetPullRequests(), 1)
+	assert.Equal(t, "pr1", reviews.GetPullRequests()[0].GetPullRequestId())
+	assert.Empty(t, reviews.GetNextPageToken())
+
+	_, err = prs.ReassignReviewer(ctx, &reviewerv1.ReassignReviewerRequest{
+		PullRequestId: "pr1",
+		OldUserId:     "reviewer1",
+	})
+	code, reason, _ = grpcError(t, err)
+	assert.Equal(t, codes.FailedPrecondition, code)
+	assert.Equal(t, "NO_CANDIDATE", reason)
+
+	_, err = prs.MergePullRequest(ctx, &reviewerv1.MergePullRequestRequest{
+		PullRequestId: "pr1",
+		Version:       pr.GetPullRequest().GetVersion() + 1,
+	})
+	code, reason, _ = grpcError(t, err)
+	assert.Equal(t, codes.Aborted, code)
+	assert.Equal(t, "VERSION_MISMATCH", reason)
+
+	merged, err := prs.MergePullRequest(ctx, &reviewerv1.MergePullRequestRequest{
+		PullRequestId: "pr1",
+		Version:       pr.GetPullRequest().GetVersion(),
+	})
+	require.NoError(t, err)
+	assert.Greater(t, merged.GetVersion(), pr.GetPullRequest().GetVersion())
+
+	stats, err := prs.GetReviewStatistics(ctx, &reviewerv1.GetReviewStatisticsRequest{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, stats.GetTotalPrs())
+	assert.EqualValues(t, 1, stats.GetMergedPrs())
+	assert.NotNil(t, stats.GetGeneratedAt())
+}
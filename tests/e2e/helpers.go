@@ -2,15 +2,23 @@ package e2e
 
 import (
 	"avito-autumn-2025/internal/config"
+	"avito-autumn-2025/internal/graphql"
 	"avito-autumn-2025/internal/http/server"
 	"avito-autumn-2025/internal/logger"
 	db "avito-autumn-2025/internal/postgres"
+	"avito-autumn-2025/internal/service/pull_request"
+	"avito-autumn-2025/internal/service/repository"
+	"avito-autumn-2025/internal/service/scim"
+	"avito-autumn-2025/internal/service/team"
+	"avito-autumn-2025/internal/service/user"
+	"avito-autumn-2025/internal/storage/postgres"
 	"context"
 	"database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -45,6 +53,8 @@ func SetupE2ETest(t *testing.T) {
 	_, _ = testDB.Exec(context.Background(), "TRUNCATE TABLE pull_requests CASCADE")
 	_, _ = testDB.Exec(context.Background(), "TRUNCATE TABLE users CASCADE")
 	_, _ = testDB.Exec(context.Background(), "TRUNCATE TABLE teams CASCADE")
+	_, _ = testDB.Exec(context.Background(), "TRUNCATE TABLE repositories CASCADE")
+	_, _ = testDB.Exec(context.Background(), "TRUNCATE TABLE idempotency_keys")
 
 	migrationsPath := filepath.Join("..", "..", "migrations")
 	if _, err := os.Stat(migrationsPath); os.IsNotExist(err) {
@@ -54,10 +64,34 @@ func SetupE2ETest(t *testing.T) {
 	require.NoError(t, err)
 
 	logger := logger.NewStdLogger()
-	testServer = server.NewServer(testDB, logger)
+	testServer = server.NewServer(newServices(testDB, logger), logger, nil, 24*time.Hour)
 	testServer.SetupRoutes()
 }
 
+// newServices wires the services over pool the way cmd/main.go does. A nil
+// pool is enough for calls that never reach the storage.
+func newServices(pool *pgxpool.Pool, log logger.Logger) server.Services {
+	userStorage := postgres.NewUserStorage(pool, log)
+	teamStorage := postgres.NewTeamStorage(pool, log)
+	repoStorage := postgres.NewRepositoryStorage(pool, log)
+	prStorage := postgres.NewPullRequestStorage(pool, log)
+	idempotencyStorage := postgres.NewIdempotencyStorage(pool, log)
+
+	userSvc := user.NewUserService(&userStorage, log)
+	teamSvc := team.NewTeamService(&teamStorage, log)
+	repoSvc := repository.NewRepositoryService(&repoStorage, &teamStorage, log)
+	scimSvc := scim.NewSCIMService(&userStorage, &teamStorage, log)
+	return server.Services{
+		Users:        &userSvc,
+		Teams:        &teamSvc,
+		Repositories: &repoSvc,
+		PullRequests: pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, log),
+		SCIM:         &scimSvc,
+		GraphQL:      graphql.NewExecutor(&userStorage, &teamStorage, &prStorage, log),
+		Idempotency:  &idempotencyStorage,
+	}
+}
+
 func TeardownE2ETest() {
 	if testDB != nil {
 		testDB.Close()
@@ -0,0 +1,119 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// idempotentRequest sends a JSON POST with an Idempotency-Key header.
+func idempotentRequest(t *testing.T, key, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", path, bytes.NewBuffer(data))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	w := httptest.NewRecorder()
+	GetTestServer().GetRouter().ServeHTTP(w, req)
+	return w
+}
+
+func TestE2E_IdempotencyKey(t *testing.T) {
+	SetupE2ETest(t)
+	defer TeardownE2ETest()
+
+	team := map[string]interface{}{
+		"team_name": "team1",
+		"members": []map[string]interface{}{
+			{"id": "author1", "username": "author1", "is_active": true},
+			{"id": "reviewer1", "username": "reviewer1", "is_active": true},
+			{"id": "reviewer2", "username": "reviewer2", "is_active": true},
+			{"id": "reviewer3", "username": "reviewer3", "is_active": true},
+		},
+	}
+	w := idempotentRequest(t, "team-add-1", "/api/v1/team/add", team)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	t.Run("retried create is replayed", func(t *testing.T) {
+		w := idempotentRequest(t, "team-add-1", "/api/v1/team/add", team)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	})
+
+	pr := map[string]interface{}{
+		"pull_request_id":   "pr1",
+		"pull_request_name": "Test PR",
+		"author_id":         "author1",
+	}
+	w = idempotentRequest(t, "pr-create-1", "/api/v1/pull-request/create", pr)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	reviewers := created["assigned_reviewers"].([]interface{})
+	require.Len(t, reviewers, 2)
+	oldReviewer := reviewers[0].(string)
+
+	t.Run("retried reassign picks no new reviewer", func(t *testing.T) {
+		reassign := map[string]interface{}{"pull_request_id": "pr1", "old_user_id": oldReviewer}
+
+		first := idempotentRequest(t, "reassign-1", "/api/v1/pull-request/reassign", reassign)
+		require.Equal(t, http.StatusOK, first.Code)
+
+		code, afterFirst := apiRequest(t, "GET", "/api/v1/pull-request/pr1", nil)
+		require.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, afterFirst["assigned_reviewers"], oldReviewer)
+
+		second := idempotentRequest(t, "reassign-1", "/api/v1/pull-request/reassign", reassign)
+		assert.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.JSONEq(t, first.Body.String(), second.Body.String())
+
+		code, afterSecond := apiRequest(t, "GET", "/api/v1/pull-request/pr1", nil)
+		require.Equal(t, http.StatusOK, code)
+		assert.ElementsMatch(t, afterFirst["assigned_reviewers"], afterSecond["assigned_reviewers"])
+	})
+
+	t.Run("key reused for another request", func(t *testing.T) {
+		w := idempotentRequest(t, "reassign-1", "/api/v1/pull-request/merge", map[string]interface{}{"pull_request_id": "pr1"})
+
+		var response map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assertAPIError(t, w.Code, response, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED")
+	})
+
+	t.Run("errors are replayed", func(t *testing.T) {
+		missing := map[string]interface{}{"pull_request_id": "missing"}
+
+		w := idempotentRequest(t, "merge-missing", "/api/v1/pull-request/merge", missing)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = idempotentRequest(t, "merge-missing", "/api/v1/pull-request/merge", missing)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("keys are scoped to the actor", func(t *testing.T) {
+		user := map[string]interface{}{"id": "user1", "username": "user1", "is_active": true}
+		data, err := json.Marshal(user)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest("POST", "/api/v1/users", bytes.NewBuffer(data))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "reassign-1")
+		req.Header.Set("X-Actor-Id", "ci-bot")
+		w := httptest.NewRecorder()
+		GetTestServer().GetRouter().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	})
+}
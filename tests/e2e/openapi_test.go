@@ -0,0 +1,71 @@
+package e2e
+
+import (
+	"avito-autumn-2025/internal/http/openapi"
+	"avito-autumn-2025/internal/http/server"
+	"avito-autumn-2025/internal/logger"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ginPathParam = regexp.MustCompile(`:([^/]+)`)
+
+// routesServer builds the router without a database; the tests only inspect
+// routes and never reach the storage.
+func routesServer() *server.Server {
+	srv := server.NewServer(server.Services{}, logger.NewStdLogger(), nil, 24*time.Hour)
+	srv.SetupRoutes()
+	return srv
+}
+
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+
+	doc, err := openapi3.NewLoader().LoadFromData(openapi.Spec())
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+	return doc
+}
+
+func TestOpenAPI_CoversEveryRoute(t *testing.T) {
+	doc := loadSpec(t)
+	routes := routesServer().GetRouter().Routes()
+	require.NotEmpty(t, routes)
+
+	registered := map[string]bool{}
+	for _, route := range routes {
+		path := ginPathParam.ReplaceAllString(route.Path, "{$1}")
+		registered[route.Method+" "+path] = true
+
+		item := doc.Paths.Find(path)
+		if !assert.NotNil(t, item, "route %s %s has no path in the specification", route.Method, path) {
+			continue
+		}
+		assert.NotNil(t, item.GetOperation(route.Method), "route %s %s has no operation in the specification", route.Method, path)
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			assert.True(t, registered[strings.ToUpper(method)+" "+path], "specification describes %s %s, which is not a route", method, path)
+		}
+	}
+}
+
+func TestOpenAPI_Served(t *testing.T) {
+	req := httptest.NewRequest("GET", openapi.Path, nil)
+	w := httptest.NewRecorder()
+	routesServer().GetRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, string(openapi.Spec()), w.Body.String())
+}
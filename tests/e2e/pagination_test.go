@@ -0,0 +1,90 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listPullRequests fetches a pull request list and returns the ids on the page
+// together with the next cursor.
+func listPullRequests(t *testing.T, path string) ([]string, string) {
+	t.Helper()
+
+	req := httptest.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	GetTestServer().GetRouter().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	ids := []string{}
+	for _, pr := range response {
+		ids = append(ids, pr["pull_request_id"].(string))
+	}
+	return ids, w.Header().Get("X-Next-Cursor")
+}
+
+func TestE2E_PullRequestPagination(t *testing.T) {
+	SetupE2ETest(t)
+	defer TeardownE2ETest()
+
+	code, _ := apiRequest(t, "POST", "/api/v1/team/add", map[string]interface{}{
+		"team_name": "team1",
+		"members": []map[string]interface{}{
+			{"id": "author1", "username": "author1", "is_active": true},
+			{"id": "reviewer1", "username": "reviewer1", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	for _, id := range []string{"pr1", "pr2", "pr3"} {
+		code, _ := apiRequest(t, "POST", "/api/v1/pull-request/create", map[string]interface{}{
+			"pull_request_id":   id,
+			"pull_request_name": "PR " + id,
+			"author_id":         "author1",
+		})
+		require.Equal(t, http.StatusCreated, code)
+	}
+	code, _ = apiRequest(t, "POST", "/api/v1/pull-request/merge", map[string]interface{}{"pull_request_id": "pr2"})
+	require.Equal(t, http.StatusOK, code)
+
+	t.Run("reviewer list is paginated", func(t *testing.T) {
+		first, cursor := listPullRequests(t, "/api/v1/users/get-review?reviewer_id=reviewer1&limit=2&order=asc")
+		assert.Equal(t, []string{"pr1", "pr2"}, first)
+		require.NotEmpty(t, cursor)
+
+		second, cursor := listPullRequests(t, "/api/v1/users/get-review?reviewer_id=reviewer1&limit=2&order=asc&cursor="+url.QueryEscape(cursor))
+		assert.Equal(t, []string{"pr3"}, second)
+		assert.Empty(t, cursor)
+	})
+
+	t.Run("search filters by status", func(t *testing.T) {
+		ids, _ := listPullRequests(t, "/api/v1/pull-requests?status=OPEN&author_id=author1&team_name=team1&order=asc")
+		assert.Equal(t, []string{"pr1", "pr3"}, ids)
+
+		ids, _ = listPullRequests(t, "/api/v1/pull-requests?status=MERGED&reviewer_id=reviewer1")
+		assert.Equal(t, []string{"pr2"}, ids)
+	})
+
+	t.Run("invalid parameters are rejected", func(t *testing.T) {
+		code, response := apiRequest(t, "GET", "/api/v1/pull-requests?sort=name", nil)
+		assertAPIError(t, code, response, http.StatusBadRequest, "INVALID_REQUEST")
+
+		code, response = apiRequest(t, "GET", "/api/v1/pull-requests?limit=0", nil)
+		assertAPIError(t, code, response, http.StatusBadRequest, "INVALID_REQUEST")
+
+		code, response = apiRequest(t, "GET", "/api/v1/pull-requests?created_from=yesterday", nil)
+		assertAPIError(t, code, response, http.StatusBadRequest, "INVALID_REQUEST")
+
+		_, cursor := listPullRequests(t, "/api/v1/pull-requests?limit=1")
+		require.NotEmpty(t, cursor)
+		code, response = apiRequest(t, "GET", "/api/v1/pull-requests?limit=1&order=asc&cursor="+url.QueryEscape(cursor), nil)
+		assertAPIError(t, code, response, http.StatusBadRequest, "INVALID_REQUEST")
+	})
+}
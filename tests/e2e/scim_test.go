@@ -0,0 +1,177 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scimClient plays the identity provider: it sends SCIM requests the way
+// provisioning clients do and decodes the responses.
+type scimClient struct {
+	t *testing.T
+}
+
+func (c scimClient) do(method, path string, body any) (int, map[string]interface{}) {
+	c.t.Helper()
+
+	var reader *bytes.Buffer
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(c.t, err)
+		reader = bytes.NewBuffer(data)
+	} else {
+		reader = &bytes.Buffer{}
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/scim+json")
+	w := httptest.NewRecorder()
+	GetTestServer().GetRouter().ServeHTTP(w, req)
+
+	var response map[string]interface{}
+	if w.Body.Len() > 0 {
+		require.Equal(c.t, "application/scim+json", w.Header().Get("Content-Type"))
+		require.NoError(c.t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return w.Code, response
+}
+
+func (c scimClient) createUser(externalID, userName string) {
+	c.t.Helper()
+	code, _ := c.do("POST", "/scim/v2/Users", map[string]interface{}{
+		"schemas":    []string{"urn:ietf:params:scim:schemas:core:2.0:User"},
+		"externalId": externalID,
+		"userName":   userName,
+		"active":     true,
+	})
+	require.Equal(c.t, http.StatusCreated, code)
+}
+
+func (c scimClient) patch(path string, operations ...map[string]interface{}) (int, map[string]interface{}) {
+	return c.do("PATCH", path, map[string]interface{}{
+		"schemas":    []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+		"Operations": operations,
+	})
+}
+
+func TestE2E_SCIMProvisioning(t *testing.T) {
+	SetupE2ETest(t)
+	defer TeardownE2ETest()
+
+	client := scimClient{t: t}
+
+	client.createUser("author1", "alice")
+	client.createUser("reviewer1", "bob")
+	client.createUser("reviewer2", "carol")
+	client.createUser("reviewer3", "dave")
+
+	code, group := client.do("POST", "/scim/v2/Groups", map[string]interface{}{
+		"schemas":     []string{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+		"displayName": "team1",
+		"members": []map[string]string{
+			{"value": "author1"}, {"value": "reviewer1"}, {"value": "reviewer2"}, {"value": "reviewer3"},
+		},
+	})
+	require.Equal(t, http.StatusCreated, code)
+	assert.Len(t, group["members"], 4)
+
+	t.Run("duplicate user", func(t *testing.T) {
+		code, response := client.do("POST", "/scim/v2/Users", map[string]interface{}{"externalId": "author1", "userName": "alice"})
+		assert.Equal(t, http.StatusConflict, code)
+		assert.Equal(t, "uniqueness", response["scimType"])
+	})
+
+	t.Run("filter and pagination", func(t *testing.T) {
+		code, response := client.do("GET", "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "BOB"`), nil)
+		require.Equal(t, http.StatusOK, code)
+		assert.EqualValues(t, 1, response["totalResults"])
+
+		code, response = client.do("GET", "/scim/v2/Users?startIndex=2&count=2", nil)
+		require.Equal(t, http.StatusOK, code)
+		assert.EqualValues(t, 4, response["totalResults"])
+		assert.EqualValues(t, 2, response["itemsPerPage"])
+		resources := response["Resources"].([]interface{})
+		require.Len(t, resources, 2)
+		assert.Equal(t, "reviewer1", resources[0].(map[string]interface{})["id"])
+
+		code, response = client.do("GET", "/scim/v2/Groups?filter="+url.QueryEscape(`members.value eq "reviewer2"`), nil)
+		require.Equal(t, http.StatusOK, code)
+		assert.EqualValues(t, 1, response["totalResults"])
+
+		code, response = client.do("GET", "/scim/v2/Groups?filter="+url.QueryEscape(`displayName eq "TEAM1"`), nil)
+		require.Equal(t, http.StatusOK, code)
+		assert.EqualValues(t, 1, response["totalResults"])
+
+		code, response = client.do("GET", "/scim/v2/Groups?filter="+url.QueryEscape(`displayName eq "team1"`)+"&startIndex=2", nil)
+		require.Equal(t, http.StatusOK, code)
+		assert.EqualValues(t, 1, response["totalResults"])
+		assert.EqualValues(t, 0, response["itemsPerPage"])
+
+		code, response = client.do("GET", "/scim/v2/Users?filter="+url.QueryEscape(`password eq "x"`), nil)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalidFilter", response["scimType"])
+	})
+
+	t.Run("deactivation reassigns open reviews", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"pull_request_id":   "pr1",
+			"pull_request_name": "Test PR",
+			"author_id":         "author1",
+		})
+		req := httptest.NewRequest("POST", "/api/v1/pull-request/create", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		GetTestServer().GetRouter().ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		var pr map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &pr))
+		reviewers := pr["assigned_reviewers"].([]interface{})
+		require.NotEmpty(t, reviewers)
+		deprovisioned := reviewers[0].(string)
+
+		// Some identity providers send booleans as strings
+		code, user := client.patch("/scim/v2/Users/"+deprovisioned, map[string]interface{}{
+			"op": "Replace", "path": "active", "value": "False",
+		})
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, false, user["active"])
+
+		req = httptest.NewRequest("GET", "/api/v1/pull-request/pr1", nil)
+		w = httptest.NewRecorder()
+		GetTestServer().GetRouter().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &pr))
+		assert.NotContains(t, pr["assigned_reviewers"], deprovisioned)
+		assert.Len(t, pr["assigned_reviewers"], len(reviewers))
+	})
+
+	t.Run("group membership patch", func(t *testing.T) {
+		code, group := client.patch("/scim/v2/Groups/team1",
+			map[string]interface{}{"op": "remove", "path": `members[value eq "reviewer3"]`},
+			map[string]interface{}{"op": "replace", "value": map[string]interface{}{"displayName": "platform"}},
+		)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "platform", group["id"])
+		assert.Len(t, group["members"], 3)
+
+		code, _ = client.do("GET", "/scim/v2/Groups/team1", nil)
+		assert.Equal(t, http.StatusNotFound, code)
+	})
+
+	t.Run("delete user", func(t *testing.T) {
+		code, _ := client.do("DELETE", "/scim/v2/Users/reviewer3", nil)
+		assert.Equal(t, http.StatusNoContent, code)
+
+		code, response := client.do("GET", "/scim/v2/Users/reviewer3", nil)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, "404", response["status"])
+	})
+}
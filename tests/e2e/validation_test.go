@@ -0,0 +1,105 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invalidFields sends a request that the specification rejects and returns the
+// reported fields as "in:field".
+func invalidFields(t *testing.T, method, path, body string) []string {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	routesServer().GetRouter().ServeHTTP(w, req)
+
+	var response struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Fields  []struct {
+				In      string `json:"in"`
+				Field   string `json:"field"`
+				Message string `json:"message"`
+			} `json:"fields"`
+		} `json:"error"`
+	}
+	require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "INVALID_REQUEST", response.Error.Code)
+	assert.NotEmpty(t, response.Error.Message)
+
+	var fields []string
+	for _, field := range response.Error.Fields {
+		assert.NotEmpty(t, field.Message)
+		fields = append(fields, field.In+":"+field.Field)
+	}
+	return fields
+}
+
+func TestValidation_RequestsViolatingSpecification(t *testing.T) {
+	longID := strings.Repeat("x", 51)
+
+	t.Run("every invalid body field is reported", func(t *testing.T) {
+		fields := invalidFields(t, "POST", "/api/v1/pull-request/create", `{
+			"pull_request_id": "`+longID+`",
+			"pull_request_name": "",
+			"priority": "SOON",
+			"additions": -1
+		}`)
+		assert.ElementsMatch(t, []string{
+			"body:pull_request_id",
+			"body:pull_request_name",
+			"body:author_id",
+			"body:priority",
+			"body:additions",
+		}, fields)
+	})
+
+	t.Run("nested body fields", func(t *testing.T) {
+		fields := invalidFields(t, "POST", "/api/v1/team/add", `{
+			"team_name": "team1",
+			"members": [{"id": "`+longID+`", "username": "u1", "role": "owner"}]
+		}`)
+		assert.ElementsMatch(t, []string{"body:members.0.id", "body:members.0.role"}, fields)
+	})
+
+	t.Run("query parameters", func(t *testing.T) {
+		fields := invalidFields(t, "GET", "/api/v1/pull-requests?author_id="+longID+"&limit=500&order=up", "")
+		assert.ElementsMatch(t, []string{"query:author_id", "query:limit", "query:order"}, fields)
+	})
+
+	t.Run("path parameters", func(t *testing.T) {
+		fields := invalidFields(t, "GET", "/api/v1/pull-request/"+longID, "")
+		assert.Equal(t, []string{"path:id"}, fields)
+	})
+
+	t.Run("headers", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/pull-request/merge", bytes.NewBufferString(`{"pull_request_id": "pr1"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", strings.Repeat("k", 256))
+		w := httptest.NewRecorder()
+		routesServer().GetRouter().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"Idempotency-Key"`)
+	})
+
+	t.Run("empty update", func(t *testing.T) {
+		fields := invalidFields(t, "PATCH", "/api/v1/users/user1", `{}`)
+		assert.Equal(t, []string{"body:"}, fields)
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		fields := invalidFields(t, "POST", "/api/v1/pull-request/review", `{"pull_request_id": `)
+		assert.Equal(t, []string{"body:"}, fields)
+	})
+}
@@ -0,0 +1,255 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/ldap"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service/directory"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ldapUserFilter  = "(objectClass=inetOrgPerson)"
+	ldapGroupFilter = "(objectClass=groupOfNames)"
+)
+
+// ldapStandIn plays the LDAP server: it checks the bind and answers each
+// search filter with the entries stored for it.
+type ldapStandIn struct {
+	entries map[string][]*goldap.Entry
+}
+
+func (s *ldapStandIn) Bind(username, password string) error {
+	if username != "cn=sync,dc=example,dc=com" || password != "secret" {
+		return goldap.NewError(goldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+func (s *ldapStandIn) SearchWithPaging(req *goldap.SearchRequest, pagingSize uint32) (*goldap.SearchResult, error) {
+	return &goldap.SearchResult{Entries: s.entries[req.Filter]}, nil
+}
+
+func (s *ldapStandIn) Close() error {
+	return nil
+}
+
+func ldapPerson(uid, cn string) *goldap.Entry {
+	return goldap.NewEntry("uid="+uid+",ou=people,dc=example,dc=com", map[string][]string{
+		"uid": {uid},
+		"cn":  {cn},
+	})
+}
+
+func ldapGroup(cn string, members ...string) *goldap.Entry {
+	return goldap.NewEntry("cn="+cn+",ou=groups,dc=example,dc=com", map[string][]string{
+		"cn":     {cn},
+		"member": members,
+	})
+}
+
+func TestDirectoryWorker_SyncOnce(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+
+	server := &ldapStandIn{entries: map[string][]*goldap.Entry{
+		ldapUserFilter: {
+			ldapPerson("user1", "alice"),
+			ldapPerson("user2", "bob"),
+			ldapPerson("user3", "carol"),
+		},
+		ldapGroupFilter: {
+			ldapGroup("backend",
+				"uid=user1,ou=people,dc=example,dc=com",
+				// DNs compare regardless of case and spacing
+				"UID=user2, OU=People, DC=example, DC=com",
+				"uid=user3,ou=people,dc=example,dc=com",
+				"cn=nested,ou=groups,dc=example,dc=com",
+			),
+			ldapGroup("payments", "uid=user2,ou=people,dc=example,dc=com"),
+		},
+	}}
+	source := ldap.NewDirectoryWithDialer(ldap.Config{
+		BindDN:          "cn=sync,dc=example,dc=com",
+		BindPassword:    "secret",
+		BaseDN:          "dc=example,dc=com",
+		UserFilter:      ldapUserFilter,
+		GroupFilter:     ldapGroupFilter,
+		UserIdAttr:      "uid",
+		UsernameAttr:    "cn",
+		GroupNameAttr:   "cn",
+		GroupMemberAttr: "member",
+	}, func() (ldap.Conn, error) { return server, nil }, logger)
+	worker := directory.NewWorker(source, &teamStorage, time.Hour, false, logger)
+
+	ctx := context.Background()
+
+	// A team and a member created by hand before the sync was enabled
+	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "backend")
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, insertTeamMemberQuery, "bot", "release-bot", true, "backend")
+	require.NoError(t, err)
+
+	t.Run("first sync creates users and teams", func(t *testing.T) {
+		result, err := worker.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+
+		backend, err := teamStorage.GetTeamWithMembers(ctx, "backend")
+		require.NoError(t, err)
+		var memberIDs []string
+		for _, member := range backend.Users {
+			memberIDs = append(memberIDs, member.Id)
+		}
+		assert.ElementsMatch(t, []string{"bot", "user1", "user2", "user3"}, memberIDs)
+
+		payments, err := teamStorage.GetTeamWithMembers(ctx, "payments")
+		require.NoError(t, err)
+		require.Len(t, payments.Users, 1)
+		assert.Equal(t, "bob", payments.Users[0].Username)
+
+		user2, err := userStorage.GetUserByID(ctx, "user2")
+		require.NoError(t, err)
+		require.NotNil(t, user2)
+		assert.Equal(t, "backend", user2.TeamName)
+	})
+
+	t.Run("unchanged directory changes nothing", func(t *testing.T) {
+		result, err := worker.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, result.Rows)
+	})
+
+	err = prStorage.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "user1",
+		Status:          models.OPEN,
+		TeamName:        "backend",
+	}, []string{"user3"})
+	require.NoError(t, err)
+
+	t.Run("users who left are deactivated", func(t *testing.T) {
+		server.entries[ldapUserFilter] = server.entries[ldapUserFilter][:2]
+
+		result, err := worker.SyncOnce(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+
+		user3, err := userStorage.GetUserByID(ctx, "user3")
+		require.NoError(t, err)
+		require.NotNil(t, user3)
+		assert.False(t, user3.IsActive)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+		require.Len(t, pr.AssignedReviewers, 1)
+		assert.NotEqual(t, "user3", pr.AssignedReviewers[0])
+
+		// Users created by hand are not managed by the directory
+		bot, err := userStorage.GetUserByID(ctx, "bot")
+		require.NoError(t, err)
+		require.NotNil(t, bot)
+		assert.True(t, bot.IsActive)
+		assert.Equal(t, "backend", bot.TeamName)
+	})
+
+	t.Run("members removed from a group leave the team", func(t *testing.T) {
+		server.entries[ldapGroupFilter][1] = ldapGroup("payments", "uid=user1,ou=people,dc=example,dc=com")
+
+		_, err := worker.SyncOnce(ctx)
+		require.NoError(t, err)
+
+		payments, err := teamStorage.GetTeamWithMembers(ctx, "payments")
+		require.NoError(t, err)
+		require.Len(t, payments.Users, 1)
+		assert.Equal(t, "user1", payments.Users[0].Id)
+	})
+
+	t.Run("users deactivated through the API stay inactive", func(t *testing.T) {
+		_, err := userStorage.DeactivateUser(ctx, "user2")
+		require.NoError(t, err)
+
+		_, err = worker.SyncOnce(ctx)
+		require.NoError(t, err)
+
+		user2, err := userStorage.GetUserByID(ctx, "user2")
+		require.NoError(t, err)
+		require.NotNil(t, user2)
+		assert.False(t, user2.IsActive)
+	})
+
+	t.Run("users who come back are reactivated", func(t *testing.T) {
+		server.entries[ldapUserFilter] = append(server.entries[ldapUserFilter], ldapPerson("user3", "carol"))
+
+		_, err := worker.SyncOnce(ctx)
+		require.NoError(t, err)
+
+		user3, err := userStorage.GetUserByID(ctx, "user3")
+		require.NoError(t, err)
+		require.NotNil(t, user3)
+		assert.True(t, user3.IsActive)
+	})
+
+	t.Run("users created by hand are adopted only when enabled", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "qa")
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, insertTeamMemberQuery, "user4", "dave-by-hand", true, "qa")
+		require.NoError(t, err)
+
+		server.entries[ldapUserFilter] = append(server.entries[ldapUserFilter], ldapPerson("user4", "dave"))
+		server.entries[ldapGroupFilter][1] = ldapGroup("payments",
+			"uid=user1,ou=people,dc=example,dc=com",
+			"uid=user4,ou=people,dc=example,dc=com",
+		)
+
+		_, err = worker.SyncOnce(ctx)
+		require.NoError(t, err)
+
+		var directoryDN *string
+		err = pool.QueryRow(ctx, "SELECT directory_dn FROM users WHERE id = $1", "user4").Scan(&directoryDN)
+		require.NoError(t, err)
+		assert.Nil(t, directoryDN)
+
+		user4, err := userStorage.GetUserByID(ctx, "user4")
+		require.NoError(t, err)
+		require.NotNil(t, user4)
+		assert.Equal(t, "dave-by-hand", user4.Username)
+		assert.Equal(t, []string{"qa"}, user4.Teams)
+
+		adopting := directory.NewWorker(source, &teamStorage, time.Hour, true, logger)
+		_, err = adopting.SyncOnce(ctx)
+		require.NoError(t, err)
+
+		user4, err = userStorage.GetUserByID(ctx, "user4")
+		require.NoError(t, err)
+		require.NotNil(t, user4)
+		assert.Equal(t, "dave", user4.Username)
+		assert.ElementsMatch(t, []string{"qa", "payments"}, user4.Teams)
+	})
+
+	t.Run("empty directory is refused", func(t *testing.T) {
+		server.entries[ldapUserFilter] = nil
+
+		_, err := worker.SyncOnce(ctx)
+		assert.Error(t, err)
+
+		user1, err := userStorage.GetUserByID(ctx, "user1")
+		require.NoError(t, err)
+		require.NotNil(t, user1)
+		assert.True(t, user1.IsActive)
+	})
+}
@@ -11,6 +11,13 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+// insertTeamMemberQuery creates a user as a primary member of a team. Arguments:
+// id, username, is_active, team_name.
+const insertTeamMemberQuery = `
+	WITH u AS (INSERT INTO users (id, username, is_active) VALUES ($1, $2, $3) RETURNING id)
+	INSERT INTO team_memberships (team_name, user_id, is_primary) SELECT $4, id, TRUE FROM u
+`
+
 func SetupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -36,6 +43,8 @@ func SetupTestDB(t *testing.T) (*pgxpool.Pool, func()) {
 	_, _ = pool.Exec(context.Background(), "TRUNCATE TABLE pull_requests CASCADE")
 	_, _ = pool.Exec(context.Background(), "TRUNCATE TABLE users CASCADE")
 	_, _ = pool.Exec(context.Background(), "TRUNCATE TABLE teams CASCADE")
+	_, _ = pool.Exec(context.Background(), "TRUNCATE TABLE repositories CASCADE")
+	_, _ = pool.Exec(context.Background(), "TRUNCATE TABLE idempotency_keys")
 
 	cleanup := func() {
 		pool.Close()
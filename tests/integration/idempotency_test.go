@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service/idempotency"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyStorage(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	store := postgres.NewIdempotencyStorage(pool, logger)
+	worker := idempotency.NewWorker(&store, 24*time.Hour, time.Hour, logger)
+
+	ctx := context.Background()
+
+	t.Run("reserved key reports the request in progress", func(t *testing.T) {
+		record, err := store.ReserveIdempotencyKey(ctx, "ci", "key1", "fp1", 24*time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, record)
+
+		record, err = store.ReserveIdempotencyKey(ctx, "ci", "key1", "fp1", 24*time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.False(t, record.Completed)
+		assert.Equal(t, "fp1", record.Fingerprint)
+	})
+
+	t.Run("completed key returns the response", func(t *testing.T) {
+		err := store.CompleteIdempotencyKey(ctx, &models.IdempotencyRecord{
+			ActorId:     "ci",
+			Key:         "key1",
+			StatusCode:  200,
+			ContentType: "application/json",
+			Body:        []byte(`{"ok":true}`),
+		})
+		require.NoError(t, err)
+
+		record, err := store.ReserveIdempotencyKey(ctx, "ci", "key1", "fp1", 24*time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.True(t, record.Completed)
+		assert.Equal(t, 200, record.StatusCode)
+		assert.Equal(t, "application/json", record.ContentType)
+		assert.JSONEq(t, `{"ok":true}`, string(record.Body))
+
+		// Keys of other actors are independent
+		record, err = store.ReserveIdempotencyKey(ctx, "someone-else", "key1", "fp2", 24*time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, record)
+	})
+
+	t.Run("released key can be reserved again", func(t *testing.T) {
+		_, err := store.ReserveIdempotencyKey(ctx, "ci", "key2", "fp1", 24*time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, store.ReleaseIdempotencyKey(ctx, "ci", "key2"))
+
+		record, err := store.ReserveIdempotencyKey(ctx, "ci", "key2", "fp1", 24*time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, record)
+	})
+
+	t.Run("abandoned reservation can be taken over", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "INSERT INTO idempotency_keys (actor_id, key, fingerprint, created_at) VALUES ($1, $2, $3, $4)",
+			"ci", "key3", "fp1", time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		record, err := store.ReserveIdempotencyKey(ctx, "ci", "key3", "fp1", 24*time.Hour)
+		require.NoError(t, err)
+		assert.Nil(t, record)
+	})
+
+	t.Run("expired keys are purged", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "INSERT INTO idempotency_keys (actor_id, key, fingerprint, status_code, created_at, completed_at) VALUES ($1, $2, $3, $4, $5, $5)",
+			"ci", "old", "fp1", 200, time.Now().Add(-48*time.Hour))
+		require.NoError(t, err)
+
+		purged, err := worker.PurgeOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+
+		record, err := store.ReserveIdempotencyKey(ctx, "ci", "key1", "fp1", 24*time.Hour)
+		require.NoError(t, err)
+		require.NotNil(t, record, "fresh keys are kept")
+	})
+}
@@ -0,0 +1,86 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/actor"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service/pull_request"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestService_EventTimeline(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
+
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
+
+	ctx := actor.WithID(context.Background(), "author1")
+
+	// Setup
+	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
+	require.NoError(t, err)
+
+	for _, id := range []string{"author1", "reviewer1", "reviewer2"} {
+		_, err = pool.Exec(ctx, insertTeamMemberQuery,
+			id, id, true, "team1")
+		require.NoError(t, err)
+	}
+
+	t.Run("mutations are recorded in order", func(t *testing.T) {
+		_, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr1",
+			PullRequestName: "Test PR",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+		})
+		require.NoError(t, err)
+
+		_, err = service.SubmitReview(ctx, "", "pr1", "reviewer1", models.APPROVED, 0)
+		require.NoError(t, err)
+
+		_, err = service.MergePullRequest(ctx, "", "pr1", false, 0)
+		require.NoError(t, err)
+
+		events, err := service.GetPullRequestEvents(ctx, "", "pr1")
+		require.NoError(t, err)
+
+		var types []models.PullRequestEventType
+		for _, event := range events {
+			types = append(types, event.Type)
+			assert.Equal(t, "author1", event.Actor)
+		}
+		assert.Equal(t, []models.PullRequestEventType{
+			models.EventCreated,
+			models.EventReviewerAssigned,
+			models.EventReviewerAssigned,
+			models.EventVerdict,
+			models.EventMerged,
+		}, types)
+
+		merged := events[len(events)-1]
+		assert.Equal(t, "OPEN", merged.Before["status"])
+		assert.Equal(t, "MERGED", merged.After["status"])
+	})
+
+	t.Run("events cannot be modified", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "DELETE FROM pull_request_events WHERE pr_id = $1", "pr1")
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown pull request", func(t *testing.T) {
+		_, err := service.GetPullRequestEvents(ctx, "", "missing")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
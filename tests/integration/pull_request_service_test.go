@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"avito-autumn-2025/internal/apperr"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"avito-autumn-2025/internal/service/pull_request"
@@ -20,8 +21,9 @@ func TestPullRequestService_CreatePullRequest(t *testing.T) {
 	prStorage := postgres.NewPullRequestStorage(pool, logger)
 	userStorage := postgres.NewUserStorage(pool, logger)
 	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
 
-	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, logger)
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
 
 	ctx := context.Background()
 
@@ -29,15 +31,15 @@ func TestPullRequestService_CreatePullRequest(t *testing.T) {
 	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"author1", "author1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer1", "reviewer1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer2", "reviewer2", true, "team1")
 	require.NoError(t, err)
 
@@ -54,12 +56,57 @@ func TestPullRequestService_CreatePullRequest(t *testing.T) {
 		assert.Equal(t, "pr1", created.PullRequestId)
 
 		// Verify reviewers were assigned
-		createdPR, err := prStorage.GetPullRequest(ctx, "pr1")
+		createdPR, err := prStorage.GetPullRequest(ctx, "", "pr1")
 		require.NoError(t, err)
 		assert.LessOrEqual(t, len(createdPR.AssignedReviewers), 2)
 		assert.NotContains(t, createdPR.AssignedReviewers, "author1") // Author should not be reviewer
 	})
 
+	t.Run("duplicate id is a conflict", func(t *testing.T) {
+		_, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr1",
+			PullRequestName: "Test PR",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+		})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+		assert.Equal(t, apperr.CodePullRequestExists, apperr.Code(err))
+	})
+
+	t.Run("create or get returns the existing pull request", func(t *testing.T) {
+		existing, err := prStorage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+
+		pr, created, err := service.CreateOrGetPullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr1",
+			PullRequestName: "Test PR",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+		})
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.ElementsMatch(t, existing.AssignedReviewers, pr.AssignedReviewers)
+
+		_, _, err = service.CreateOrGetPullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr1",
+			PullRequestName: "Test PR",
+			AuthorId:        "reviewer1",
+			Status:          models.OPEN,
+		})
+		assert.ErrorIs(t, err, apperr.ErrConflict)
+
+		pr, created, err = service.CreateOrGetPullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr3",
+			PullRequestName: "Test PR 3",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+		})
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.NotEmpty(t, pr.AssignedReviewers)
+	})
+
 	t.Run("author not active", func(t *testing.T) {
 		_, err := pool.Exec(ctx, "UPDATE users SET is_active = false WHERE id = $1", "author1")
 		require.NoError(t, err)
@@ -85,8 +132,9 @@ func TestPullRequestService_MergePullRequest(t *testing.T) {
 	prStorage := postgres.NewPullRequestStorage(pool, logger)
 	userStorage := postgres.NewUserStorage(pool, logger)
 	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
 
-	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, logger)
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
 
 	ctx := context.Background()
 
@@ -94,7 +142,7 @@ func TestPullRequestService_MergePullRequest(t *testing.T) {
 	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"author1", "author1", true, "team1")
 	require.NoError(t, err)
 
@@ -103,16 +151,16 @@ func TestPullRequestService_MergePullRequest(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("successful merge", func(t *testing.T) {
-		err := service.MergePullRequest(ctx, "pr1")
+		_, err := service.MergePullRequest(ctx, "", "pr1", false, 0)
 		require.NoError(t, err)
 
-		pr, err := prStorage.GetPullRequest(ctx, "pr1")
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr1")
 		require.NoError(t, err)
 		assert.Equal(t, models.MERGED, pr.Status)
 	})
 
 	t.Run("idempotent merge", func(t *testing.T) {
-		err := service.MergePullRequest(ctx, "pr1")
+		_, err := service.MergePullRequest(ctx, "", "pr1", false, 0)
 		require.NoError(t, err) // Should not error on second merge
 	})
 }
@@ -125,8 +173,9 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 	prStorage := postgres.NewPullRequestStorage(pool, logger)
 	userStorage := postgres.NewUserStorage(pool, logger)
 	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
 
-	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, logger)
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
 
 	ctx := context.Background()
 
@@ -134,15 +183,15 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"author1", "author1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer1", "reviewer1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer2", "reviewer2", true, "team1")
 	require.NoError(t, err)
 
@@ -155,11 +204,280 @@ func TestPullRequestService_ReassignReviewer(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("successful reassignment", func(t *testing.T) {
-		err := service.ReassignReviewer(ctx, "pr1", "reviewer1")
+		_, err := service.ReassignReviewer(ctx, "", "pr1", "reviewer1", 0)
 		require.NoError(t, err)
 
-		pr, err := prStorage.GetPullRequest(ctx, "pr1")
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr1")
 		require.NoError(t, err)
 		assert.NotContains(t, pr.AssignedReviewers, "reviewer1")
 	})
 }
+
+func TestPullRequestService_CreatePullRequestWithMetadata(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
+
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
+
+	ctx := context.Background()
+
+	// Setup: author with three possible reviewers
+	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
+	require.NoError(t, err)
+
+	for _, id := range []string{"author1", "reviewer1", "reviewer2", "reviewer3"} {
+		_, err = pool.Exec(ctx, insertTeamMemberQuery,
+			id, id, true, "team1")
+		require.NoError(t, err)
+	}
+
+	_, err = repoStorage.UpsertRepository(ctx, &models.Repository{Name: "backend-api"})
+	require.NoError(t, err)
+
+	t.Run("large PR gets a third reviewer", func(t *testing.T) {
+		pr := &models.PullRequest{
+			PullRequestId:   "large",
+			PullRequestName: "Large PR",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			Repository:      "backend-api",
+			Labels:          []string{"refactoring"},
+			Additions:       400,
+			Deletions:       200,
+		}
+
+		created, err := service.CreatePullRequest(ctx, pr)
+		require.NoError(t, err)
+		assert.Len(t, created.AssignedReviewers, 3)
+
+		stored, err := prStorage.GetPullRequest(ctx, "backend-api", "large")
+		require.NoError(t, err)
+		assert.Equal(t, "backend-api", stored.Repository)
+		assert.Equal(t, []string{"refactoring"}, stored.Labels)
+		assert.Equal(t, models.NORMAL, stored.Priority)
+		assert.Equal(t, 600, stored.ChangedLines())
+	})
+
+	t.Run("urgent PR skips busy reviewers", func(t *testing.T) {
+		for _, id := range []string{"busy1", "busy2"} {
+			_, err := pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status) VALUES ($1, $2, $3, $4)",
+				id, id, "author1", "OPEN")
+			require.NoError(t, err)
+			_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)", id, "reviewer1")
+			require.NoError(t, err)
+		}
+
+		pr := &models.PullRequest{
+			PullRequestId:   "urgent",
+			PullRequestName: "Hotfix",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			Priority:        models.URGENT,
+		}
+
+		created, err := service.CreatePullRequest(ctx, pr)
+		require.NoError(t, err)
+		assert.NotContains(t, created.AssignedReviewers, "reviewer1")
+	})
+}
+
+func TestPullRequestService_CreatePullRequestWithRepositoryPool(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
+
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
+
+	ctx := context.Background()
+
+	// Setup: author team and a platform engineer outside it who owns the repository pool
+	for _, team := range []string{"team1", "platform"} {
+		_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", team)
+		require.NoError(t, err)
+	}
+
+	for id, team := range map[string]string{"author1": "team1", "teammate1": "team1", "platform1": "platform"} {
+		_, err := pool.Exec(ctx, insertTeamMemberQuery,
+			id, id, true, team)
+		require.NoError(t, err)
+	}
+
+	_, err := repoStorage.UpsertRepository(ctx, &models.Repository{
+		Name:                 "infra",
+		FallbackToAuthorTeam: true,
+		Reviewers:            []string{"platform1"},
+	})
+	require.NoError(t, err)
+
+	_, err = repoStorage.UpsertRepository(ctx, &models.Repository{Name: "app"})
+	require.NoError(t, err)
+
+	t.Run("reviewers come from the repository pool", func(t *testing.T) {
+		created, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "1",
+			PullRequestName: "Infra change",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			Repository:      "infra",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"platform1"}, created.AssignedReviewers)
+	})
+
+	t.Run("same id in another repository falls back to author team", func(t *testing.T) {
+		created, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "1",
+			PullRequestName: "App change",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			Repository:      "app",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"teammate1"}, created.AssignedReviewers)
+
+		infraPR, err := prStorage.GetPullRequest(ctx, "infra", "1")
+		require.NoError(t, err)
+		assert.Equal(t, "Infra change", infraPR.PullRequestName)
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		_, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "1",
+			PullRequestName: "Typo",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			Repository:      "infar",
+		})
+		assert.Equal(t, apperr.CodeRepositoryNotFound, apperr.Code(err))
+
+		_, err = prStorage.GetPullRequest(ctx, "infar", "1")
+		assert.Equal(t, apperr.CodePullRequestNotFound, apperr.Code(err))
+	})
+}
+
+func TestPullRequestService_CreatePullRequestTeamSelection(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
+
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
+
+	ctx := context.Background()
+
+	// Setup: author in a feature team and, as a secondary membership, in a guild
+	for _, team := range []string{"feature", "guild"} {
+		_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", team)
+		require.NoError(t, err)
+	}
+
+	for id, team := range map[string]string{"author1": "feature", "feature1": "feature", "guild1": "guild"} {
+		_, err := pool.Exec(ctx, insertTeamMemberQuery, id, id, true, team)
+		require.NoError(t, err)
+	}
+
+	_, err := pool.Exec(ctx, "INSERT INTO team_memberships (team_name, user_id) VALUES ($1, $2)", "guild", "author1")
+	require.NoError(t, err)
+
+	_, err = repoStorage.UpsertRepository(ctx, &models.Repository{Name: "guild-tools", TeamName: "guild"})
+	require.NoError(t, err)
+
+	t.Run("primary team by default", func(t *testing.T) {
+		created, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr1",
+			PullRequestName: "Feature change",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "feature", created.TeamName)
+		assert.Equal(t, []string{"feature1"}, created.AssignedReviewers)
+	})
+
+	t.Run("explicit team on the request", func(t *testing.T) {
+		created, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr2",
+			PullRequestName: "Guild change",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			TeamName:        "guild",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"guild1"}, created.AssignedReviewers)
+	})
+
+	t.Run("repository team", func(t *testing.T) {
+		created, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr3",
+			PullRequestName: "Tooling change",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			Repository:      "guild-tools",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "guild", created.TeamName)
+		assert.Equal(t, []string{"guild1"}, created.AssignedReviewers)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		_, err := service.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr4",
+			PullRequestName: "Broken change",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			TeamName:        "missing",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestPullRequestService_CreatePullRequestParentTeamFallback(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
+
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
+
+	ctx := context.Background()
+
+	// Setup: a squad whose only member is the author, inside a larger team
+	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "backend")
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, "INSERT INTO teams (name, parent_name) VALUES ($1, $2)", "payments", "backend")
+	require.NoError(t, err)
+
+	for id, team := range map[string]string{"author1": "payments", "backend1": "backend"} {
+		_, err := pool.Exec(ctx, insertTeamMemberQuery, id, id, true, team)
+		require.NoError(t, err)
+	}
+
+	created, err := service.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Squad change",
+		AuthorId:        "author1",
+		Status:          models.OPEN,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "payments", created.TeamName)
+	assert.Equal(t, []string{"backend1"}, created.AssignedReviewers)
+}
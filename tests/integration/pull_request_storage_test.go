@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"avito-autumn-2025/internal/apperr"
 	"avito-autumn-2025/internal/logger"
 	"avito-autumn-2025/internal/models"
 	"avito-autumn-2025/internal/storage/postgres"
@@ -25,15 +26,15 @@ func TestPullRequestStorage_CreatePullRequest(t *testing.T) {
 	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"author1", "author1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer1", "reviewer1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer2", "reviewer2", true, "team1")
 	require.NoError(t, err)
 
@@ -50,7 +51,7 @@ func TestPullRequestStorage_CreatePullRequest(t *testing.T) {
 		require.NoError(t, err)
 
 		// Verify PR was created
-		created, err := storage.GetPullRequest(ctx, "pr1")
+		created, err := storage.GetPullRequest(ctx, "", "pr1")
 		require.NoError(t, err)
 		assert.Equal(t, "pr1", created.PullRequestId)
 		assert.Equal(t, "Test PR", created.PullRequestName)
@@ -71,7 +72,7 @@ func TestPullRequestStorage_MergePullRequest(t *testing.T) {
 	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"author1", "author1", true, "team1")
 	require.NoError(t, err)
 
@@ -80,20 +81,20 @@ func TestPullRequestStorage_MergePullRequest(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("successful merge", func(t *testing.T) {
-		err := storage.MergePullRequest(ctx, "pr1")
+		_, err := storage.MergePullRequest(ctx, "", "pr1", false, 0)
 		require.NoError(t, err)
 
-		pr, err := storage.GetPullRequest(ctx, "pr1")
+		pr, err := storage.GetPullRequest(ctx, "", "pr1")
 		require.NoError(t, err)
 		assert.Equal(t, models.MERGED, pr.Status)
 		assert.NotNil(t, pr.MergedAt)
 	})
 
 	t.Run("idempotent merge", func(t *testing.T) {
-		err := storage.MergePullRequest(ctx, "pr1")
+		_, err := storage.MergePullRequest(ctx, "", "pr1", false, 0)
 		require.NoError(t, err)
 
-		pr, err := storage.GetPullRequest(ctx, "pr1")
+		pr, err := storage.GetPullRequest(ctx, "", "pr1")
 		require.NoError(t, err)
 		assert.Equal(t, models.MERGED, pr.Status)
 	})
@@ -112,15 +113,15 @@ func TestPullRequestStorage_ReassignReviewer(t *testing.T) {
 	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"author1", "author1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer1", "reviewer1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer2", "reviewer2", true, "team1")
 	require.NoError(t, err)
 
@@ -132,11 +133,33 @@ func TestPullRequestStorage_ReassignReviewer(t *testing.T) {
 		"pr1", "reviewer1")
 	require.NoError(t, err)
 
+	t.Run("stale version is rejected", func(t *testing.T) {
+		pr, err := storage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+
+		_, err = storage.ReassignReviewer(ctx, "", "pr1", "reviewer1", pr.Version+1)
+		assert.ErrorIs(t, err, apperr.ErrVersionMismatch)
+
+		unchanged, err := storage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, pr.Version, unchanged.Version)
+		assert.Equal(t, []string{"reviewer1"}, unchanged.AssignedReviewers)
+	})
+
+	t.Run("current version is accepted", func(t *testing.T) {
+		pr, err := storage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+
+		version, err := storage.SubmitReview(ctx, "", "pr1", "reviewer1", models.COMMENTED, pr.Version)
+		require.NoError(t, err)
+		assert.Greater(t, version, pr.Version)
+	})
+
 	t.Run("successful reassignment", func(t *testing.T) {
-		err := storage.ReassignReviewer(ctx, "pr1", "reviewer1")
+		_, err := storage.ReassignReviewer(ctx, "", "pr1", "reviewer1", 0)
 		require.NoError(t, err)
 
-		pr, err := storage.GetPullRequest(ctx, "pr1")
+		pr, err := storage.GetPullRequest(ctx, "", "pr1")
 		require.NoError(t, err)
 		assert.Contains(t, pr.AssignedReviewers, "reviewer2")
 		assert.NotContains(t, pr.AssignedReviewers, "reviewer1")
@@ -144,17 +167,17 @@ func TestPullRequestStorage_ReassignReviewer(t *testing.T) {
 
 	t.Run("cannot reassign merged PR", func(t *testing.T) {
 		// Merge PR
-		err := storage.MergePullRequest(ctx, "pr1")
+		_, err := storage.MergePullRequest(ctx, "", "pr1", false, 0)
 		require.NoError(t, err)
 
 		// Try to reassign
-		err = storage.ReassignReviewer(ctx, "pr1", "reviewer2")
+		_, err = storage.ReassignReviewer(ctx, "", "pr1", "reviewer2", 0)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "merged")
 	})
 }
 
-func TestPullRequestStorage_GetPullRequestsByReviewer(t *testing.T) {
+func TestPullRequestStorage_SearchPullRequests(t *testing.T) {
 	pool, cleanup := SetupTestDB(t)
 	defer cleanup()
 
@@ -167,26 +190,171 @@ func TestPullRequestStorage_GetPullRequestsByReviewer(t *testing.T) {
 	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"author1", "author1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO users (id, username, is_active, team_name) VALUES ($1, $2, $3, $4)",
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
+		"author2", "author2", true, "team1")
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
 		"reviewer1", "reviewer1", true, "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, created_at) VALUES ($1, $2, $3, $4, $5)",
-		"pr1", "PR 1", "author1", "OPEN", time.Now())
+	base := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	prs := []struct {
+		id, author, status string
+		createdAt          time.Time
+	}{
+		{"pr1", "author1", "OPEN", base},
+		{"pr2", "author2", "MERGED", base.Add(time.Hour)},
+		{"pr3", "author1", "OPEN", base.Add(2 * time.Hour)},
+		{"pr4", "author1", "CLOSED", base.Add(3 * time.Hour)},
+	}
+	for _, pr := range prs {
+		_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, team_name, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+			pr.id, "PR "+pr.id, pr.author, pr.status, "team1", pr.createdAt)
+		require.NoError(t, err)
+	}
+
+	for _, prID := range []string{"pr1", "pr2", "pr3"} {
+		_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)",
+			prID, "reviewer1")
+		require.NoError(t, err)
+	}
+
+	ids := func(page *models.PullRequestPage) []string {
+		var ids []string
+		for _, pr := range page.PullRequests {
+			ids = append(ids, pr.PullRequestId)
+		}
+		return ids
+	}
+
+	t.Run("get PRs by reviewer", func(t *testing.T) {
+		page, err := storage.SearchPullRequests(ctx, &models.PullRequestQuery{
+			ReviewerId: "reviewer1", Sort: models.SortByCreatedAt, Order: models.SortDesc, Limit: 10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr3", "pr2", "pr1"}, ids(page))
+		assert.Nil(t, page.NextCursor)
+		assert.Equal(t, "team1", page.PullRequests[0].TeamName)
+	})
+
+	t.Run("filters by status and author", func(t *testing.T) {
+		page, err := storage.SearchPullRequests(ctx, &models.PullRequestQuery{
+			AuthorId: "author1", Statuses: []models.PullRequestStatus{models.OPEN, models.CLOSED},
+			Sort: models.SortByCreatedAt, Order: models.SortAsc, Limit: 10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr1", "pr3", "pr4"}, ids(page))
+	})
+
+	t.Run("filters by creation date", func(t *testing.T) {
+		from, to := base.Add(time.Hour), base.Add(3*time.Hour)
+		page, err := storage.SearchPullRequests(ctx, &models.PullRequestQuery{
+			CreatedFrom: &from, CreatedTo: &to, Sort: models.SortByCreatedAt, Order: models.SortAsc, Limit: 10,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr2", "pr3"}, ids(page))
+	})
+
+	t.Run("pages with a cursor", func(t *testing.T) {
+		query := &models.PullRequestQuery{Sort: models.SortByCreatedAt, Order: models.SortDesc, Limit: 3}
+		page, err := storage.SearchPullRequests(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr4", "pr3", "pr2"}, ids(page))
+		require.NotNil(t, page.NextCursor)
+
+		query.After = page.NextCursor
+		page, err = storage.SearchPullRequests(ctx, query)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr1"}, ids(page))
+		assert.Nil(t, page.NextCursor)
+	})
+}
+
+func TestPullRequestStorage_BatchLoading(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewPullRequestStorage(pool, logger)
+
+	ctx := context.Background()
+
+	// Setup
+	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)",
-		"pr1", "reviewer1")
+	for _, id := range []string{"author1", "reviewer1", "reviewer2"} {
+		_, err = pool.Exec(ctx, insertTeamMemberQuery, id, id, true, "team1")
+		require.NoError(t, err)
+	}
+
+	for prID, status := range map[string]string{"pr1": "OPEN", "pr2": "MERGED"} {
+		_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, team_name) VALUES ($1, $2, $3, $4, $5)",
+			prID, "PR "+prID, "author1", status, "team1")
+		require.NoError(t, err)
+	}
+
+	base := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	reviewers := []struct {
+		prID, userID string
+		assignedAt   time.Time
+	}{
+		{"pr1", "reviewer1", base},
+		{"pr1", "reviewer2", base.Add(time.Minute)},
+		{"pr2", "reviewer1", base.Add(time.Hour)},
+	}
+	for _, r := range reviewers {
+		_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id, assigned_at) VALUES ($1, $2, $3)",
+			r.prID, r.userID, r.assignedAt)
+		require.NoError(t, err)
+	}
+	_, err = pool.Exec(ctx, "UPDATE pull_request_reviewers SET verdict = 'APPROVED', responded_at = $1 WHERE pr_id = 'pr1' AND user_id = 'reviewer1'",
+		base.Add(2*time.Hour))
 	require.NoError(t, err)
 
-	t.Run("get PRs by reviewer", func(t *testing.T) {
-		prs, err := storage.GetPullRequestsByReviewer(ctx, "reviewer1")
+	t.Run("pull requests by keys", func(t *testing.T) {
+		prs, err := storage.GetPullRequestsByKeys(ctx, []models.PullRequestKey{
+			{PullRequestId: "pr1"}, {PullRequestId: "pr2"}, {PullRequestId: "missing"}, {Repository: "other", PullRequestId: "pr1"},
+		})
+		require.NoError(t, err)
+		require.Len(t, prs, 2)
+
+		byID := map[string]*models.PullRequest{}
+		for _, pr := range prs {
+			byID[pr.PullRequestId] = pr
+		}
+		assert.Equal(t, []string{"reviewer1", "reviewer2"}, byID["pr1"].AssignedReviewers)
+		assert.Equal(t, []string{"reviewer1"}, byID["pr2"].AssignedReviewers)
+		assert.Equal(t, models.MERGED, byID["pr2"].Status)
+		assert.Equal(t, "team1", byID["pr1"].TeamName)
+	})
+
+	t.Run("review assignments by reviewers", func(t *testing.T) {
+		assignments, err := storage.GetReviewAssignmentsByReviewers(ctx, []string{"reviewer1", "author1"})
+		require.NoError(t, err)
+		require.Len(t, assignments, 2)
+
+		assert.Equal(t, "pr2", assignments[0].PullRequestId)
+		assert.Equal(t, models.MERGED, assignments[0].PullRequestStatus)
+		assert.Empty(t, assignments[0].Verdict)
+		assert.Nil(t, assignments[0].RespondedAt)
+
+		assert.Equal(t, "pr1", assignments[1].PullRequestId)
+		assert.Equal(t, models.APPROVED, assignments[1].Verdict)
+		assert.NotNil(t, assignments[1].RespondedAt)
+		assert.Equal(t, "team1", assignments[1].TeamName)
+	})
+
+	t.Run("review assignments by pull requests", func(t *testing.T) {
+		assignments, err := storage.GetReviewAssignmentsByPullRequests(ctx, []models.PullRequestKey{{PullRequestId: "pr1"}})
 		require.NoError(t, err)
-		assert.Len(t, prs, 1)
-		assert.Equal(t, "pr1", prs[0].PullRequestId)
+		require.Len(t, assignments, 2)
+		assert.Equal(t, "reviewer1", assignments[0].ReviewerId)
+		assert.Equal(t, "reviewer2", assignments[1].ReviewerId)
 	})
 }
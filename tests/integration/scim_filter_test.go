@@ -0,0 +1,147 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/scim"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var scimFilterAttributes = []string{"userName", "externalId", "active", "groups.value", "groups.display"}
+
+func TestSCIMFilter_Match(t *testing.T) {
+	alice := scim.Attributes{
+		"username":       "Alice",
+		"externalid":     "ext-1",
+		"active":         true,
+		"groups.value":   []string{"backend", "guild"},
+		"groups.display": []string{"Backend", "Guild"},
+	}
+	bob := scim.Attributes{
+		"username":       "bob",
+		"externalid":     "",
+		"active":         false,
+		"groups.value":   []string{},
+		"groups.display": []string{},
+	}
+
+	tests := []struct {
+		expr  string
+		alice bool
+		bob   bool
+	}{
+		{`userName eq "alice"`, true, false},
+		{`USERNAME EQ "ALICE"`, true, false},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "bob"`, false, true},
+		{`userName ne "alice"`, false, true},
+		{`userName co "li"`, true, false},
+		{`userName sw "b"`, false, true},
+		{`userName ew "ce"`, true, false},
+		{`userName gt "b"`, false, true},
+		{`userName le "alice"`, true, false},
+		{`active eq true`, true, false},
+		{`active ne true`, false, true},
+		{`externalId pr`, true, false},
+		{`externalId eq null`, false, true},
+		{`groups.value eq "guild"`, true, false},
+		{`groups.display sw "back"`, true, false},
+		{`groups.value pr`, true, false},
+		{`userName eq "alice" and active eq true`, true, false},
+		{`userName eq "alice" or userName eq "bob"`, true, true},
+		{`userName eq "bob" or userName eq "alice" and active eq false`, false, true},
+		{`(userName eq "bob" or userName eq "alice") and active eq false`, false, true},
+		{`not (active eq true)`, false, true},
+		{`not (userName eq "alice" or userName eq "bob")`, false, false},
+		{`userName eq "a \"quoted\" name"`, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			filter, err := scim.ParseFilter(tt.expr, scimFilterAttributes...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.alice, filter.Match(alice), "alice")
+			assert.Equal(t, tt.bob, filter.Match(bob), "bob")
+		})
+	}
+
+	t.Run("missing attribute", func(t *testing.T) {
+		filter, err := scim.ParseFilter(`active eq true`, scimFilterAttributes...)
+		require.NoError(t, err)
+		assert.False(t, filter.Match(scim.Attributes{}))
+
+		filter, err = scim.ParseFilter(`active ne true`, scimFilterAttributes...)
+		require.NoError(t, err)
+		assert.True(t, filter.Match(scim.Attributes{}))
+	})
+}
+
+func TestSCIMFilter_Malformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		detail string
+	}{
+		{"empty", "   ", "empty filter"},
+		{"unterminated string", `userName eq "alice`, "unterminated string"},
+		{"unterminated escaped quote", `userName eq "alice\"`, "unterminated string"},
+		{"invalid escape", `userName eq "\q"`, `invalid string "\q"`},
+		{"trailing and", `userName eq "alice" and`, "unexpected end of filter"},
+		{"trailing or", `userName eq "alice" or`, "unexpected end of filter"},
+		{"not without parentheses", `not active eq true`, "not must be followed by a parenthesized filter"},
+		{"missing closing parenthesis", `(userName eq "alice"`, "missing closing parenthesis"},
+		{"stray closing parenthesis", `userName eq "alice")`, `unexpected ")"`},
+		{"missing value", `userName eq`, "unexpected end of filter"},
+		{"missing operator", `userName`, "unexpected end of filter"},
+		{"quoted attribute", `"userName" eq "alice"`, `expected attribute, got "userName"`},
+		{"quoted operator", `userName "eq" "alice"`, `expected operator, got "eq"`},
+		{"unknown attribute", `displayName eq "alice"`, "unsupported attribute displayName"},
+		{"unknown operator", `userName like "alice"`, "unsupported operator like"},
+		{"unquoted value", `userName eq alice`, "unsupported value alice"},
+		{"ordering on a bool", `active gt true`, "operator gt needs a string value"},
+		{"two comparisons without a keyword", `active eq true userName pr`, `unexpected "userName"`},
+		{"complex attribute filter", `groups[value eq "guild"]`, "unsupported attribute groups[value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := scim.ParseFilter(tt.expr, scimFilterAttributes...)
+
+			var scimErr *scim.Error
+			require.True(t, errors.As(err, &scimErr), "expected a SCIM error, got %v", err)
+			assert.Equal(t, http.StatusBadRequest, scimErr.Status)
+			assert.Equal(t, "invalidFilter", scimErr.ScimType)
+			assert.Equal(t, tt.detail, scimErr.Detail)
+		})
+	}
+}
+
+func TestSCIMFilter_EqualityValue(t *testing.T) {
+	tests := []struct {
+		expr  string
+		value string
+		ok    bool
+	}{
+		{`userName eq "alice"`, "alice", true},
+		{`USERNAME EQ "Alice"`, "Alice", true},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "bob"`, "bob", true},
+		{`userName ne "alice"`, "", false},
+		{`userName co "alice"`, "", false},
+		{`externalId eq "alice"`, "", false},
+		{`userName eq null`, "", false},
+		{`userName eq "alice" and active eq true`, "", false},
+		{`(userName eq "alice")`, "alice", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			filter, err := scim.ParseFilter(tt.expr, scimFilterAttributes...)
+			require.NoError(t, err)
+			value, ok := scim.EqualityValue(filter, "userName")
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
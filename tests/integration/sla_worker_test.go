@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service/sla"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAWorker_CheckOnce(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	worker := sla.NewWorker(&prStorage, time.Minute, logger)
+
+	ctx := context.Background()
+
+	// Setup: team with a strict reassign policy and an overdue review
+	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
+	require.NoError(t, err)
+
+	for _, id := range []string{"author1", "reviewer1", "reviewer2"} {
+		_, err = pool.Exec(ctx, insertTeamMemberQuery,
+			id, id, true, "team1")
+		require.NoError(t, err)
+	}
+
+	_, err = pool.Exec(ctx, "INSERT INTO team_policies (team_name, first_response_hours, business_hours_only, sla_action) VALUES ($1, $2, $3, $4)",
+		"team1", 1, false, "REASSIGN")
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, team_name) VALUES ($1, $2, $3, $4, $5)",
+		"pr1", "Test PR", "author1", "OPEN", "team1")
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id, assigned_at) VALUES ($1, $2, $3)",
+		"pr1", "reviewer1", time.Now().Add(-2*time.Hour))
+	require.NoError(t, err)
+
+	t.Run("overdue reviewer is reassigned", func(t *testing.T) {
+		actions, err := worker.CheckOnce(ctx)
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		assert.Equal(t, models.SLAActionReassign, actions[0].Action)
+		assert.Equal(t, "reviewer2", actions[0].NewReviewerId)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"reviewer2"}, pr.AssignedReviewers)
+		require.Len(t, pr.SLAActions, 1)
+		assert.Equal(t, "reviewer1", pr.SLAActions[0].ReviewerId)
+	})
+
+	t.Run("fresh assignment is left alone", func(t *testing.T) {
+		actions, err := worker.CheckOnce(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, actions)
+	})
+}
+
+func TestSLADeadline(t *testing.T) {
+	officeHours := &sla.BusinessHours{Start: 9, End: 18, Location: time.UTC}
+	friday := time.Date(2025, time.November, 14, 18, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, friday.Add(24*time.Hour), sla.Deadline(friday, 24, nil))
+	// Friday is over at 18:00: 9 hours on Monday, 9 on Tuesday, the last 6 on Wednesday
+	assert.Equal(t, time.Date(2025, time.November, 19, 15, 0, 0, 0, time.UTC), sla.Deadline(friday, 24, officeHours))
+
+	// Nights do not count: assigned before the office opens, due 2 hours after it does
+	tuesday := time.Date(2025, time.November, 18, 7, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, time.November, 18, 11, 0, 0, 0, time.UTC), sla.Deadline(tuesday, 2, officeHours))
+
+	// Working hours are those of the team's time zone: 16:00 in Moscow is 13:00
+	// UTC, 2 hours are left on Friday and 6 more run from 09:00 on Monday
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	moscowHours := &sla.BusinessHours{Start: 9, End: 18, Location: moscow}
+	assignedAt := time.Date(2025, time.November, 14, 13, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, time.November, 17, 12, 0, 0, 0, time.UTC), sla.Deadline(assignedAt, 8, moscowHours))
+
+	// Assigned on Saturday, the clock starts on Monday morning
+	saturday := time.Date(2025, time.November, 15, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2025, time.November, 17, 10, 0, 0, 0, time.UTC), sla.Deadline(saturday, 1, officeHours))
+}
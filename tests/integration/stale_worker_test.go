@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service/stale"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleWorker_CheckOnce(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	worker := stale.NewWorker(&prStorage, time.Hour, logger)
+
+	ctx := context.Background()
+
+	// Setup: team that marks PRs stale after 3 days and closes them 2 days later
+	_, err := pool.Exec(ctx, "INSERT INTO teams (name) VALUES ($1)", "team1")
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, insertTeamMemberQuery,
+		"author1", "author1", true, "team1")
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO team_policies (team_name, stale_after_days, close_after_days) VALUES ($1, $2, $3)",
+		"team1", 3, 2)
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, updated_at, team_name) VALUES ($1, $2, $3, $4, $5, $6)",
+		"inactive", "Inactive PR", "author1", "OPEN", time.Now().Add(-4*24*time.Hour), "team1")
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, updated_at, team_name) VALUES ($1, $2, $3, $4, $5, $6)",
+		"active", "Active PR", "author1", "OPEN", time.Now(), "team1")
+	require.NoError(t, err)
+
+	t.Run("inactive PR is marked stale", func(t *testing.T) {
+		result, err := worker.CheckOnce(ctx)
+		require.NoError(t, err)
+		require.Len(t, result.MarkedStale, 1)
+		assert.Equal(t, "inactive", result.MarkedStale[0].PullRequestId)
+		assert.Empty(t, result.Closed)
+
+		prs, err := prStorage.GetStalePullRequests(ctx)
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, "inactive", prs[0].PullRequestId)
+	})
+
+	t.Run("stale PR is closed after grace period", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "UPDATE pull_requests SET stale_at = $1 WHERE id = $2", time.Now().Add(-3*24*time.Hour), "inactive")
+		require.NoError(t, err)
+
+		result, err := worker.CheckOnce(ctx)
+		require.NoError(t, err)
+		require.Len(t, result.Closed, 1)
+		assert.Equal(t, "inactive", result.Closed[0].PullRequestId)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "inactive")
+		require.NoError(t, err)
+		assert.Equal(t, models.CLOSED, pr.Status)
+		assert.NotNil(t, pr.ClosedAt)
+	})
+}
@@ -0,0 +1,299 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/importer"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service/team"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const teamImportYAML = `
+teams:
+  - team_name: payments
+    parent_team: backend
+    members:
+      - id: user2
+        username: bob
+        is_active: true
+  - team_name: backend
+    policy:
+      first_response_hours: 8
+      sla_action: ESCALATE
+    members:
+      - id: user1
+        username: alice
+        is_active: true
+        role: lead
+`
+
+func TestTeamService_ImportTeams(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	service := team.NewTeamService(&teamStorage, logger)
+
+	ctx := context.Background()
+
+	t.Run("dry run reports the diff without applying it", func(t *testing.T) {
+		imp, err := importer.Parse(importer.YAML, strings.NewReader(teamImportYAML))
+		require.NoError(t, err)
+
+		result, err := service.ImportTeams(ctx, imp, true)
+		require.NoError(t, err)
+		assert.False(t, result.Failed())
+		assert.False(t, result.Applied)
+
+		// Parents are created before their subteams
+		require.NotEmpty(t, result.Rows)
+		assert.Equal(t, "backend", result.Rows[0].TeamName)
+		for _, row := range result.Rows {
+			assert.Equal(t, models.ImportCreate, row.Action)
+		}
+
+		_, err = teamStorage.GetTeamWithMembers(ctx, "backend")
+		assert.Error(t, err)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		imp, err := importer.Parse(importer.YAML, strings.NewReader(teamImportYAML))
+		require.NoError(t, err)
+
+		result, err := service.ImportTeams(ctx, imp, false)
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+
+		team, err := teamStorage.GetTeamWithMembers(ctx, "payments")
+		require.NoError(t, err)
+		assert.Equal(t, "backend", team.ParentTeam)
+		require.Len(t, team.Users, 1)
+
+		policy, err := teamStorage.GetTeamPolicy(ctx, "backend")
+		require.NoError(t, err)
+		require.NotNil(t, policy)
+		assert.Equal(t, 8, policy.FirstResponseHours)
+		// Working hours left out of the document get the defaults
+		assert.Equal(t, models.DefaultWorkdayStart, policy.WorkdayStart)
+		assert.Equal(t, models.DefaultWorkdayEnd, policy.WorkdayEnd)
+		assert.Equal(t, models.DefaultTimeZone, policy.TimeZone)
+	})
+
+	t.Run("csv update diff", func(t *testing.T) {
+		csv := "team_name,parent_team,user_id,username,is_active,role,is_primary\n" +
+			"backend,,user1,alice,true,lead,\n" +
+			"backend,,user3,carol,true,observer,\n"
+		imp, err := importer.Parse(importer.CSV, strings.NewReader(csv))
+		require.NoError(t, err)
+
+		result, err := service.ImportTeams(ctx, imp, true)
+		require.NoError(t, err)
+
+		actions := map[string]models.ImportAction{}
+		for _, row := range result.Rows {
+			actions[string(row.Kind)+":"+row.UserId] = row.Action
+		}
+		assert.Equal(t, models.ImportUnchanged, actions["team:"])
+		assert.Equal(t, models.ImportUnchanged, actions["member:user1"])
+		assert.Equal(t, models.ImportCreate, actions["member:user3"])
+	})
+
+	t.Run("omitted is_active keeps the current state", func(t *testing.T) {
+		userStorage := postgres.NewUserStorage(pool, logger)
+		_, err := pool.Exec(ctx, "UPDATE users SET is_active = false WHERE id = 'user2'")
+		require.NoError(t, err)
+
+		csv := "team_name,parent_team,user_id,username,is_active,role,is_primary\n" +
+			"payments,,user2,bob,,,\n" +
+			"payments,,user6,frank,,,\n"
+		imp, err := importer.Parse(importer.CSV, strings.NewReader(csv))
+		require.NoError(t, err)
+
+		result, err := service.ImportTeams(ctx, imp, false)
+		require.NoError(t, err)
+		require.True(t, result.Applied)
+
+		bob, err := userStorage.GetUserByID(ctx, "user2")
+		require.NoError(t, err)
+		assert.False(t, bob.IsActive)
+
+		// New users are created active
+		frank, err := userStorage.GetUserByID(ctx, "user6")
+		require.NoError(t, err)
+		assert.True(t, frank.IsActive)
+	})
+
+	t.Run("invalid rows reject the whole import", func(t *testing.T) {
+		csv := "team_name,parent_team,user_id,username,is_active,role,is_primary\n" +
+			"frontend,,user4,dave,true,,\n" +
+			"frontend,,user5,,true,owner,\n"
+		imp, err := importer.Parse(importer.CSV, strings.NewReader(csv))
+		require.NoError(t, err)
+
+		result, err := service.ImportTeams(ctx, imp, false)
+		require.NoError(t, err)
+		assert.True(t, result.Failed())
+		assert.False(t, result.Applied)
+
+		invalid := result.Rows[len(result.Rows)-1]
+		assert.Equal(t, "line 3", invalid.Row)
+		assert.Equal(t, models.ImportInvalid, invalid.Action)
+		assert.Contains(t, invalid.Error, "username is required")
+		assert.Contains(t, invalid.Error, "role must be one of")
+
+		_, err = teamStorage.GetTeamWithMembers(ctx, "frontend")
+		assert.Error(t, err)
+	})
+}
+
+const teamReconcileYAML = `
+teams:
+  - team_name: backend
+    members:
+      - id: author1
+        username: author1
+        is_active: true
+      - id: stayer
+        username: stayer
+        is_active: true
+      - id: newcomer
+        username: newcomer
+        is_active: true
+`
+
+func TestTeamService_ReconcileTeams(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	service := team.NewTeamService(&teamStorage, logger)
+
+	ctx := context.Background()
+
+	_, err := teamStorage.CreateTeam(ctx, &models.Team{
+		Name: "backend",
+		Users: []*models.User{
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "leaver", Username: "leaver", IsActive: true},
+			{Id: "stayer", Username: "stayer", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+	_, err = teamStorage.CreateTeam(ctx, &models.Team{
+		Name:  "legacy",
+		Users: []*models.User{{Id: "old", Username: "old", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	err = prStorage.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		Status:          models.OPEN,
+		TeamName:        "backend",
+	}, []string{"leaver"})
+	require.NoError(t, err)
+
+	t.Run("dry run reports the diff without applying it", func(t *testing.T) {
+		imp, err := importer.Parse(importer.YAML, strings.NewReader(teamReconcileYAML))
+		require.NoError(t, err)
+
+		result, err := service.ReconcileTeams(ctx, imp, true)
+		require.NoError(t, err)
+		assert.False(t, result.Failed())
+		assert.False(t, result.Applied)
+
+		actions := map[string]models.ImportAction{}
+		for _, row := range result.Rows {
+			actions[string(row.Kind)+":"+row.TeamName+":"+row.UserId] = row.Action
+		}
+		assert.Equal(t, models.ImportCreate, actions["member:backend:newcomer"])
+		assert.Equal(t, models.ImportUpdate, actions["user::leaver"])
+		assert.Equal(t, models.ImportUpdate, actions["user::old"])
+		assert.Equal(t, models.ImportDelete, actions["member:backend:leaver"])
+		assert.Equal(t, models.ImportUpdate, actions["review:backend:leaver"])
+		assert.Equal(t, models.ImportDelete, actions["team:legacy:"])
+
+		_, err = teamStorage.GetTeamWithMembers(ctx, "legacy")
+		assert.NoError(t, err)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		imp, err := importer.Parse(importer.YAML, strings.NewReader(teamReconcileYAML))
+		require.NoError(t, err)
+
+		result, err := service.ReconcileTeams(ctx, imp, false)
+		require.NoError(t, err)
+		assert.True(t, result.Applied)
+
+		leaver, err := userStorage.GetUserByID(ctx, "leaver")
+		require.NoError(t, err)
+		require.NotNil(t, leaver)
+		assert.False(t, leaver.IsActive)
+		assert.Empty(t, leaver.Teams)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+		require.Len(t, pr.AssignedReviewers, 1)
+		assert.Contains(t, []string{"stayer", "newcomer"}, pr.AssignedReviewers[0])
+
+		_, err = teamStorage.GetTeamWithMembers(ctx, "legacy")
+		assert.Error(t, err)
+	})
+
+	t.Run("members listed as inactive hand over their reviews", func(t *testing.T) {
+		err := prStorage.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr2",
+			PullRequestName: "Another PR",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			TeamName:        "backend",
+		}, []string{"stayer"})
+		require.NoError(t, err)
+
+		doc := strings.Replace(teamReconcileYAML, "username: stayer\n        is_active: true", "username: stayer\n        is_active: false", 1)
+		imp, err := importer.Parse(importer.YAML, strings.NewReader(doc))
+		require.NoError(t, err)
+
+		result, err := service.ReconcileTeams(ctx, imp, false)
+		require.NoError(t, err)
+		require.True(t, result.Applied)
+
+		reviews := 0
+		for _, row := range result.Rows {
+			if row.Kind == models.ImportRowReview && row.UserId == "stayer" {
+				assert.Equal(t, models.ImportUpdate, row.Action)
+				reviews++
+			}
+		}
+		assert.Positive(t, reviews)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr2")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"newcomer"}, pr.AssignedReviewers)
+	})
+
+	t.Run("parent team must be in the document", func(t *testing.T) {
+		imp := &models.Import{Teams: []*models.ImportTeam{{Name: "payments", ParentTeam: "backend"}}}
+
+		result, err := service.ReconcileTeams(ctx, imp, false)
+		require.NoError(t, err)
+		assert.True(t, result.Failed())
+		assert.Contains(t, result.Rows[0].Error, "not in the document")
+	})
+
+	t.Run("empty document", func(t *testing.T) {
+		_, err := service.ReconcileTeams(ctx, &models.Import{}, false)
+		assert.Error(t, err)
+	})
+}
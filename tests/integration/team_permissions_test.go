@@ -0,0 +1,263 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/auth"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/service/pull_request"
+	"avito-autumn-2025/internal/service/repository"
+	"avito-autumn-2025/internal/service/team"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamService_Permissions(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	service := team.NewTeamService(&teamStorage, logger)
+
+	ctx := context.Background()
+
+	// Setup: a team with a lead, a member and an observer, and a subteam
+	_, err := teamStorage.CreateTeam(ctx, &models.Team{
+		Name: "backend",
+		Users: []*models.User{
+			{Id: "lead1", Username: "lead1", IsActive: true, Role: models.RoleLead},
+			{Id: "member1", Username: "member1", IsActive: true},
+			{Id: "observer1", Username: "observer1", IsActive: true, Role: models.RoleObserver},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = teamStorage.CreateTeam(ctx, &models.Team{Name: "payments", ParentTeam: "backend"})
+	require.NoError(t, err)
+
+	policy := &models.TeamPolicy{TeamName: "payments", FirstResponseHours: 24, SLAAction: models.SLAActionReassign}
+
+	t.Run("member cannot edit policy", func(t *testing.T) {
+		memberCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "member1"})
+		_, err := service.SetTeamPolicy(memberCtx, policy)
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+
+	t.Run("lead of parent team can edit subteam policy", func(t *testing.T) {
+		leadCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "lead1"})
+		_, err := service.SetTeamPolicy(leadCtx, policy)
+		assert.NoError(t, err)
+	})
+
+	t.Run("only admins create top-level teams", func(t *testing.T) {
+		leadCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "lead1"})
+		_, err := service.CreateTeam(leadCtx, &models.Team{Name: "frontend"})
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+
+		adminCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "admin", Admin: true})
+		_, err = service.CreateTeam(adminCtx, &models.Team{Name: "frontend"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("observers are listed with their role but not picked", func(t *testing.T) {
+		team, err := service.GetTeamWithMembers(ctx, "backend")
+		require.NoError(t, err)
+		roles := map[string]models.MembershipRole{}
+		for _, member := range team.Users {
+			roles[member.Id] = member.Role
+		}
+		assert.Equal(t, models.RoleObserver, roles["observer1"])
+
+		prStorage := postgres.NewPullRequestStorage(pool, logger)
+		members, err := prStorage.GetActiveTeamMembers(ctx, "backend", "lead1")
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, "member1", members[0].Id)
+	})
+}
+
+func TestPullRequestService_ForceMerge(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
+
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
+
+	ctx := context.Background()
+
+	_, err := teamStorage.CreateTeam(ctx, &models.Team{
+		Name: "team1",
+		Users: []*models.User{
+			{Id: "lead1", Username: "lead1", IsActive: true, Role: models.RoleLead},
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "reviewer1", Username: "reviewer1", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = service.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		Status:          models.OPEN,
+	})
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "UPDATE pull_request_reviewers SET verdict = $1 WHERE pr_id = $2", models.CHANGES_REQUESTED, "pr1")
+	require.NoError(t, err)
+
+	t.Run("requested changes block the merge", func(t *testing.T) {
+		_, err := service.MergePullRequest(ctx, "", "pr1", false, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "requesting changes")
+	})
+
+	t.Run("only leads force merges", func(t *testing.T) {
+		authorCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "author1"})
+		_, err := service.MergePullRequest(authorCtx, "", "pr1", true, 0)
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+
+		leadCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "lead1"})
+		_, err = service.MergePullRequest(leadCtx, "", "pr1", true, 0)
+		require.NoError(t, err)
+
+		pr, err := service.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, models.MERGED, pr.Status)
+	})
+
+	t.Run("only leads reassign reviewers", func(t *testing.T) {
+		authorCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "author1"})
+		_, err := service.ReassignReviewer(authorCtx, "", "pr1", "reviewer1", 0)
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+}
+
+func TestPullRequestService_SubmitReviewPermissions(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
+
+	service := pull_request.NewPullRequestService(&prStorage, &userStorage, &teamStorage, &repoStorage, logger)
+
+	ctx := context.Background()
+
+	_, err := teamStorage.CreateTeam(ctx, &models.Team{
+		Name: "team1",
+		Users: []*models.User{
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "reviewer1", Username: "reviewer1", IsActive: true},
+			{Id: "reviewer2", Username: "reviewer2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = service.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		Status:          models.OPEN,
+	})
+	require.NoError(t, err)
+
+	t.Run("nobody approves on behalf of a reviewer", func(t *testing.T) {
+		authorCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "author1"})
+		_, err := service.SubmitReview(authorCtx, "", "pr1", "reviewer1", models.APPROVED, 0)
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+
+		reviewerCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "reviewer2"})
+		_, err = service.SubmitReview(reviewerCtx, "", "pr1", "reviewer1", models.APPROVED, 0)
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+
+		var verdicts int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM pull_request_reviewers WHERE pr_id = $1 AND verdict IS NOT NULL", "pr1").Scan(&verdicts)
+		require.NoError(t, err)
+		assert.Zero(t, verdicts)
+	})
+
+	t.Run("reviewers submit their own reviews", func(t *testing.T) {
+		reviewerCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "reviewer1"})
+		_, err := service.SubmitReview(reviewerCtx, "", "pr1", "reviewer1", models.APPROVED, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("admins submit on behalf of reviewers", func(t *testing.T) {
+		adminCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "admin", Admin: true})
+		_, err := service.SubmitReview(adminCtx, "", "pr1", "reviewer2", models.COMMENTED, 0)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepositoryService_Permissions(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	repoStorage := postgres.NewRepositoryStorage(pool, logger)
+	service := repository.NewRepositoryService(&repoStorage, &teamStorage, logger)
+
+	ctx := context.Background()
+
+	_, err := teamStorage.CreateTeam(ctx, &models.Team{
+		Name: "platform",
+		Users: []*models.User{
+			{Id: "lead1", Username: "lead1", IsActive: true, Role: models.RoleLead},
+			{Id: "member1", Username: "member1", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = teamStorage.CreateTeam(ctx, &models.Team{
+		Name:  "frontend",
+		Users: []*models.User{{Id: "lead2", Username: "lead2", IsActive: true, Role: models.RoleLead}},
+	})
+	require.NoError(t, err)
+
+	memberCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "member1"})
+	leadCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "lead1"})
+	otherLeadCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "lead2"})
+	adminCtx := auth.WithPrincipal(ctx, auth.Principal{UserID: "admin", Admin: true})
+
+	t.Run("member cannot configure the team repository", func(t *testing.T) {
+		_, err := service.SetRepository(memberCtx, &models.Repository{Name: "infra", TeamName: "platform", Reviewers: []string{"member1"}})
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+	})
+
+	t.Run("lead configures the team repository", func(t *testing.T) {
+		_, err := service.SetRepository(leadCtx, &models.Repository{Name: "infra", TeamName: "platform", Reviewers: []string{"member1"}})
+		require.NoError(t, err)
+	})
+
+	t.Run("lead of another team cannot take the repository over", func(t *testing.T) {
+		_, err := service.SetRepository(otherLeadCtx, &models.Repository{Name: "infra", TeamName: "frontend", Reviewers: []string{"lead2"}})
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+
+		repo, err := service.GetRepository(ctx, "infra")
+		require.NoError(t, err)
+		require.NotNil(t, repo)
+		assert.Equal(t, "platform", repo.TeamName)
+	})
+
+	t.Run("only admins configure repositories without a team", func(t *testing.T) {
+		_, err := service.SetRepository(leadCtx, &models.Repository{Name: "shared"})
+		assert.ErrorIs(t, err, auth.ErrForbidden)
+
+		_, err = service.SetRepository(adminCtx, &models.Repository{Name: "shared"})
+		require.NoError(t, err)
+	})
+}
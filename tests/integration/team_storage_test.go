@@ -0,0 +1,585 @@
+package integration
+
+import (
+	"avito-autumn-2025/internal/apperr"
+	"avito-autumn-2025/internal/logger"
+	"avito-autumn-2025/internal/models"
+	"avito-autumn-2025/internal/storage/postgres"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamStorage_UpdateTeam(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewTeamStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := storage.CreateTeam(ctx, &models.Team{
+		Name:  "team1",
+		Users: []*models.User{{Id: "user1", Username: "user1", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	_, err = storage.CreateTeam(ctx, &models.Team{Name: "team2"})
+	require.NoError(t, err)
+
+	_, err = storage.UpsertTeamPolicy(ctx, &models.TeamPolicy{
+		TeamName:           "team1",
+		FirstResponseHours: 24,
+		SLAAction:          models.SLAActionReassign,
+	})
+	require.NoError(t, err)
+
+	t.Run("rename cascades to members and policy", func(t *testing.T) {
+		team, err := storage.UpdateTeam(ctx, "team1", &models.TeamUpdate{Name: "renamed"}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", team.Name)
+		require.Len(t, team.Users, 1)
+		assert.Equal(t, "user1", team.Users[0].Id)
+
+		policy, err := storage.GetTeamPolicy(ctx, "renamed")
+		require.NoError(t, err)
+		assert.NotNil(t, policy)
+	})
+
+	t.Run("rename to existing team", func(t *testing.T) {
+		_, err := storage.UpdateTeam(ctx, "renamed", &models.TeamUpdate{Name: "team2"}, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		_, err := storage.UpdateTeam(ctx, "missing", &models.TeamUpdate{Name: "other"}, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+	t.Run("membership changes bump the version", func(t *testing.T) {
+		before, err := storage.GetTeamWithMembers(ctx, "team2")
+		require.NoError(t, err)
+
+		after, err := storage.AddTeamMember(ctx, "team2", &models.User{Id: "user2", Username: "user2", IsActive: true}, false, before.Version)
+		require.NoError(t, err)
+		assert.Greater(t, after.Version, before.Version)
+
+		_, err = storage.UpdateTeam(ctx, "team2", &models.TeamUpdate{Name: "team3"}, before.Version)
+		assert.ErrorIs(t, err, apperr.ErrVersionMismatch)
+
+		renamed, err := storage.UpdateTeam(ctx, "team2", &models.TeamUpdate{Name: "team3"}, after.Version)
+		require.NoError(t, err)
+		assert.Equal(t, "team3", renamed.Name)
+	})
+}
+
+func TestTeamStorage_DeleteTeam(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewTeamStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := storage.CreateTeam(ctx, &models.Team{
+		Name: "authors",
+		Users: []*models.User{
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "backup1", Username: "backup1", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = storage.CreateTeam(ctx, &models.Team{
+		Name:       "leaving",
+		ParentTeam: "authors",
+		Users:      []*models.User{{Id: "reviewer1", Username: "reviewer1", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, team_name) VALUES ($1, $2, $3, $4, $5)",
+		"pr1", "Test PR", "author1", "OPEN", "leaving")
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)", "pr1", "reviewer1")
+	require.NoError(t, err)
+
+	t.Run("rejects open reviews by default", func(t *testing.T) {
+		_, err := storage.DeleteTeam(ctx, "leaving", models.OpenReviewsReject, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "open reviews")
+	})
+
+	t.Run("reassigns open reviews to the parent team", func(t *testing.T) {
+		reassigned, err := storage.DeleteTeam(ctx, "leaving", models.OpenReviewsReassign, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reassigned)
+
+		var reviewers []string
+		rows, err := pool.Query(ctx, "SELECT user_id FROM pull_request_reviewers WHERE pr_id = $1", "pr1")
+		require.NoError(t, err)
+		for rows.Next() {
+			var id string
+			require.NoError(t, rows.Scan(&id))
+			reviewers = append(reviewers, id)
+		}
+		rows.Close()
+		assert.Equal(t, []string{"backup1"}, reviewers)
+
+		_, err = storage.GetTeamWithMembers(ctx, "leaving")
+		assert.Error(t, err)
+	})
+
+	t.Run("reassigns reviews within a top-level team to other teams", func(t *testing.T) {
+		_, err := storage.CreateTeam(ctx, &models.Team{
+			Name: "standalone",
+			Users: []*models.User{
+				{Id: "author2", Username: "author2", IsActive: true},
+				{Id: "reviewer2", Username: "reviewer2", IsActive: true},
+			},
+		})
+		require.NoError(t, err)
+
+		_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, team_name) VALUES ($1, $2, $3, $4, $5)",
+			"pr2", "Same team PR", "author2", "OPEN", "standalone")
+		require.NoError(t, err)
+
+		_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)", "pr2", "reviewer2")
+		require.NoError(t, err)
+
+		reassigned, err := storage.DeleteTeam(ctx, "standalone", models.OpenReviewsReassign, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reassigned)
+
+		var reviewer string
+		err = pool.QueryRow(ctx, "SELECT user_id FROM pull_request_reviewers WHERE pr_id = $1", "pr2").Scan(&reviewer)
+		require.NoError(t, err)
+		assert.Contains(t, []string{"author1", "backup1"}, reviewer)
+	})
+
+	t.Run("keeps reviews of members on other teams' pull requests", func(t *testing.T) {
+		_, err := storage.CreateTeam(ctx, &models.Team{
+			Name:  "guild",
+			Users: []*models.User{{Id: "guildie", Username: "guildie", IsActive: true}},
+		})
+		require.NoError(t, err)
+		_, err = storage.AddTeamMember(ctx, "authors", &models.User{Id: "guildie", Username: "guildie", IsActive: true}, false, 0)
+		require.NoError(t, err)
+
+		_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, team_name) VALUES ($1, $2, $3, $4, $5)",
+			"pr3", "Authors PR", "author1", "OPEN", "authors")
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)", "pr3", "guildie")
+		require.NoError(t, err)
+
+		reassigned, err := storage.DeleteTeam(ctx, "guild", models.OpenReviewsReject, 0)
+		require.NoError(t, err)
+		assert.Zero(t, reassigned)
+
+		var reviewer string
+		err = pool.QueryRow(ctx, "SELECT user_id FROM pull_request_reviewers WHERE pr_id = $1", "pr3").Scan(&reviewer)
+		require.NoError(t, err)
+		assert.Equal(t, "guildie", reviewer)
+	})
+
+	t.Run("reassigns subteam reviews held only through the deleted team", func(t *testing.T) {
+		_, err := storage.CreateTeam(ctx, &models.Team{
+			Name:  "platform",
+			Users: []*models.User{{Id: "platform1", Username: "platform1", IsActive: true}},
+		})
+		require.NoError(t, err)
+		_, err = storage.CreateTeam(ctx, &models.Team{
+			Name:       "infra",
+			ParentTeam: "platform",
+			Users: []*models.User{
+				{Id: "author3", Username: "author3", IsActive: true},
+				{Id: "infra1", Username: "infra1", IsActive: true},
+			},
+		})
+		require.NoError(t, err)
+
+		// platform1 was picked through the parent team fallback
+		_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, team_name) VALUES ($1, $2, $3, $4, $5)",
+			"pr4", "Infra PR", "author3", "OPEN", "infra")
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)", "pr4", "platform1")
+		require.NoError(t, err)
+
+		_, err = storage.DeleteTeam(ctx, "platform", models.OpenReviewsReject, 0)
+		assert.Equal(t, apperr.CodeOpenReviews, apperr.Code(err))
+
+		reassigned, err := storage.DeleteTeam(ctx, "platform", models.OpenReviewsReassign, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reassigned)
+
+		var reviewer string
+		err = pool.QueryRow(ctx, "SELECT user_id FROM pull_request_reviewers WHERE pr_id = $1", "pr4").Scan(&reviewer)
+		require.NoError(t, err)
+		assert.Equal(t, "infra1", reviewer)
+	})
+}
+
+func TestTeamStorage_TeamMembers(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewTeamStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := storage.CreateTeam(ctx, &models.Team{
+		Name: "team1",
+		Users: []*models.User{
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "reviewer1", Username: "reviewer1", IsActive: true},
+			{Id: "reviewer2", Username: "reviewer2", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = storage.CreateTeam(ctx, &models.Team{Name: "team2"})
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO pull_requests (id, pull_request_name, author_id, status, team_name) VALUES ($1, $2, $3, $4, $5)",
+		"pr1", "Test PR", "author1", "OPEN", "team1")
+	require.NoError(t, err)
+
+	_, err = pool.Exec(ctx, "INSERT INTO pull_request_reviewers (pr_id, user_id) VALUES ($1, $2)", "pr1", "reviewer1")
+	require.NoError(t, err)
+
+	t.Run("add new member", func(t *testing.T) {
+		team, err := storage.AddTeamMember(ctx, "team2", &models.User{Id: "user3", Username: "user3", IsActive: true}, false, 0)
+		require.NoError(t, err)
+		require.Len(t, team.Users, 1)
+		assert.Equal(t, "user3", team.Users[0].Id)
+		assert.Equal(t, "team2", team.Users[0].TeamName)
+	})
+
+	t.Run("re-adding a member without a role keeps their role", func(t *testing.T) {
+		_, err := storage.AddTeamMember(ctx, "team2", &models.User{Id: "user3", Username: "user3", IsActive: true, Role: models.RoleLead}, false, 0)
+		require.NoError(t, err)
+
+		team, err := storage.AddTeamMember(ctx, "team2", &models.User{Id: "user3", Username: "user3", IsActive: true}, false, 0)
+		require.NoError(t, err)
+		require.Len(t, team.Users, 1)
+		assert.Equal(t, models.RoleLead, team.Users[0].Role)
+
+		team, err = storage.AddTeamMember(ctx, "team2", &models.User{Id: "user3", Username: "user3", IsActive: true, Role: models.RoleMember}, false, 0)
+		require.NoError(t, err)
+		assert.Equal(t, models.RoleMember, team.Users[0].Role)
+	})
+
+	t.Run("member of several teams keeps primary team", func(t *testing.T) {
+		team, err := storage.AddTeamMember(ctx, "team2", &models.User{Id: "reviewer2", Username: "reviewer2", IsActive: true}, false, 0)
+		require.NoError(t, err)
+		assert.Len(t, team.Users, 2)
+
+		members, err := storage.GetTeamWithMembers(ctx, "team1")
+		require.NoError(t, err)
+		assert.Len(t, members.Users, 3)
+		for _, member := range team.Users {
+			if member.Id == "reviewer2" {
+				assert.Equal(t, "team1", member.TeamName)
+			}
+		}
+	})
+
+	t.Run("remove member reassigns team reviews", func(t *testing.T) {
+		reassigned, err := storage.RemoveTeamMember(ctx, "team1", "reviewer1", 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reassigned)
+
+		var reviewerID string
+		err = pool.QueryRow(ctx, "SELECT user_id FROM pull_request_reviewers WHERE pr_id = $1", "pr1").Scan(&reviewerID)
+		require.NoError(t, err)
+		assert.Equal(t, "reviewer2", reviewerID)
+
+		team, err := storage.GetTeamWithMembers(ctx, "team1")
+		require.NoError(t, err)
+		assert.Len(t, team.Users, 2)
+	})
+
+	t.Run("remove non-member", func(t *testing.T) {
+		_, err := storage.RemoveTeamMember(ctx, "team2", "reviewer1", 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not a member")
+	})
+
+	t.Run("remove member nobody can replace unassigns the review", func(t *testing.T) {
+		reassigned, err := storage.RemoveTeamMember(ctx, "team1", "reviewer2", 0)
+		require.NoError(t, err)
+		assert.Equal(t, 0, reassigned)
+
+		var count int
+		err = pool.QueryRow(ctx, "SELECT COUNT(*) FROM pull_request_reviewers WHERE pr_id = $1", "pr1").Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("replace renames and sets members", func(t *testing.T) {
+		members := []*models.User{
+			{Id: "reviewer2", Username: "reviewer2", IsActive: true},
+			{Id: "user4", Username: "user4", IsActive: true},
+		}
+		team, err := storage.ReplaceTeam(ctx, "team2", "team3", members, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "team3", team.Name)
+		ids := []string{}
+		for _, member := range team.Users {
+			ids = append(ids, member.Id)
+		}
+		assert.ElementsMatch(t, []string{"reviewer2", "user4"}, ids)
+	})
+
+	t.Run("failed replace changes nothing", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "UPDATE users SET deleted_at = NOW() WHERE id = $1", "user3")
+		require.NoError(t, err)
+
+		members := []*models.User{{Id: "user3", Username: "user3", IsActive: true}}
+		_, err = storage.ReplaceTeam(ctx, "team3", "team4", members, 0)
+		assert.Equal(t, apperr.CodeUserDeleted, apperr.Code(err))
+
+		team, err := storage.GetTeamWithMembers(ctx, "team3")
+		require.NoError(t, err)
+		assert.Len(t, team.Users, 2)
+		_, err = storage.GetTeamWithMembers(ctx, "team4")
+		assert.Error(t, err)
+	})
+}
+
+func TestTeamStorage_Hierarchy(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewTeamStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := storage.CreateTeam(ctx, &models.Team{Name: "department"})
+	require.NoError(t, err)
+
+	_, err = storage.CreateTeam(ctx, &models.Team{Name: "backend", ParentTeam: "department"})
+	require.NoError(t, err)
+
+	_, err = storage.CreateTeam(ctx, &models.Team{
+		Name:       "payments",
+		ParentTeam: "backend",
+		Users:      []*models.User{{Id: "user1", Username: "user1", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	t.Run("ancestors nearest first", func(t *testing.T) {
+		ancestors, err := storage.GetTeamAncestors(ctx, "payments")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "department"}, ancestors)
+	})
+
+	t.Run("descendants", func(t *testing.T) {
+		team, err := storage.GetTeamWithDescendants(ctx, "department")
+		require.NoError(t, err)
+		require.Len(t, team.Subteams, 1)
+		assert.Equal(t, "backend", team.Subteams[0].Name)
+		require.Len(t, team.Subteams[0].Subteams, 1)
+		assert.Equal(t, "payments", team.Subteams[0].Subteams[0].Name)
+		assert.Len(t, team.Subteams[0].Subteams[0].Users, 1)
+	})
+
+	t.Run("cannot move under own subteam", func(t *testing.T) {
+		parent := "payments"
+		_, err := storage.UpdateTeam(ctx, "department", &models.TeamUpdate{ParentTeam: &parent}, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "own subteam")
+	})
+
+	t.Run("deleting a team reparents its subteams", func(t *testing.T) {
+		_, err := storage.DeleteTeam(ctx, "backend", models.OpenReviewsReject, 0)
+		require.NoError(t, err)
+
+		team, err := storage.GetTeamWithMembers(ctx, "payments")
+		require.NoError(t, err)
+		assert.Equal(t, "department", team.ParentTeam)
+	})
+}
+
+func TestTeamStorage_MembershipHistory(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewTeamStorage(pool, logger)
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := storage.CreateTeam(ctx, &models.Team{
+		Name: "team1",
+		Users: []*models.User{
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "mover", Username: "mover", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = storage.CreateTeam(ctx, &models.Team{Name: "team2"})
+	require.NoError(t, err)
+
+	err = prStorage.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		Status:          models.OPEN,
+		TeamName:        "team1",
+	}, []string{"mover"})
+	require.NoError(t, err)
+	_, err = prStorage.MergePullRequest(ctx, "", "pr1", false, 0)
+	require.NoError(t, err)
+
+	// The reviewer moves to another team after the assignment
+	_, err = storage.AddTeamMember(ctx, "team2", &models.User{Id: "mover", Username: "mover", IsActive: true}, true, 0)
+	require.NoError(t, err)
+	_, err = storage.RemoveTeamMember(ctx, "team1", "mover", 0)
+	require.NoError(t, err)
+
+	t.Run("intervals are recorded", func(t *testing.T) {
+		history, err := storage.GetMembershipHistory(ctx, "team1")
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+
+		for _, interval := range history {
+			if interval.UserId == "mover" {
+				assert.NotNil(t, interval.LeftAt)
+			} else {
+				assert.Nil(t, interval.LeftAt)
+			}
+		}
+	})
+
+	t.Run("statistics keep the team at assignment time", func(t *testing.T) {
+		stats, err := prStorage.GetReviewStatistics(ctx)
+		require.NoError(t, err)
+
+		reviews := map[string]int{}
+		for _, team := range stats.TeamStats {
+			reviews[team.TeamName] = team.ReviewsAssigned
+		}
+		assert.Equal(t, 1, reviews["team1"])
+		assert.Equal(t, 0, reviews["team2"])
+	})
+
+	t.Run("renaming a team carries its history", func(t *testing.T) {
+		_, err := storage.UpdateTeam(ctx, "team2", &models.TeamUpdate{Name: "team3"}, 0)
+		require.NoError(t, err)
+
+		history, err := storage.GetMembershipHistory(ctx, "team3")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, "mover", history[0].UserId)
+	})
+
+	t.Run("deleting a team keeps its history and review attribution", func(t *testing.T) {
+		_, err := storage.DeleteTeam(ctx, "team1", models.OpenReviewsReject, 0)
+		require.NoError(t, err)
+
+		history, err := storage.GetMembershipHistory(ctx, "team1")
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		for _, interval := range history {
+			assert.NotNil(t, interval.LeftAt)
+		}
+
+		var teamName *string
+		err = pool.QueryRow(ctx, "SELECT team_name FROM pull_request_reviewers WHERE pr_id = $1 AND user_id = $2", "pr1", "mover").Scan(&teamName)
+		require.NoError(t, err)
+		require.NotNil(t, teamName)
+		assert.Equal(t, "team1", *teamName)
+
+		stats, err := prStorage.GetReviewStatistics(ctx)
+		require.NoError(t, err)
+		var deleted *models.TeamStatistics
+		for i := range stats.TeamStats {
+			if stats.TeamStats[i].TeamName == "team1" {
+				deleted = &stats.TeamStats[i]
+			}
+		}
+		require.NotNil(t, deleted)
+		assert.True(t, deleted.Deleted)
+		assert.Equal(t, 1, deleted.ReviewsAssigned)
+		assert.Equal(t, 1, deleted.SubtreeReviewsAssigned)
+		assert.Equal(t, 0, deleted.MemberCount)
+	})
+
+	t.Run("team not found", func(t *testing.T) {
+		_, err := storage.GetMembershipHistory(ctx, "missing")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestTeamStorage_BatchLoading(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewTeamStorage(pool, logger)
+	userStorage := postgres.NewUserStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := storage.CreateTeam(ctx, &models.Team{
+		Name: "backend",
+		Users: []*models.User{
+			{Id: "user1", Username: "bob", IsActive: true},
+			{Id: "user2", Username: "alice", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = storage.CreateTeam(ctx, &models.Team{Name: "payments", ParentTeam: "backend"})
+	require.NoError(t, err)
+	_, err = storage.CreateTeam(ctx, &models.Team{Name: "billing", ParentTeam: "backend"})
+	require.NoError(t, err)
+
+	_, err = storage.AddTeamMember(ctx, "payments", &models.User{Id: "user1", Username: "bob", IsActive: true}, false, 0)
+	require.NoError(t, err)
+
+	t.Run("teams by names", func(t *testing.T) {
+		teams, err := storage.GetTeamsByNames(ctx, []string{"payments", "missing"})
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, "backend", teams[0].ParentTeam)
+		assert.Positive(t, teams[0].Version)
+	})
+
+	t.Run("subteams", func(t *testing.T) {
+		teams, err := storage.GetSubteams(ctx, []string{"backend", "payments"})
+		require.NoError(t, err)
+		require.Len(t, teams, 2)
+		assert.Equal(t, "billing", teams[0].Name)
+		assert.Equal(t, "payments", teams[1].Name)
+	})
+
+	t.Run("members", func(t *testing.T) {
+		members, err := storage.GetTeamMembers(ctx, []string{"backend", "payments", "billing"})
+		require.NoError(t, err)
+		require.Len(t, members["backend"], 2)
+		assert.Equal(t, "alice", members["backend"][0].Username)
+		require.Len(t, members["payments"], 1)
+		assert.Equal(t, "backend", members["payments"][0].TeamName)
+		assert.Empty(t, members["billing"])
+	})
+
+	t.Run("users by IDs", func(t *testing.T) {
+		users, err := userStorage.GetUsersByIDs(ctx, []string{"user1", "missing"})
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, "backend", users[0].TeamName)
+		assert.Equal(t, []string{"backend", "payments"}, users[0].Teams)
+	})
+}
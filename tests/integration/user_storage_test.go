@@ -102,7 +102,7 @@ func TestUserStorage_GetUsersByTeam(t *testing.T) {
 	_, err = storage.CreateUser(ctx, user1)
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "UPDATE users SET team_name = $1 WHERE id = $2", "team1", "user1")
+	_, err = pool.Exec(ctx, "INSERT INTO team_memberships (team_name, user_id, is_primary) VALUES ($1, $2, TRUE)", "team1", "user1")
 	require.NoError(t, err)
 
 	user2 := &models.User{
@@ -114,7 +114,7 @@ func TestUserStorage_GetUsersByTeam(t *testing.T) {
 	_, err = storage.CreateUser(ctx, user2)
 	require.NoError(t, err)
 
-	_, err = pool.Exec(ctx, "UPDATE users SET team_name = $1 WHERE id = $2", "team1", "user2")
+	_, err = pool.Exec(ctx, "INSERT INTO team_memberships (team_name, user_id, is_primary) VALUES ($1, $2, TRUE)", "team1", "user2")
 	require.NoError(t, err)
 
 	t.Run("get users by team", func(t *testing.T) {
@@ -123,3 +123,221 @@ func TestUserStorage_GetUsersByTeam(t *testing.T) {
 		assert.Len(t, users, 2)
 	})
 }
+
+func TestUserStorage_UpdateUser(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := teamStorage.CreateTeam(ctx, &models.Team{
+		Name: "team1",
+		Users: []*models.User{
+			{Id: "user1", Username: "user1", IsActive: true},
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "backup1", Username: "backup1", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = teamStorage.CreateTeam(ctx, &models.Team{Name: "team2"})
+	require.NoError(t, err)
+
+	err = prStorage.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		Status:          models.OPEN,
+		TeamName:        "team1",
+	}, []string{"user1"})
+	require.NoError(t, err)
+
+	t.Run("username and activity", func(t *testing.T) {
+		username := "renamed"
+		isActive := false
+		user, err := storage.UpdateUser(ctx, "user1", &models.UserUpdate{Username: &username, IsActive: &isActive})
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", user.Username)
+		assert.False(t, user.IsActive)
+		assert.Equal(t, "team1", user.TeamName)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backup1"}, pr.AssignedReviewers, "deactivation reassigns open reviews")
+	})
+
+	t.Run("primary team", func(t *testing.T) {
+		teamName := "team2"
+		user, err := storage.UpdateUser(ctx, "user1", &models.UserUpdate{TeamName: &teamName})
+		require.NoError(t, err)
+		assert.Equal(t, "team2", user.TeamName)
+		assert.Equal(t, []string{"team2", "team1"}, user.Teams)
+	})
+
+	t.Run("unknown team", func(t *testing.T) {
+		teamName := "missing"
+		_, err := storage.UpdateUser(ctx, "user1", &models.UserUpdate{TeamName: &teamName})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestUserStorage_DeleteUser(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := teamStorage.CreateTeam(ctx, &models.Team{
+		Name: "team1",
+		Users: []*models.User{
+			{Id: "author1", Username: "author1", IsActive: true},
+			{Id: "leaver", Username: "leaver", IsActive: true},
+			{Id: "backup1", Username: "backup1", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	err = prStorage.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		Status:          models.OPEN,
+		TeamName:        "team1",
+	}, []string{"leaver"})
+	require.NoError(t, err)
+
+	t.Run("open reviews are reassigned", func(t *testing.T) {
+		result, err := storage.DeleteUser(ctx, "leaver")
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Reassigned)
+		assert.Equal(t, 0, result.Unassigned)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backup1"}, pr.AssignedReviewers)
+	})
+
+	t.Run("deleted user is hidden", func(t *testing.T) {
+		user, err := storage.GetUserByID(ctx, "leaver")
+		require.NoError(t, err)
+		assert.Nil(t, user)
+
+		members, err := storage.GetUsersByTeam(ctx, "team1")
+		require.NoError(t, err)
+		for _, member := range members {
+			assert.NotEqual(t, "leaver", member.Id)
+		}
+
+		var exists bool
+		err = pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", "leaver").Scan(&exists)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("deleted user cannot rejoin a team", func(t *testing.T) {
+		_, err := teamStorage.AddTeamMember(ctx, "team1", &models.User{Id: "leaver", Username: "leaver", IsActive: true}, false, 0)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "deleted")
+	})
+
+	t.Run("delete twice", func(t *testing.T) {
+		_, err := storage.DeleteUser(ctx, "leaver")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("review without a replacement is dropped", func(t *testing.T) {
+		_, err := teamStorage.CreateTeam(ctx, &models.Team{
+			Name:  "solo",
+			Users: []*models.User{{Id: "solo1", Username: "solo1", IsActive: true}},
+		})
+		require.NoError(t, err)
+
+		err = prStorage.CreatePullRequest(ctx, &models.PullRequest{
+			PullRequestId:   "pr2",
+			PullRequestName: "Solo PR",
+			AuthorId:        "author1",
+			Status:          models.OPEN,
+			TeamName:        "solo",
+		}, []string{"solo1"})
+		require.NoError(t, err)
+
+		result, err := storage.DeleteUser(ctx, "solo1")
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Reassigned)
+		assert.Equal(t, 1, result.Unassigned)
+
+		user, err := storage.GetUserByID(ctx, "solo1")
+		require.NoError(t, err)
+		assert.Nil(t, user)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr2")
+		require.NoError(t, err)
+		assert.Empty(t, pr.AssignedReviewers)
+	})
+}
+
+func TestUserStorage_DeactivateUser(t *testing.T) {
+	pool, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	logger := logger.NewStdLogger()
+	storage := postgres.NewUserStorage(pool, logger)
+	teamStorage := postgres.NewTeamStorage(pool, logger)
+	prStorage := postgres.NewPullRequestStorage(pool, logger)
+
+	ctx := context.Background()
+
+	_, err := teamStorage.CreateTeam(ctx, &models.Team{
+		Name:  "authors",
+		Users: []*models.User{{Id: "author1", Username: "author1", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	_, err = teamStorage.CreateTeam(ctx, &models.Team{
+		Name:  "solo",
+		Users: []*models.User{{Id: "solo1", Username: "solo1", IsActive: true}},
+	})
+	require.NoError(t, err)
+
+	err = prStorage.CreatePullRequest(ctx, &models.PullRequest{
+		PullRequestId:   "pr1",
+		PullRequestName: "Test PR",
+		AuthorId:        "author1",
+		Status:          models.OPEN,
+		TeamName:        "solo",
+	}, []string{"solo1"})
+	require.NoError(t, err)
+
+	t.Run("only member of a team is deactivated without a replacement", func(t *testing.T) {
+		result, err := storage.DeactivateUser(ctx, "solo1")
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Reassigned)
+		assert.Equal(t, 1, result.Unassigned)
+
+		user, err := storage.GetUserByID(ctx, "solo1")
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.False(t, user.IsActive)
+
+		pr, err := prStorage.GetPullRequest(ctx, "", "pr1")
+		require.NoError(t, err)
+		assert.Empty(t, pr.AssignedReviewers)
+
+		events, err := prStorage.GetPullRequestEvents(ctx, "", "pr1")
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		assert.Equal(t, models.EventReviewerUnassigned, events[len(events)-1].Type)
+	})
+}